@@ -0,0 +1,75 @@
+package soda
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+)
+
+// aliasOfExtension names the parameter a deprecated alias is bound to.
+const aliasOfExtension = "x-aliasOf"
+
+// aliasParameters returns the parameters documenting the aliases of the query parameter, e.g.
+// `query:"page_size" oai:"alias=per_page"`, deprecated names still accepted after a rename.
+func aliasParameters(parameter openapi3.Parameter, field *tagsResolver, prefix string) []*openapi3.ParameterRef {
+	aliases, ok := field.pairs[propAlias]
+	if !ok {
+		return nil
+	}
+	if parameter.In != openapi3.ParameterInQuery {
+		panic(fmt.Sprintf("the alias of the parameter %s can only be set on query parameters, not %s ones", parameter.Name, parameter.In))
+	}
+	refs := make([]*openapi3.ParameterRef, 0)
+	for _, alias := range strings.Split(aliases, SeparatorPropItem) {
+		param := parameter
+		param.Name = prefix + alias
+		param.Required = false
+		param.Deprecated = true
+		param.Description = fmt.Sprintf("Deprecated alias of %s.", parameter.Name)
+		param.Extensions = map[string]any{aliasOfExtension: parameter.Name}
+		refs = append(refs, &openapi3.ParameterRef{Value: &param})
+	}
+	return refs
+}
+
+// queryAliasesOf returns the names of the query parameters by alias.
+func queryAliasesOf(parameters openapi3.Parameters) map[string]string {
+	var aliases map[string]string
+	for _, param := range parameters {
+		name, ok := param.Value.Extensions[aliasOfExtension].(string)
+		if !ok || param.Value.In != openapi3.ParameterInQuery {
+			continue
+		}
+		if aliases == nil {
+			aliases = make(map[string]string)
+		}
+		aliases[param.Value.Name] = name
+	}
+	return aliases
+}
+
+// renameQueryAliases binds the values of the aliases to the query parameters they are aliases of, unless the
+// request sets the latter, warning the clients using them.
+func (op *OperationBuilder) renameQueryAliases(ctx *fiber.Ctx) {
+	if len(op.queryAliases) == 0 {
+		return
+	}
+	args := ctx.Request().URI().QueryArgs()
+	for _, alias := range sortedKeys(op.queryAliases) {
+		name := op.queryAliases[alias]
+		if !args.Has(alias) || args.Has(name) {
+			continue
+		}
+		var values []string
+		for _, value := range args.PeekMulti(alias) {
+			values = append(values, string(value))
+		}
+		args.Del(alias)
+		for _, value := range values {
+			args.Add(name, value)
+		}
+		AddWarning(ctx, WarnMiscellaneousPersistent, fmt.Sprintf("the query parameter %s is deprecated, use %s", alias, name))
+	}
+}
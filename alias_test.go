@@ -0,0 +1,62 @@
+package soda_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestQueryAliases(t *testing.T) {
+	Convey("Given a query parameter renamed from its aliases", t, func() {
+		type input struct {
+			PageSize int      `query:"page_size" oai:"alias=per_page,limit"`
+			Tags     []string `query:"tags"      oai:"alias=tag"`
+		}
+		engine := soda.New()
+		engine.Get("/items", func(c *fiber.Ctx) error {
+			return c.JSON(soda.GetInput[input](c))
+		}).SetInput(&input{}).OK()
+		get := func(query string) input {
+			resp, err := engine.App().Test(httptest.NewRequest("GET", "/items?"+query, nil))
+			So(err, ShouldBeNil)
+			body, _ := io.ReadAll(resp.Body)
+			var got input
+			So(json.Unmarshal(body, &got), ShouldBeNil)
+			return got
+		}
+
+		Convey("The parameter should be bound from its name or any alias", func() {
+			So(get("page_size=10").PageSize, ShouldEqual, 10)
+			So(get("per_page=20").PageSize, ShouldEqual, 20)
+			So(get("limit=30").PageSize, ShouldEqual, 30)
+			So(get("tag=a&tag=b").Tags, ShouldResemble, []string{"a", "b"})
+		})
+
+		Convey("The name should take precedence over the aliases", func() {
+			So(get("per_page=20&page_size=10").PageSize, ShouldEqual, 10)
+		})
+
+		Convey("The aliases should be documented as deprecated", func() {
+			params := engine.OpenAPI().Paths.Value("/items").Get.Parameters
+			param := params.GetByInAndName("query", "per_page")
+			So(param, ShouldNotBeNil)
+			So(param.Deprecated, ShouldBeTrue)
+			So(param.Extensions["x-aliasOf"], ShouldEqual, "page_size")
+			So(params.GetByInAndName("query", "limit"), ShouldNotBeNil)
+			So(params.GetByInAndName("query", "page_size").Deprecated, ShouldBeFalse)
+		})
+
+		Convey("An alias of a parameter outside the query should panic", func() {
+			So(func() {
+				engine.Get("/other", nil).SetInput(&struct {
+					Region string `header:"X-Region" oai:"alias=Region"`
+				}{})
+			}, ShouldPanicWith, "the alias of the parameter X-Region can only be set on query parameters, not header ones")
+		})
+	})
+}
@@ -0,0 +1,154 @@
+package soda
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+)
+
+// AsyncState is the state of a long-running operation.
+type AsyncState string
+
+const (
+	AsyncPending   AsyncState = "pending"
+	AsyncRunning   AsyncState = "running"
+	AsyncSucceeded AsyncState = "succeeded"
+	AsyncFailed    AsyncState = "failed"
+)
+
+// AsyncStatus is the status resource of a long-running operation.
+type AsyncStatus struct {
+	ID        string     `json:"id"`
+	State     AsyncState `json:"state"            oai:"enum=pending,running,succeeded,failed"`
+	Result    any        `json:"result,omitempty"`
+	Error     string     `json:"error,omitempty"`
+	CreatedAt time.Time  `json:"createdAt"`
+	UpdatedAt time.Time  `json:"updatedAt"`
+}
+
+// AsyncStore persists the status of long-running operations.
+// Load returns nil and no error when the operation does not exist.
+type AsyncStore interface {
+	Save(ctx context.Context, status *AsyncStatus) error
+	Load(ctx context.Context, id string) (*AsyncStatus, error)
+}
+
+type memoryAsyncStore struct {
+	mu       sync.RWMutex
+	statuses map[string]AsyncStatus
+}
+
+// NewMemoryAsyncStore creates an in-memory AsyncStore, suitable for a single instance or for testing.
+func NewMemoryAsyncStore() AsyncStore {
+	return &memoryAsyncStore{statuses: make(map[string]AsyncStatus)}
+}
+
+func (s *memoryAsyncStore) Save(_ context.Context, status *AsyncStatus) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.statuses[status.ID] = *status
+	return nil
+}
+
+func (s *memoryAsyncStore) Load(_ context.Context, id string) (*AsyncStatus, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	status, ok := s.statuses[id]
+	if !ok {
+		return nil, nil
+	}
+	return &status, nil
+}
+
+// AsyncManager runs long-running operations in the background and serves their status resource.
+type AsyncManager struct {
+	store      AsyncStore
+	statusPath string
+}
+
+// NewAsyncManager creates a new AsyncManager. The statusPath is the route of the status operation,
+// its ":id" parameter is replaced by the operation ID to build the Location header.
+func NewAsyncManager(store AsyncStore, statusPath string) *AsyncManager {
+	return &AsyncManager{store: store, statusPath: statusPath}
+}
+
+// Start runs the task in the background and responds with 202 Accepted,
+// the Location header pointing to the status resource.
+func (m *AsyncManager) Start(c *fiber.Ctx, task func(ctx context.Context) (any, error)) error {
+	now := time.Now()
+	status := &AsyncStatus{ID: newID(), State: AsyncPending, CreatedAt: now, UpdatedAt: now}
+	if err := m.store.Save(c.UserContext(), status); err != nil {
+		return err
+	}
+
+	go m.run(*status, task)
+
+	c.Location(strings.Replace(m.statusPath, ":id", status.ID, 1))
+	return c.Status(http.StatusAccepted).JSON(status)
+}
+
+func (m *AsyncManager) run(status AsyncStatus, task func(ctx context.Context) (any, error)) {
+	ctx := context.Background()
+	status.State = AsyncRunning
+	status.UpdatedAt = time.Now()
+	_ = m.store.Save(ctx, &status)
+
+	result, err := runTask(ctx, task)
+	status.UpdatedAt = time.Now()
+	if err != nil {
+		status.State = AsyncFailed
+		status.Error = err.Error()
+	} else {
+		status.State = AsyncSucceeded
+		status.Result = result
+	}
+	_ = m.store.Save(ctx, &status)
+}
+
+// runTask runs the task, failing it with the value of a panic rather than crashing the process.
+func runTask(ctx context.Context, task func(ctx context.Context) (any, error)) (result any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			result, err = nil, fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return task(ctx)
+}
+
+// StatusHandler serves the status resource identified by the "id" path parameter.
+func (m *AsyncManager) StatusHandler(c *fiber.Ctx) error {
+	status, err := m.store.Load(c.UserContext(), c.Params("id"))
+	if err != nil {
+		return err
+	}
+	if status == nil {
+		return fiber.ErrNotFound
+	}
+	return c.JSON(status)
+}
+
+// Async documents the operation as long-running: a 202 Accepted response carrying the AsyncStatus,
+// a Location header and a link to the status operation identified by statusOperationID.
+func (op *OperationBuilder) Async(statusOperationID string) *OperationBuilder {
+	response := op.route.gen.GenerateResponse(http.StatusAccepted, AsyncStatus{}, "application/json", "")
+	response.Headers = openapi3.Headers{
+		"Location": &openapi3.HeaderRef{Value: &openapi3.Header{Parameter: openapi3.Parameter{
+			Description: "The URL of the operation status resource.",
+			Schema:      openapi3.NewStringSchema().NewRef(),
+		}}},
+	}
+	response.Links = openapi3.Links{
+		"status": &openapi3.LinkRef{Value: &openapi3.Link{
+			OperationID: statusOperationID,
+			Parameters:  map[string]any{"id": "$response.body#/id"},
+		}},
+	}
+	op.operation.AddResponse(http.StatusAccepted, response)
+	return op
+}
@@ -0,0 +1,92 @@
+package soda_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestAsync(t *testing.T) {
+	Convey("Given an async operation and its status operation", t, func() {
+		engine := soda.New()
+		manager := soda.NewAsyncManager(soda.NewMemoryAsyncStore(), "/jobs/:id")
+		engine.Post("/jobs", func(c *fiber.Ctx) error {
+			fail, crash := c.Query("fail") != "", c.Query("panic") != ""
+			return manager.Start(c, func(ctx context.Context) (any, error) {
+				if crash {
+					panic("nil job")
+				}
+				if fail {
+					return nil, errors.New("boom")
+				}
+				return "done", nil
+			})
+		}).Async("get-job").OK()
+		engine.Get("/jobs/:id", manager.StatusHandler).SetOperationID("get-job").OK()
+
+		poll := func(location string) soda.AsyncStatus {
+			var status soda.AsyncStatus
+			for i := 0; i < 100; i++ {
+				request, _ := http.NewRequest("GET", location, nil)
+				response, _ := engine.App().Test(request)
+				body, _ := io.ReadAll(response.Body)
+				_ = json.Unmarshal(body, &status)
+				if status.State == soda.AsyncSucceeded || status.State == soda.AsyncFailed {
+					break
+				}
+				time.Sleep(time.Millisecond)
+			}
+			return status
+		}
+
+		Convey("The 202 response should be documented", func() {
+			response := engine.OpenAPI().Paths.Find("/jobs").Post.Responses.Status(http.StatusAccepted)
+			So(response, ShouldNotBeNil)
+			So(response.Value.Headers, ShouldContainKey, "Location")
+			So(response.Value.Links["status"].Value.OperationID, ShouldEqual, "get-job")
+		})
+
+		Convey("The operation should be accepted and eventually succeed", func() {
+			request, _ := http.NewRequest("POST", "/jobs", nil)
+			response, err := engine.App().Test(request)
+			So(err, ShouldBeNil)
+			So(response.StatusCode, ShouldEqual, http.StatusAccepted)
+			location := response.Header.Get("Location")
+			So(location, ShouldStartWith, "/jobs/")
+
+			status := poll(location)
+			So(status.State, ShouldEqual, soda.AsyncSucceeded)
+			So(status.Result, ShouldEqual, "done")
+		})
+
+		Convey("A failing operation should report its error", func() {
+			request, _ := http.NewRequest("POST", "/jobs?fail=1", nil)
+			response, _ := engine.App().Test(request)
+			status := poll(response.Header.Get("Location"))
+			So(status.State, ShouldEqual, soda.AsyncFailed)
+			So(status.Error, ShouldEqual, "boom")
+		})
+
+		Convey("A panicking operation should fail with the panic value", func() {
+			request, _ := http.NewRequest("POST", "/jobs?panic=1", nil)
+			response, _ := engine.App().Test(request)
+			status := poll(response.Header.Get("Location"))
+			So(status.State, ShouldEqual, soda.AsyncFailed)
+			So(status.Error, ShouldEqual, "panic: nil job")
+		})
+
+		Convey("An unknown operation should not be found", func() {
+			request, _ := http.NewRequest("GET", "/jobs/unknown", nil)
+			response, _ := engine.App().Test(request)
+			So(response.StatusCode, ShouldEqual, http.StatusNotFound)
+		})
+	})
+}
@@ -0,0 +1,109 @@
+package soda
+
+import (
+	"reflect"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+)
+
+// asyncAPIVersion is the version of the AsyncAPI documents generated.
+const asyncAPIVersion = "2.6.0"
+
+// AsyncAPI is a minimal AsyncAPI document of the events published by the service.
+type AsyncAPI struct {
+	AsyncAPI   string                      `json:"asyncapi"`
+	Info       *openapi3.Info              `json:"info"`
+	Channels   map[string]*AsyncAPIChannel `json:"channels"`
+	Components AsyncAPIComponents          `json:"components"`
+}
+
+// AsyncAPIChannel is a channel the service publishes messages to, which the consumers subscribe to.
+type AsyncAPIChannel struct {
+	Description string             `json:"description,omitempty"`
+	Subscribe   *AsyncAPIOperation `json:"subscribe"`
+}
+
+// AsyncAPIOperation is the operation of a channel.
+type AsyncAPIOperation struct {
+	OperationID string          `json:"operationId"`
+	Message     AsyncAPIMessage `json:"message"`
+}
+
+// AsyncAPIMessage is the message of an operation, whose payload is documented like the OpenAPI schemas.
+type AsyncAPIMessage struct {
+	Name        string              `json:"name"`
+	ContentType string              `json:"contentType"`
+	Payload     *openapi3.SchemaRef `json:"payload"`
+}
+
+// AsyncAPIComponents holds the schemas of the payloads.
+type AsyncAPIComponents struct {
+	Schemas openapi3.Schemas `json:"schemas"`
+}
+
+// event is an event published by the service.
+type event struct {
+	name        string
+	payload     *openapi3.SchemaRef
+	description string
+}
+
+// Event documents an event published by the service on the channel, such as "order.created", in the AsyncAPI
+// document served by ServeAsyncAPI. The schema of its payload is generated like those of the operations,
+// anonymous payload types being named after the channel.
+func (e *Engine) Event(channel string, payload any, description ...string) *Engine {
+	for _, ev := range e.events {
+		if ev.name == channel {
+			panic("event " + channel + " is already registered")
+		}
+	}
+	if e.eventsGen == nil {
+		e.eventsGen = NewGenerator()
+	}
+	t := reflect.TypeOf(payload)
+	var name []string
+	// The anonymous payload types are named after the channel.
+	if derefType(t).Name() == "" {
+		name = append(name, channel)
+	}
+	ev := event{name: channel, payload: e.eventsGen.generateSchemaRef(nil, t, "json", name...)}
+	if len(description) != 0 {
+		ev.description = description[0]
+	}
+	e.events = append(e.events, ev)
+	return e
+}
+
+// AsyncAPI returns the AsyncAPI document of the events, sharing the info of the OpenAPI document.
+func (e *Engine) AsyncAPI() *AsyncAPI {
+	doc := &AsyncAPI{
+		AsyncAPI: asyncAPIVersion,
+		Info:     e.gen.doc.Info,
+		Channels: make(map[string]*AsyncAPIChannel, len(e.events)),
+		Components: AsyncAPIComponents{
+			Schemas: openapi3.Schemas{},
+		},
+	}
+	if e.eventsGen != nil {
+		doc.Components.Schemas = e.eventsGen.doc.Components.Schemas
+	}
+	for _, ev := range e.events {
+		doc.Channels[ev.name] = &AsyncAPIChannel{
+			Description: ev.description,
+			Subscribe: &AsyncAPIOperation{
+				OperationID: ev.name,
+				Message:     AsyncAPIMessage{Name: ev.name, ContentType: fiber.MIMEApplicationJSON, Payload: ev.payload},
+			},
+		}
+	}
+	return doc
+}
+
+// ServeAsyncAPI serves the AsyncAPI document of the events as JSON, alongside the OpenAPI document.
+func (e *Engine) ServeAsyncAPI(pattern string) *Engine {
+	e.serveDoc(pattern, func(c *fiber.Ctx) error {
+		return c.JSON(e.AsyncAPI())
+	})
+	return e
+}
@@ -0,0 +1,65 @@
+package soda_test
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type orderLine struct {
+	SKU      string `json:"sku"`
+	Quantity int    `json:"quantity"`
+}
+
+type orderCreated struct {
+	OrderID string      `json:"orderId"`
+	Lines   []orderLine `json:"lines"`
+}
+
+func TestAsyncAPI(t *testing.T) {
+	Convey("Given an engine publishing events", t, func() {
+		engine := soda.New()
+		engine.OpenAPI().Info.Title = "Orders"
+		engine.OpenAPI().Info.Version = "1.0.0"
+		engine.Event("order.created", orderCreated{}, "An order was placed.")
+		engine.Event("order.cancelled", struct {
+			OrderID string `json:"orderId"`
+		}{})
+		engine.ServeAsyncAPI("/asyncapi.json")
+
+		Convey("The AsyncAPI document should describe the channels", func() {
+			resp, err := engine.App().Test(httptest.NewRequest("GET", "/asyncapi.json", nil))
+			So(err, ShouldBeNil)
+			var doc map[string]any
+			So(json.NewDecoder(resp.Body).Decode(&doc), ShouldBeNil)
+			So(doc["asyncapi"], ShouldEqual, "2.6.0")
+			So(doc["info"].(map[string]any)["title"], ShouldEqual, "Orders")
+
+			channels := doc["channels"].(map[string]any)
+			So(channels, ShouldHaveLength, 2)
+			created := channels["order.created"].(map[string]any)
+			So(created["description"], ShouldEqual, "An order was placed.")
+			message := created["subscribe"].(map[string]any)["message"].(map[string]any)
+			So(message["contentType"], ShouldEqual, "application/json")
+			So(message["payload"].(map[string]any)["$ref"], ShouldEqual, "#/components/schemas/soda_test.orderCreated")
+
+			cancelled := channels["order.cancelled"].(map[string]any)["subscribe"].(map[string]any)["message"].(map[string]any)
+			So(cancelled["payload"].(map[string]any)["$ref"], ShouldEqual, "#/components/schemas/order.cancelled")
+
+			schemas := doc["components"].(map[string]any)["schemas"].(map[string]any)
+			So(schemas, ShouldContainKey, "soda_test.orderCreated")
+			So(schemas, ShouldContainKey, "soda_test.orderLine")
+		})
+
+		Convey("The payload schemas should not leak into the OpenAPI document", func() {
+			So(engine.OpenAPI().Components.Schemas, ShouldNotContainKey, "soda_test.orderCreated")
+		})
+
+		Convey("Registering an event twice should panic", func() {
+			So(func() { engine.Event("order.created", orderCreated{}) }, ShouldPanicWith, "event order.created is already registered")
+		})
+	})
+}
@@ -0,0 +1,85 @@
+package soda
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// KeyActor is the key of the actor of the request in the context locals, set by SetActor.
+const KeyActor ck = "soda::actor"
+
+// AuditRecord records a request to an audited operation.
+type AuditRecord struct {
+	Time        time.Time
+	Duration    time.Duration
+	OperationID string
+	Method      string
+	Path        string
+	// Actor is the actor set by the security handler of the request, see SetActor.
+	Actor string
+	// Input is the bound input with the sensitive fields redacted, see Redact. It is nil when the request
+	// was rejected before binding.
+	Input  any
+	Status int
+}
+
+// AuditSink receives the audit records, e.g. to write them to a compliance log.
+// It is called synchronously once the response is written.
+type AuditSink func(c *fiber.Ctx, record AuditRecord)
+
+// UseAudit records the requests of the operations finalized afterwards to the sink.
+func (e *Engine) UseAudit(sink AuditSink) *Engine {
+	e.auditSink = sink
+	return e
+}
+
+// SetActor sets the actor of the request, e.g. the subject of the token verified by a security handler,
+// as recorded by the audit.
+func SetActor(c *fiber.Ctx, actor string) {
+	c.Locals(KeyActor, actor)
+}
+
+// Actor returns the actor of the request, set by SetActor.
+func Actor(c *fiber.Ctx) string {
+	actor, _ := c.Locals(KeyActor).(string)
+	return actor
+}
+
+// audit returns the handler recording the requests of the operation to the sink.
+func (op *OperationBuilder) audit(sink AuditSink) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+		err := c.Next()
+		record := AuditRecord{
+			Time:        start,
+			Duration:    time.Since(start),
+			OperationID: op.operation.OperationID,
+			Method:      c.Method(),
+			Path:        c.Path(),
+			Actor:       Actor(c),
+			Input:       Redact(c.Locals(KeyInput)),
+			Status:      auditStatus(c, err),
+		}
+		sink(c, record)
+		return err
+	}
+}
+
+// auditStatus returns the status of the response, which is written by the error handler when the chain failed.
+func auditStatus(c *fiber.Ctx, err error) int {
+	if err == nil {
+		return c.Response().StatusCode()
+	}
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.Status
+	}
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		return fiberErr.Code
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,81 @@
+package soda_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type auditedInput struct {
+	ID   int `path:"id"`
+	Body struct {
+		Name     string `json:"name"`
+		Password string `json:"password" oai:"sensitive"`
+	} `body:"json"`
+}
+
+func TestAudit(t *testing.T) {
+	Convey("Given an audited engine", t, func() {
+		var records []soda.AuditRecord
+		engine := soda.New().UseAudit(func(c *fiber.Ctx, record soda.AuditRecord) {
+			records = append(records, record)
+		})
+		authenticate := func(c *fiber.Ctx) error {
+			if c.Get("Authorization") == "" {
+				return soda.NewError(http.StatusUnauthorized, "unauthorized", "missing credentials")
+			}
+			soda.SetActor(c, strings.TrimPrefix(c.Get("Authorization"), "Bearer "))
+			return nil
+		}
+		engine.Put("/users/:id", func(c *fiber.Ctx) error {
+			return c.SendStatus(http.StatusNoContent)
+		}).SetOperationID("update-user").SetInput(auditedInput{}).OnBeforeBind(authenticate).OK()
+
+		call := func(token string) {
+			req := httptest.NewRequest("PUT", "/users/7", strings.NewReader(`{"name":"ada","password":"secret"}`))
+			req.Header.Set("Content-Type", "application/json")
+			if token != "" {
+				req.Header.Set("Authorization", "Bearer "+token)
+			}
+			_, err := engine.App().Test(req)
+			So(err, ShouldBeNil)
+		}
+
+		Convey("The requests should be recorded with the redacted input", func() {
+			call("ada-token")
+			So(records, ShouldHaveLength, 1)
+			record := records[0]
+			So(record.OperationID, ShouldEqual, "update-user")
+			So(record.Method, ShouldEqual, "PUT")
+			So(record.Path, ShouldEqual, "/users/7")
+			So(record.Actor, ShouldEqual, "ada-token")
+			So(record.Status, ShouldEqual, http.StatusNoContent)
+			So(record.Input, ShouldResemble, map[string]any{
+				"ID":   7,
+				"Body": map[string]any{"name": "ada", "password": soda.RedactedValue},
+			})
+		})
+
+		Convey("The rejected requests should be recorded without input", func() {
+			call("")
+			So(records, ShouldHaveLength, 1)
+			So(records[0].Actor, ShouldBeEmpty)
+			So(records[0].Input, ShouldBeNil)
+			So(records[0].Status, ShouldEqual, http.StatusUnauthorized)
+		})
+
+		Convey("The operations finalized before should not be audited", func() {
+			engine := soda.New()
+			engine.Get("/before", func(c *fiber.Ctx) error { return nil }).OK()
+			engine.UseAudit(func(c *fiber.Ctx, record soda.AuditRecord) { records = append(records, record) })
+			_, err := engine.App().Test(httptest.NewRequest("GET", "/before", nil))
+			So(err, ShouldBeNil)
+			So(records, ShouldBeEmpty)
+		})
+	})
+}
@@ -0,0 +1,142 @@
+package soda_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+)
+
+type benchQueryInput struct {
+	Limit  int      `query:"limit"`
+	Offset int      `query:"offset"`
+	Sort   string   `query:"sort"`
+	Tags   []string `query:"tags"`
+}
+
+type benchPathInput struct {
+	ID      int    `path:"id"`
+	Version string `header:"X-Version"`
+}
+
+type benchBodyInput struct {
+	ID   int `path:"id"`
+	Body struct {
+		Name    string            `json:"name"`
+		Email   string            `json:"email"`
+		Age     int               `json:"age"`
+		Tags    []string          `json:"tags"`
+		Profile map[string]string `json:"profile"`
+	} `body:"json"`
+}
+
+const benchBody = `{"name":"Ada","email":"ada@example.com","age":36,"tags":["a","b"],"profile":{"lang":"en"}}`
+
+// benchEngine registers the representative operations the binding pipeline is benchmarked with.
+func benchEngine() *soda.Engine {
+	engine := soda.New()
+	handler := func(c *fiber.Ctx) error { return c.SendStatus(http.StatusNoContent) }
+	engine.Get("/query", handler).SetInput(benchQueryInput{}).OK()
+	engine.Get("/path/:id", handler).SetInput(benchPathInput{}).OK()
+	engine.Post("/body/:id", handler).SetInput(benchBodyInput{}).OK()
+	engine.Get("/none", handler).OK()
+	engine.Get("/params", func(c *fiber.Ctx) error {
+		params := soda.GetParams(c)
+		if _, err := params.GetInt("limit"); err != nil {
+			return err
+		}
+		if _, err := params.GetInt("offset"); err != nil {
+			return err
+		}
+		_ = params.GetString("sort")
+		return c.SendStatus(http.StatusNoContent)
+	}).SetParams(benchQueryInput{}).OK()
+	return engine
+}
+
+// benchRequests are the requests of the benchmarks by name.
+var benchRequests = map[string]func() *http.Request{
+	"Query": func() *http.Request {
+		return httptest.NewRequest("GET", "/query?limit=10&offset=20&sort=name&tags=a&tags=b", nil)
+	},
+	"Path": func() *http.Request {
+		req := httptest.NewRequest("GET", "/path/42", nil)
+		req.Header.Set("X-Version", "2")
+		return req
+	},
+	"Body": func() *http.Request {
+		req := httptest.NewRequest("POST", "/body/42", strings.NewReader(benchBody))
+		req.Header.Set("Content-Type", "application/json")
+		return req
+	},
+	"None": func() *http.Request {
+		return httptest.NewRequest("GET", "/none", nil)
+	},
+	"Params": func() *http.Request {
+		return httptest.NewRequest("GET", "/params?limit=10&offset=20&sort=name&tags=a&tags=b", nil)
+	},
+}
+
+// raceEnabled reports whether the tests run with the race detector, see race_test.go.
+var raceEnabled bool
+
+// allocationBudgets are the maximum allocations per request of the benchmarks, including those of fiber and of the
+// test transport, checked by TestAllocationBudgets so that regressions of the binding pipeline fail the CI.
+var allocationBudgets = map[string]float64{
+	"None":   40,
+	"Query":  95,
+	"Path":   80,
+	"Body":   90,
+	"Params": 40,
+}
+
+func BenchmarkBinding(b *testing.B) {
+	engine := benchEngine()
+	for _, name := range []string{"None", "Query", "Params", "Path", "Body"} {
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				resp, err := engine.App().Test(benchRequests[name](), -1)
+				if err != nil || resp.StatusCode != http.StatusNoContent {
+					b.Fatalf("unexpected response: %v %v", resp, err)
+				}
+			}
+		})
+	}
+}
+
+func TestAllocationBudgets(t *testing.T) {
+	if testing.Short() {
+		t.Skip("allocation budgets are not checked in short mode")
+	}
+	if raceEnabled {
+		t.Skip("allocation budgets are not checked with the race detector, which allocates")
+	}
+	engine := benchEngine()
+	for name, budget := range allocationBudgets {
+		allocs := testing.AllocsPerRun(100, func() {
+			_, _ = engine.App().Test(benchRequests[name](), -1)
+		})
+		if allocs > budget {
+			t.Errorf("%s: %.0f allocations per request exceed the budget of %.0f", name, allocs, budget)
+		}
+		t.Logf("%s: %.0f allocations per request, budget %.0f", name, allocs, budget)
+	}
+}
+
+func BenchmarkRegistration(b *testing.B) {
+	b.ReportAllocs()
+	handler := func(c *fiber.Ctx) error { return nil }
+	for i := 0; i < b.N; i++ {
+		engine := soda.New()
+		for j := 0; j < 100; j++ {
+			path := "/resources" + strconv.Itoa(j)
+			engine.Get(path, handler).SetInput(benchQueryInput{}).OK()
+			engine.Post(path+"/:id", handler).SetInput(benchBodyInput{}).AddJSONResponse(http.StatusOK, benchQueryInput{}).OK()
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package soda
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Binder is implemented by the inputs whose parameters are bound by static code generated with soda-gen
+// (github.com/neo-f/soda/v3/cmd/soda-gen), which the operations prefer to binding them by reflection.
+// The request body is still bound by soda.
+type Binder interface {
+	BindParams(c *fiber.Ctx) error
+}
+
+// paramError is an error binding a parameter.
+type paramError struct {
+	in   string
+	name string
+	err  error
+}
+
+// ParamError returns the error of the invalid value of the parameter in the location, such as "query",
+// reported by soda like the errors binding the parameters by reflection. It is used by the generated binders.
+func ParamError(in, name string, err error) error {
+	return &paramError{in: in, name: name, err: err}
+}
+
+func (e *paramError) Error() string {
+	return fmt.Sprintf("%s: %v", e.name, e.err)
+}
+
+func (e *paramError) Unwrap() error {
+	return e.err
+}
+
+// bindGenerated binds the parameters of the input with its generated binder.
+func (op *OperationBuilder) bindGenerated(ctx *fiber.Ctx, binder Binder) error {
+	err := binder.BindParams(ctx)
+	if err == nil {
+		return nil
+	}
+	var pe *paramError
+	if errors.As(err, &pe) {
+		return op.bindError(ctx, paramMessages[pe.in], err)
+	}
+	return op.bindError(ctx, MsgInvalidQuery, err)
+}
@@ -0,0 +1,59 @@
+// Code generated by soda-gen. DO NOT EDIT.
+
+package soda_test
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+)
+
+// BindParams binds and validates the parameters of generatedInput without reflection.
+func (in *generatedInput) BindParams(c *fiber.Ctx) error {
+	if v := c.Params("id"); v != "" {
+		v, err := soda.Convert("base62", v)
+		if err != nil {
+			return soda.ParamError("path", "id", err)
+		}
+		x, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return soda.ParamError("path", "id", err)
+		}
+		in.ID = x
+	}
+	if v := c.Query("limit"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 0)
+		if err != nil {
+			return soda.ParamError("query", "limit", err)
+		}
+		x := int(n)
+		in.Limit = &x
+	}
+	for _, b := range c.Context().QueryArgs().PeekMulti("tags") {
+		v := string(b)
+		x := strings.Clone(v)
+		in.Tags = append(in.Tags, x)
+	}
+	if v := c.Query("ratio"); v != "" {
+		n, err := strconv.ParseFloat(v, 32)
+		if err != nil {
+			return soda.ParamError("query", "ratio", err)
+		}
+		x := float32(n)
+		in.Ratio = x
+	}
+	if v := c.Get("Active"); v != "" {
+		x, err := strconv.ParseBool(v)
+		if err != nil {
+			return soda.ParamError("header", "Active", err)
+		}
+		in.Active = x
+	}
+	if v := c.Cookies("token"); v != "" {
+		x := strings.Clone(v)
+		in.Token = x
+	}
+	return nil
+}
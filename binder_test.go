@@ -0,0 +1,84 @@
+package soda_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+//go:generate go run ./cmd/soda-gen -type generatedInput -output binder_gen_test.go
+
+type generatedInput struct {
+	ID     int64    `path:"id"       json:"id" oai:"converter=base62"`
+	Limit  *int     `query:"limit"   json:"limit"`
+	Tags   []string `query:"tags"    json:"tags"`
+	Ratio  float32  `query:"ratio"   json:"ratio"`
+	Active bool     `header:"Active" json:"active"`
+	Token  string   `cookie:"token"  json:"token"`
+	Body   struct {
+		Name string `json:"name"`
+	} `body:"json" json:"body"`
+}
+
+func TestGeneratedBinder(t *testing.T) {
+	Convey("Given an input with a generated binder", t, func() {
+		var _ soda.Binder = &generatedInput{}
+		engine := soda.New().SetBindErrorStatus(http.StatusBadRequest)
+		engine.Post("/items/:id", func(c *fiber.Ctx) error {
+			return c.JSON(soda.GetInput[generatedInput](c))
+		}).SetInput(generatedInput{}).OK()
+
+		post := func(target string) (int, map[string]any) {
+			req := httptest.NewRequest("POST", target, nil)
+			req.Header.Set("Active", "true")
+			req.Header.Set("Content-Type", "application/json")
+			req.AddCookie(&http.Cookie{Name: "token", Value: "secret"})
+			resp, err := engine.App().Test(req)
+			So(err, ShouldBeNil)
+			data, _ := io.ReadAll(resp.Body)
+			var body map[string]any
+			_ = json.Unmarshal(data, &body)
+			return resp.StatusCode, body
+		}
+
+		Convey("The parameters should be bound by the generated binder", func() {
+			req := httptest.NewRequest("POST", "/items/7?limit=3&tags=a&tags=b&ratio=0.5", strings.NewReader(`{"name":"pen"}`))
+			req.Header.Set("Active", "true")
+			req.Header.Set("Content-Type", "application/json")
+			req.AddCookie(&http.Cookie{Name: "token", Value: "secret"})
+
+			resp, err := engine.App().Test(req)
+			So(err, ShouldBeNil)
+			data, _ := io.ReadAll(resp.Body)
+			So(string(data), ShouldEqual, `{"id":7,"limit":3,"tags":["a","b"],"ratio":0.5,"active":true,"token":"secret","body":{"name":"pen"}}`)
+		})
+
+		Convey("Invalid values should be reported like the reflection binding does", func() {
+			status, body := post("/items/7?limit=many")
+			So(status, ShouldEqual, http.StatusBadRequest)
+			So(body["code"], ShouldEqual, soda.MsgInvalidQuery)
+			So(body["message"], ShouldContainSubstring, "limit")
+			status, body = post("/items/se-ven")
+			So(status, ShouldEqual, http.StatusBadRequest)
+			So(body["code"], ShouldEqual, soda.MsgInvalidPath)
+			So(body["message"], ShouldContainSubstring, "invalid base62 ID")
+		})
+
+		Convey("The path parameters should be converted before they are bound", func() {
+			req := httptest.NewRequest("POST", "/items/1z", strings.NewReader(`{"name":"pen"}`))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := engine.App().Test(req)
+			So(err, ShouldBeNil)
+			data, _ := io.ReadAll(resp.Body)
+			So(string(data), ShouldStartWith, `{"id":123,`)
+		})
+	})
+}
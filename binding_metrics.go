@@ -0,0 +1,170 @@
+package soda
+
+import (
+	"log/slog"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// BindingMetricsConfig configures the instrumentation of the binding of the inputs.
+type BindingMetricsConfig struct {
+	// SlowThreshold is the duration of the binding of a request above which a warning is logged, none if zero.
+	SlowThreshold time.Duration
+	// Logger logs the slow bindings, slog.Default() if nil.
+	Logger *slog.Logger
+}
+
+// BindingStats are the binding metrics of an operation.
+type BindingStats struct {
+	OperationID string `json:"operationId"`
+	Method      string `json:"method"`
+	Path        string `json:"path"`
+	// Requests counts the bound requests, Errors those whose binding or validation failed, and Slow those whose
+	// binding exceeded the slow threshold.
+	Requests uint64 `json:"requests"`
+	Errors   uint64 `json:"errors"`
+	Slow     uint64 `json:"slow"`
+	// BindDuration and ValidateDuration are the total durations of the binding of the parameters and the body, and
+	// of the validation of the body, and MaxDuration the longest binding of a request.
+	BindDuration     time.Duration `json:"bindDuration"`
+	ValidateDuration time.Duration `json:"validateDuration"`
+	MaxDuration      time.Duration `json:"maxDuration"`
+}
+
+// ErrorRate returns the ratio of the requests whose binding failed, 0 if none was bound.
+func (s BindingStats) ErrorRate() float64 {
+	if s.Requests == 0 {
+		return 0
+	}
+	return float64(s.Errors) / float64(s.Requests)
+}
+
+// bindingMetrics accumulates the binding metrics of an operation.
+type bindingMetrics struct {
+	operationID, method, path string
+	requests, errors, slow    atomic.Uint64
+	bindNanos, validateNanos  atomic.Int64
+	maxNanos                  atomic.Int64
+	slowThreshold             time.Duration
+	logger                    *slog.Logger
+}
+
+// bindingSample times the binding of a request, the zero value being a disabled one.
+type bindingSample struct {
+	start, validating time.Time
+}
+
+// EnableBindingMetrics records the durations and the errors of the binding of the inputs of the operations
+// registered afterwards, reported by Engine.BindingStats, and logs a warning for the slow ones.
+func (e *Engine) EnableBindingMetrics(cfg BindingMetricsConfig) *Engine {
+	e.bindingMetricsConfig = &cfg
+	return e
+}
+
+// addBindingMetrics returns the binding metrics of the operation, if enabled.
+func (e *Engine) addBindingMetrics(op *OperationBuilder) *bindingMetrics {
+	cfg := e.bindingMetricsConfig
+	if cfg == nil {
+		return nil
+	}
+	m := &bindingMetrics{
+		operationID:   op.operation.OperationID,
+		method:        op.method,
+		path:          op.docPath,
+		slowThreshold: cfg.SlowThreshold,
+		logger:        cfg.Logger,
+	}
+	e.bindingsMu.Lock()
+	defer e.bindingsMu.Unlock()
+	e.bindings = append(e.bindings, m)
+	return m
+}
+
+// BindingStats reports the binding metrics of the operations, sorted by path and method.
+func (e *Engine) BindingStats() []BindingStats {
+	e.bindingsMu.Lock()
+	defer e.bindingsMu.Unlock()
+	stats := make([]BindingStats, 0, len(e.bindings))
+	for _, m := range e.bindings {
+		stats = append(stats, BindingStats{
+			OperationID:      m.operationID,
+			Method:           m.method,
+			Path:             m.path,
+			Requests:         m.requests.Load(),
+			Errors:           m.errors.Load(),
+			Slow:             m.slow.Load(),
+			BindDuration:     time.Duration(m.bindNanos.Load()),
+			ValidateDuration: time.Duration(m.validateNanos.Load()),
+			MaxDuration:      time.Duration(m.maxNanos.Load()),
+		})
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Path != stats[j].Path {
+			return stats[i].Path < stats[j].Path
+		}
+		return stats[i].Method < stats[j].Method
+	})
+	return stats
+}
+
+// startBinding starts timing the binding of a request, if the binding metrics of the operation are enabled.
+func (op *OperationBuilder) startBinding() bindingSample {
+	if op.bindingMetrics == nil {
+		return bindingSample{}
+	}
+	return bindingSample{start: time.Now()}
+}
+
+// validate marks the start of the validation of the body.
+func (s *bindingSample) validate() {
+	if !s.start.IsZero() {
+		s.validating = time.Now()
+	}
+}
+
+// recordBinding records the binding of a request and returns its error, logging a warning if it was slow.
+func (op *OperationBuilder) recordBinding(c *fiber.Ctx, s bindingSample, err error) error {
+	m := op.bindingMetrics
+	if m == nil || s.start.IsZero() {
+		return err
+	}
+	end := time.Now()
+	bind, validate := end.Sub(s.start), time.Duration(0)
+	if !s.validating.IsZero() {
+		bind, validate = s.validating.Sub(s.start), end.Sub(s.validating)
+	}
+	total := bind + validate
+	m.requests.Add(1)
+	if err != nil {
+		m.errors.Add(1)
+	}
+	m.bindNanos.Add(int64(bind))
+	m.validateNanos.Add(int64(validate))
+	for {
+		longest := m.maxNanos.Load()
+		if int64(total) <= longest || m.maxNanos.CompareAndSwap(longest, int64(total)) {
+			break
+		}
+	}
+	if m.slowThreshold > 0 && total > m.slowThreshold {
+		m.slow.Add(1)
+		logger := m.logger
+		if logger == nil {
+			logger = slog.Default()
+		}
+		logger.Warn("slow binding",
+			slog.String("operationId", m.operationID),
+			slog.String("method", m.method),
+			slog.String("path", c.Path()),
+			slog.Duration("duration", total),
+			slog.Duration("bind", bind),
+			slog.Duration("validate", validate),
+			slog.Int("bodySize", len(c.Body())),
+			slog.Bool("failed", err != nil),
+		)
+	}
+	return err
+}
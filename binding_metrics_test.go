@@ -0,0 +1,82 @@
+package soda_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type bindingMetricsInput struct {
+	Body struct {
+		Name string `json:"name" oai:"minLength=1"`
+	} `body:"json"`
+}
+
+func TestBindingMetrics(t *testing.T) {
+	Convey("Given operations registered with the binding metrics", t, func() {
+		var logs bytes.Buffer
+		engine := soda.New().SetBindErrorStatus(http.StatusBadRequest).EnableBindingMetrics(soda.BindingMetricsConfig{
+			SlowThreshold: time.Nanosecond,
+			Logger:        slog.New(slog.NewJSONHandler(&logs, nil)),
+		})
+		engine.Post("/users", func(c *fiber.Ctx) error {
+			return c.SendStatus(http.StatusCreated)
+		}).SetInput(bindingMetricsInput{}).SetOperationID("createUser").OK()
+		create := func(body string) int {
+			req := httptest.NewRequest("POST", "/users", strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := engine.App().Test(req)
+			So(err, ShouldBeNil)
+			return resp.StatusCode
+		}
+
+		Convey("The bindings and their errors should be reported per operation", func() {
+			So(create(`{"name":"neo"}`), ShouldEqual, http.StatusCreated)
+			So(create(`{"name":`), ShouldEqual, http.StatusBadRequest)
+
+			stats := engine.BindingStats()
+			So(stats, ShouldHaveLength, 1)
+			So(stats[0].OperationID, ShouldEqual, "createUser")
+			So(stats[0].Method, ShouldEqual, "POST")
+			So(stats[0].Path, ShouldEqual, "/users")
+			So(stats[0].Requests, ShouldEqual, 2)
+			So(stats[0].Errors, ShouldEqual, 1)
+			So(stats[0].ErrorRate(), ShouldEqual, 0.5)
+			So(stats[0].MaxDuration, ShouldBeGreaterThan, 0)
+			So(stats[0].BindDuration+stats[0].ValidateDuration, ShouldBeGreaterThanOrEqualTo, stats[0].MaxDuration)
+		})
+
+		Convey("The slow bindings should be logged", func() {
+			So(create(`{"name":"neo"}`), ShouldEqual, http.StatusCreated)
+			So(engine.BindingStats()[0].Slow, ShouldEqual, 1)
+
+			var record map[string]any
+			So(json.Unmarshal(logs.Bytes(), &record), ShouldBeNil)
+			So(record["level"], ShouldEqual, "WARN")
+			So(record["msg"], ShouldEqual, "slow binding")
+			So(record["operationId"], ShouldEqual, "createUser")
+			So(record["path"], ShouldEqual, "/users")
+			So(record["bodySize"], ShouldEqual, 14)
+		})
+	})
+
+	Convey("Given operations registered without the binding metrics", t, func() {
+		engine := soda.New()
+		engine.Post("/users", func(c *fiber.Ctx) error {
+			return c.SendStatus(http.StatusCreated)
+		}).SetInput(bindingMetricsInput{}).OK()
+
+		Convey("No binding should be reported", func() {
+			So(engine.BindingStats(), ShouldBeEmpty)
+		})
+	})
+}
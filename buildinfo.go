@@ -0,0 +1,57 @@
+package soda
+
+import (
+	"runtime/debug"
+)
+
+// Build metadata of the application, meant to be set at link time, such as:
+//
+//	go build -ldflags "-X github.com/neo-f/soda/v3.BuildVersion=v1.2.3 -X github.com/neo-f/soda/v3.BuildRevision=$(git rev-parse HEAD)"
+//
+// They take precedence over the metadata embedded by the go command, which is used by UseBuildInfo otherwise.
+var (
+	BuildVersion  string
+	BuildRevision string
+	BuildTime     string
+)
+
+// buildInfoExtension is the extension of the spec info holding the build metadata.
+const buildInfoExtension = "x-build"
+
+// UseBuildInfo fills the version of the spec, unless already set, and its x-build extension from the build
+// metadata of the application, so that consumers can correlate a deployed spec with a revision.
+// It must be called before the spec is served.
+func (e *Engine) UseBuildInfo() *Engine {
+	build := make(map[string]any)
+	if info, ok := debug.ReadBuildInfo(); ok {
+		if v := info.Main.Version; v != "" && v != "(devel)" {
+			build["version"] = v
+		}
+		build["goVersion"] = info.GoVersion
+		for _, setting := range info.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				build["revision"] = setting.Value
+			case "vcs.time":
+				build["time"] = setting.Value
+			case "vcs.modified":
+				build["modified"] = setting.Value == "true"
+			}
+		}
+	}
+	for key, value := range map[string]string{"version": BuildVersion, "revision": BuildRevision, "time": BuildTime} {
+		if value != "" {
+			build[key] = value
+		}
+	}
+
+	info := e.gen.doc.Info
+	if version, ok := build["version"].(string); ok && info.Version == "" {
+		info.Version = version
+	}
+	if info.Extensions == nil {
+		info.Extensions = make(map[string]any)
+	}
+	info.Extensions[buildInfoExtension] = build
+	return e
+}
@@ -0,0 +1,33 @@
+package soda_test
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestUseBuildInfo(t *testing.T) {
+	Convey("Given the build metadata set at link time", t, func() {
+		soda.BuildVersion, soda.BuildRevision = "v1.2.3", "abc123"
+		defer func() { soda.BuildVersion, soda.BuildRevision = "", "" }()
+
+		Convey("It should fill the version and the x-build extension", func() {
+			engine := soda.New().UseBuildInfo()
+			info := engine.OpenAPI().Info
+			So(info.Version, ShouldEqual, "v1.2.3")
+			build := info.Extensions["x-build"].(map[string]any)
+			So(build["version"], ShouldEqual, "v1.2.3")
+			So(build["revision"], ShouldEqual, "abc123")
+			So(build["goVersion"], ShouldEqual, runtime.Version())
+		})
+
+		Convey("It should keep an explicit version", func() {
+			engine := soda.New()
+			engine.OpenAPI().Info.Version = "2024-01"
+			engine.UseBuildInfo()
+			So(engine.OpenAPI().Info.Version, ShouldEqual, "2024-01")
+		})
+	})
+}
@@ -0,0 +1,80 @@
+package soda
+
+import (
+	"net/http"
+	"reflect"
+	"slices"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+)
+
+// BulkRequest wraps the items submitted to a bulk endpoint.
+type BulkRequest[T any] struct {
+	Items []T `json:"items" oai:"minItems=1"`
+}
+
+// BulkItemResult is the outcome of a single item of a bulk operation.
+type BulkItemResult struct {
+	Index  int    `json:"index"`
+	Status int    `json:"status"`
+	Data   any    `json:"data,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// BulkResults assembles the per-item results of a bulk operation into a 207 Multi-Status response.
+type BulkResults struct {
+	Results []BulkItemResult `json:"results"`
+}
+
+// NewBulkResults creates a new result set for a bulk operation of the given size.
+func NewBulkResults(size int) *BulkResults {
+	return &BulkResults{Results: make([]BulkItemResult, 0, size)}
+}
+
+// Succeed records a successful item.
+func (r *BulkResults) Succeed(index, status int, data any) *BulkResults {
+	r.Results = append(r.Results, BulkItemResult{Index: index, Status: status, Data: data})
+	return r
+}
+
+// Fail records a failed item.
+func (r *BulkResults) Fail(index, status int, err error) *BulkResults {
+	r.Results = append(r.Results, BulkItemResult{Index: index, Status: status, Error: err.Error()})
+	return r
+}
+
+// Send writes the results ordered by item index with the 207 Multi-Status status code.
+func (r *BulkResults) Send(c *fiber.Ctx) error {
+	slices.SortStableFunc(r.Results, func(a, b BulkItemResult) int { return a.Index - b.Index })
+	return c.Status(http.StatusMultiStatus).JSON(r)
+}
+
+// GenerateBulkResponse generates a 207 Multi-Status response whose per-item data is described by the model.
+func (g *Generator) GenerateBulkResponse(model any, description string) *openapi3.Response {
+	item := openapi3.NewObjectSchema().
+		WithProperty("index", openapi3.NewIntegerSchema()).
+		WithProperty("status", openapi3.NewIntegerSchema()).
+		WithProperty("error", openapi3.NewStringSchema()).
+		WithRequired([]string{"index", "status"})
+	if model != nil {
+		item.Properties["data"] = g.generateSchemaRef(nil, reflect.TypeOf(model), "json")
+	}
+	schema := openapi3.NewObjectSchema().
+		WithProperty("results", openapi3.NewArraySchema().WithItems(item)).
+		WithRequired([]string{"results"})
+	return openapi3.NewResponse().
+		WithDescription(description).
+		WithJSONSchema(schema)
+}
+
+// AddBulkResponse documents the 207 Multi-Status response of a bulk operation, see BulkResults.
+func (op *OperationBuilder) AddBulkResponse(model any, description ...string) (self *OperationBuilder) {
+	defer op.recoverMisuse(&self)
+	desc := http.StatusText(http.StatusMultiStatus)
+	if len(description) > 0 {
+		desc = description[0]
+	}
+	op.operation.AddResponse(http.StatusMultiStatus, op.route.gen.GenerateBulkResponse(model, desc))
+	return op
+}
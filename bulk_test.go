@@ -0,0 +1,70 @@
+package soda_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestBulk(t *testing.T) {
+	Convey("Given a bulk operation", t, func() {
+		type item struct {
+			Name string `json:"name"`
+		}
+		type input struct {
+			Body soda.BulkRequest[item] `body:"json"`
+		}
+		engine := soda.New()
+		engine.Post("/items/bulk", func(c *fiber.Ctx) error {
+			in := soda.GetInput[input](c)
+			results := soda.NewBulkResults(len(in.Body.Items))
+			for i := len(in.Body.Items) - 1; i >= 0; i-- {
+				if in.Body.Items[i].Name == "" {
+					results.Fail(i, http.StatusBadRequest, errors.New("name is required"))
+					continue
+				}
+				results.Succeed(i, http.StatusCreated, in.Body.Items[i])
+			}
+			return results.Send(c)
+		}).
+			SetInput(input{}).
+			AddBulkResponse(item{}).
+			OK()
+
+		Convey("The 207 response should be documented", func() {
+			operation := engine.OpenAPI().Paths.Find("/items/bulk").Post
+			response := operation.Responses.Status(http.StatusMultiStatus)
+			So(response, ShouldNotBeNil)
+			So(*response.Value.Description, ShouldEqual, "Multi-Status")
+			results := response.Value.Content["application/json"].Schema.Value.Properties["results"]
+			So(results.Value.Items.Value.Properties, ShouldContainKey, "data")
+
+			body := operation.RequestBody.Value.Content["application/json"].Schema.Value
+			So(body.Properties["items"].Value.MinItems, ShouldEqual, 1)
+		})
+
+		Convey("The results should be sent ordered by index", func() {
+			request, _ := http.NewRequest("POST", "/items/bulk", strings.NewReader(`{"items": [{"name": "a"}, {"name": ""}]}`))
+			request.Header.Add("Content-Type", "application/json")
+			response, err := engine.App().Test(request)
+			So(err, ShouldBeNil)
+			So(response.StatusCode, ShouldEqual, http.StatusMultiStatus)
+
+			var results soda.BulkResults
+			body, _ := io.ReadAll(response.Body)
+			So(json.Unmarshal(body, &results), ShouldBeNil)
+			So(results.Results, ShouldHaveLength, 2)
+			So(results.Results[0].Index, ShouldEqual, 0)
+			So(results.Results[0].Status, ShouldEqual, http.StatusCreated)
+			So(results.Results[1].Status, ShouldEqual, http.StatusBadRequest)
+			So(results.Results[1].Error, ShouldEqual, "name is required")
+		})
+	})
+}
@@ -0,0 +1,224 @@
+package soda
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// CircuitBreaker guards the operations depending on an upstream service. Its method set matches the two-step
+// circuit breakers of the usual libraries, such as gobreaker.TwoStepCircuitBreaker, and NewCircuitBreaker
+// provides a simple one.
+type CircuitBreaker interface {
+	// Name names the breaker in the stats.
+	Name() string
+	// Allow returns an error if the breaker is open, or the function recording the outcome of the request.
+	Allow() (done func(success bool), err error)
+}
+
+// Circuit breaker states, as reported by the breakers of NewCircuitBreaker.
+const (
+	CircuitClosed   = "closed"
+	CircuitOpen     = "open"
+	CircuitHalfOpen = "half-open"
+)
+
+// defaultOpenTimeout is the delay after which an open breaker of NewCircuitBreaker lets a trial request through
+// by default.
+const defaultOpenTimeout = 30 * time.Second
+
+// CircuitBreakerConfig configures the breakers of NewCircuitBreaker.
+type CircuitBreakerConfig struct {
+	// Failures is the number of consecutive failures opening the breaker, 5 by default.
+	Failures int
+	// OpenTimeout is the delay after which an open breaker lets a trial request through, 30s by default.
+	OpenTimeout time.Duration
+}
+
+// circuitBreaker is a breaker opening after consecutive failures, and closing after a successful trial.
+type circuitBreaker struct {
+	name string
+	cfg  CircuitBreakerConfig
+
+	mu       sync.Mutex
+	state    string
+	failures int
+	openedAt time.Time
+	trial    bool
+}
+
+// NewCircuitBreaker creates a breaker opening after consecutive failures, which lets a trial request through once
+// open for the timeout, and closes after its success.
+func NewCircuitBreaker(name string, cfg CircuitBreakerConfig) CircuitBreaker {
+	if cfg.Failures <= 0 {
+		cfg.Failures = 5
+	}
+	if cfg.OpenTimeout <= 0 {
+		cfg.OpenTimeout = defaultOpenTimeout
+	}
+	return &circuitBreaker{name: name, cfg: cfg, state: CircuitClosed}
+}
+
+func (cb *circuitBreaker) Name() string {
+	return cb.name
+}
+
+// State returns the state of the breaker.
+func (cb *circuitBreaker) State() string {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	if cb.state == CircuitOpen && time.Since(cb.openedAt) >= cb.cfg.OpenTimeout {
+		return CircuitHalfOpen
+	}
+	return cb.state
+}
+
+func (cb *circuitBreaker) Allow() (func(success bool), error) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	if cb.state == CircuitOpen {
+		if time.Since(cb.openedAt) < cb.cfg.OpenTimeout || cb.trial {
+			return nil, fmt.Errorf("the circuit breaker %s is open", cb.name)
+		}
+		cb.trial = true
+	}
+	return cb.done, nil
+}
+
+// done records the outcome of a request.
+func (cb *circuitBreaker) done(success bool) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	if cb.trial {
+		cb.trial = false
+		if success {
+			cb.state, cb.failures = CircuitClosed, 0
+		} else {
+			cb.openedAt = time.Now()
+		}
+		return
+	}
+	if success {
+		cb.failures = 0
+		return
+	}
+	cb.failures++
+	if cb.failures >= cb.cfg.Failures {
+		cb.state, cb.openedAt = CircuitOpen, time.Now()
+	}
+}
+
+// CircuitBreakerStats are the metrics of a breaker guarding operations.
+type CircuitBreakerStats struct {
+	Name string `json:"name"`
+	// State is the state reported by the State method of the breaker, if any, such as "open".
+	State string `json:"state,omitempty"`
+	// Requests counts the requests let through, Failures those which failed, and Rejected those short-circuited.
+	Requests uint64 `json:"requests"`
+	Failures uint64 `json:"failures"`
+	Rejected uint64 `json:"rejected"`
+}
+
+// breakerMetrics counts the outcomes of the requests guarded by a breaker.
+type breakerMetrics struct {
+	cb                           CircuitBreaker
+	requests, failures, rejected atomic.Uint64
+}
+
+// WithCircuitBreaker guards the handlers of the operation with the breaker: the requests are short-circuited with
+// a 503 response while it is open, whose Retry-After header advises the delay given, by default the open timeout
+// of the breakers of NewCircuitBreaker and 30 seconds for the others, and the outcome of the others is recorded,
+// the errors and the 5xx responses being failures. The 503 response is documented, and the breaker is reported by
+// Engine.CircuitBreakerStats.
+func (op *OperationBuilder) WithCircuitBreaker(cb CircuitBreaker, retryAfter ...time.Duration) (self *OperationBuilder) {
+	defer op.recoverMisuse(&self)
+	op.breaker = op.route.engine.breakerMetrics(cb)
+	op.breakerRetryAfter = defaultOpenTimeout
+	if cb, ok := cb.(*circuitBreaker); ok {
+		op.breakerRetryAfter = cb.cfg.OpenTimeout
+	}
+	if len(retryAfter) != 0 {
+		op.breakerRetryAfter = retryAfter[0]
+	}
+	return op.AddRetryableResponse(http.StatusServiceUnavailable, "An upstream service is unavailable",
+		RetryHint{Backoff: BackoffConstant, InitialDelay: op.breakerRetryAfter})
+}
+
+// breakerMetrics returns the metrics of the breaker, shared by the operations it guards.
+func (e *Engine) breakerMetrics(cb CircuitBreaker) *breakerMetrics {
+	e.breakersMu.Lock()
+	defer e.breakersMu.Unlock()
+	if m, ok := e.breakers[cb.Name()]; ok {
+		if m.cb != cb {
+			panic("another circuit breaker is named " + cb.Name())
+		}
+		return m
+	}
+	if e.breakers == nil {
+		e.breakers = make(map[string]*breakerMetrics)
+	}
+	m := &breakerMetrics{cb: cb}
+	e.breakers[cb.Name()] = m
+	return m
+}
+
+// guardCircuit short-circuits the request while the breaker of the operation is open, and records its outcome
+// otherwise.
+func (op *OperationBuilder) guardCircuit(c *fiber.Ctx) error {
+	m := op.breaker
+	done, err := m.cb.Allow()
+	if err != nil {
+		m.rejected.Add(1)
+		return RetryAfter(c, http.StatusServiceUnavailable, op.breakerRetryAfter, "service temporarily unavailable")
+	}
+	m.requests.Add(1)
+	defer func() {
+		// A panicking handler fails, and is recovered upstream.
+		if r := recover(); r != nil {
+			m.failures.Add(1)
+			done(false)
+			panic(r)
+		}
+	}()
+	err = c.Next()
+	success := auditStatus(c, err) < http.StatusInternalServerError
+	if !success {
+		m.failures.Add(1)
+	}
+	done(success)
+	return err
+}
+
+// CircuitBreakerStats reports the metrics of the breakers guarding the operations, sorted by name.
+func (e *Engine) CircuitBreakerStats() []CircuitBreakerStats {
+	e.breakersMu.Lock()
+	defer e.breakersMu.Unlock()
+	stats := make([]CircuitBreakerStats, 0, len(e.breakers))
+	for name, m := range e.breakers {
+		stats = append(stats, CircuitBreakerStats{
+			Name:     name,
+			State:    breakerState(m.cb),
+			Requests: m.requests.Load(),
+			Failures: m.failures.Load(),
+			Rejected: m.rejected.Load(),
+		})
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Name < stats[j].Name })
+	return stats
+}
+
+// breakerState returns the state reported by the State method of the breaker, whose result type varies by library,
+// or "" if it has none.
+func breakerState(cb CircuitBreaker) string {
+	method := reflect.ValueOf(cb).MethodByName("State")
+	if !method.IsValid() || method.Type().NumIn() != 0 || method.Type().NumOut() != 1 {
+		return ""
+	}
+	return fmt.Sprint(method.Call(nil)[0].Interface())
+}
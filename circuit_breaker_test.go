@@ -0,0 +1,77 @@
+package soda_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	Convey("Given operations guarded by a circuit breaker", t, func() {
+		engine := soda.New()
+		upstreamDown := true
+		cb := soda.NewCircuitBreaker("payments", soda.CircuitBreakerConfig{Failures: 2, OpenTimeout: 50 * time.Millisecond})
+		engine.Post("/charges", func(c *fiber.Ctx) error {
+			if upstreamDown {
+				return errors.New("upstream timeout")
+			}
+			return c.SendStatus(http.StatusCreated)
+		}).WithCircuitBreaker(cb).OK()
+		engine.Get("/charges/:id", func(c *fiber.Ctx) error {
+			return fiber.ErrNotFound
+		}).WithCircuitBreaker(cb).OK()
+
+		charge := func() int {
+			resp, err := engine.App().Test(httptest.NewRequest("POST", "/charges", nil))
+			So(err, ShouldBeNil)
+			return resp.StatusCode
+		}
+
+		Convey("The breaker should open after consecutive failures and close after a successful trial", func() {
+			So(charge(), ShouldEqual, http.StatusInternalServerError)
+			So(charge(), ShouldEqual, http.StatusInternalServerError)
+			resp, err := engine.App().Test(httptest.NewRequest("POST", "/charges", nil))
+			So(err, ShouldBeNil)
+			So(resp.StatusCode, ShouldEqual, http.StatusServiceUnavailable)
+			So(resp.Header.Get("Retry-After"), ShouldEqual, "1")
+
+			stats := engine.CircuitBreakerStats()
+			So(stats, ShouldResemble, []soda.CircuitBreakerStats{
+				{Name: "payments", State: soda.CircuitOpen, Requests: 2, Failures: 2, Rejected: 1},
+			})
+
+			time.Sleep(60 * time.Millisecond)
+			So(engine.CircuitBreakerStats()[0].State, ShouldEqual, soda.CircuitHalfOpen)
+			upstreamDown = false
+			So(charge(), ShouldEqual, http.StatusCreated)
+			So(engine.CircuitBreakerStats()[0].State, ShouldEqual, soda.CircuitClosed)
+		})
+
+		Convey("The client errors should not count as failures", func() {
+			for i := 0; i < 3; i++ {
+				resp, err := engine.App().Test(httptest.NewRequest("GET", "/charges/1", nil))
+				So(err, ShouldBeNil)
+				So(resp.StatusCode, ShouldEqual, http.StatusNotFound)
+			}
+			So(engine.CircuitBreakerStats()[0].Failures, ShouldEqual, 0)
+		})
+
+		Convey("The 503 response should be documented with its Retry-After header", func() {
+			resp := engine.OpenAPI().Paths.Value("/charges").Post.Responses.Status(http.StatusServiceUnavailable)
+			So(resp, ShouldNotBeNil)
+			So(resp.Value.Headers, ShouldContainKey, "Retry-After")
+			So(resp.Value.Extensions["x-retry"], ShouldResemble, map[string]any{"backoff": "constant", "initialDelay": 1})
+		})
+
+		Convey("Naming two breakers alike should panic", func() {
+			other := soda.NewCircuitBreaker("payments", soda.CircuitBreakerConfig{})
+			So(func() { engine.Get("/refunds", nil).WithCircuitBreaker(other) }, ShouldPanicWith, "another circuit breaker is named payments")
+		})
+	})
+}
@@ -0,0 +1,313 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// locations are the tags of the locations of the parameters, in the order soda looks them up.
+var locations = []string{"path", "query", "header", "cookie"}
+
+// accessors are the expressions reading the value of a parameter of the location from the fiber.Ctx c.
+var accessors = map[string]string{
+	"path":   "c.Params(%q)",
+	"query":  "c.Query(%q)",
+	"header": "c.Get(%q)",
+	"cookie": "c.Cookies(%q)",
+}
+
+// parsers are the statements parsing the string v into the value x of the builtin types, setting err.
+var parsers = map[string]string{
+	"string":  "x := strings.Clone(v)",
+	"bool":    "x, err := strconv.ParseBool(v)",
+	"int":     "n, err := strconv.ParseInt(v, 10, 0); x := int(n)",
+	"int8":    "n, err := strconv.ParseInt(v, 10, 8); x := int8(n)",
+	"int16":   "n, err := strconv.ParseInt(v, 10, 16); x := int16(n)",
+	"int32":   "n, err := strconv.ParseInt(v, 10, 32); x := int32(n)",
+	"int64":   "x, err := strconv.ParseInt(v, 10, 64)",
+	"uint":    "n, err := strconv.ParseUint(v, 10, 0); x := uint(n)",
+	"uint8":   "n, err := strconv.ParseUint(v, 10, 8); x := uint8(n)",
+	"uint16":  "n, err := strconv.ParseUint(v, 10, 16); x := uint16(n)",
+	"uint32":  "n, err := strconv.ParseUint(v, 10, 32); x := uint32(n)",
+	"uint64":  "x, err := strconv.ParseUint(v, 10, 64)",
+	"float32": "n, err := strconv.ParseFloat(v, 32); x := float32(n)",
+	"float64": "x, err := strconv.ParseFloat(v, 64)",
+}
+
+// Generate returns the source of the binders of the struct types of the package in the directory.
+func Generate(dir, pkg, sodaPath string, types []string) ([]byte, error) {
+	structs, err := findStructs(dir, pkg)
+	if err != nil {
+		return nil, err
+	}
+
+	g := &generator{structs: structs}
+	for _, name := range types {
+		st, ok := structs[name]
+		if !ok {
+			return nil, fmt.Errorf("struct type %s not found in package %s", name, pkg)
+		}
+		if err := g.generateBinder(name, st); err != nil {
+			return nil, err
+		}
+	}
+
+	var src bytes.Buffer
+	fmt.Fprintf(&src, "// Code generated by soda-gen. DO NOT EDIT.\n\npackage %s\n\nimport (\n", pkg)
+	code := append(g.decls.Bytes(), g.body.Bytes()...)
+	for _, std := range []string{"errors", "regexp", "strconv", "strings"} {
+		if bytes.Contains(code, []byte(std+".")) {
+			fmt.Fprintf(&src, "\t%q\n", std)
+		}
+	}
+	fmt.Fprintf(&src, "\n\t\"github.com/gofiber/fiber/v2\"\n\t%q\n)\n%s", sodaPath, code)
+	formatted, err := format.Source(src.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting the generated code: %w", err)
+	}
+	return formatted, nil
+}
+
+// findStructs returns the struct types declared in the Go files of the package in the directory, by name.
+func findStructs(dir, pkg string) (map[string]*ast.StructType, error) {
+	pkgs, err := parser.ParseDir(token.NewFileSet(), dir, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+	p, ok := pkgs[pkg]
+	if !ok {
+		return nil, fmt.Errorf("package %s not found in %s", pkg, dir)
+	}
+	structs := make(map[string]*ast.StructType)
+	for _, file := range p.Files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			if spec, ok := n.(*ast.TypeSpec); ok {
+				if st, ok := spec.Type.(*ast.StructType); ok {
+					structs[spec.Name.Name] = st
+				}
+			}
+			return true
+		})
+	}
+	return structs, nil
+}
+
+// generator writes the binders, along with the declarations they use, e.g. the compiled patterns.
+type generator struct {
+	structs map[string]*ast.StructType
+	decls   bytes.Buffer
+	body    bytes.Buffer
+	// binder and patterns name the patterns of the binder being written, not to conflict with those of the
+	// binders generated by the other runs in the package.
+	binder   string
+	patterns int
+}
+
+// generateBinder writes the BindParams method of the struct type.
+func (g *generator) generateBinder(name string, st *ast.StructType) error {
+	g.binder, g.patterns = name, 0
+	fmt.Fprintf(&g.body, "\n// BindParams binds and validates the parameters of %s without reflection.\n", name)
+	fmt.Fprintf(&g.body, "func (in *%s) BindParams(c *fiber.Ctx) error {\n", name)
+	if err := g.generateFields(name, st, nil); err != nil {
+		return err
+	}
+	g.body.WriteString("\treturn nil\n}\n")
+	return nil
+}
+
+// generateFields writes the binding of the parameters of the fields of the struct, including those of the
+// embedded structs of the package, whose names are in embedding.
+func (g *generator) generateFields(name string, st *ast.StructType, embedding []string) error {
+	for _, field := range st.Fields.List {
+		tag := reflect.StructTag("")
+		if field.Tag != nil {
+			value, err := strconv.Unquote(field.Tag.Value)
+			if err != nil {
+				return err
+			}
+			tag = reflect.StructTag(value)
+		}
+		if len(field.Names) == 0 {
+			embedded, ok := field.Type.(*ast.Ident)
+			if !ok || g.structs[embedded.Name] == nil {
+				return fmt.Errorf("%s: embedded fields must be structs of the package", name)
+			}
+			if slices.Contains(embedding, embedded.Name) {
+				return fmt.Errorf("%s: the embedded struct %s is recursive", name, embedded.Name)
+			}
+			if err := g.generateFields(name, g.structs[embedded.Name], append(embedding, embedded.Name)); err != nil {
+				return err
+			}
+			continue
+		}
+		in, param := location(tag)
+		if in == "" {
+			continue
+		}
+		for _, fieldName := range field.Names {
+			path := "in." + strings.Join(append(slices.Clone(embedding), fieldName.Name), ".")
+			if err := g.generateField(in, param, path, field.Type, props(tag)); err != nil {
+				return fmt.Errorf("%s.%s: %w", name, fieldName.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// location returns the location and the name of the parameter of the field tag, if any.
+func location(tag reflect.StructTag) (string, string) {
+	for _, in := range locations {
+		if name, _, _ := strings.Cut(tag.Get(in), ","); name != "" {
+			return in, name
+		}
+	}
+	return "", ""
+}
+
+// props returns the props of the oai tag, e.g. `oai:"minimum=1;maximum=100"`.
+func props(tag reflect.StructTag) map[string]string {
+	pairs := make(map[string]string)
+	for _, pair := range strings.Split(tag.Get("oai"), ";") {
+		if key, value, _ := strings.Cut(pair, "="); key != "" {
+			pairs[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+	return pairs
+}
+
+// generateField writes the binding and the validation of the parameter to the field of the type.
+func (g *generator) generateField(in, param, field string, typ ast.Expr, pairs map[string]string) error {
+	errorf := fmt.Sprintf("return soda.ParamError(%q, %q, err)", in, param)
+	accessor := fmt.Sprintf(accessors[in], param)
+	switch t := typ.(type) {
+	case *ast.Ident, *ast.StarExpr:
+		elem, pointer := t, false
+		if star, ok := t.(*ast.StarExpr); ok {
+			elem, pointer = star.X, true
+		}
+		ident, ok := elem.(*ast.Ident)
+		if !ok || parsers[ident.Name] == "" {
+			if pointer {
+				return fmt.Errorf("unsupported pointer type")
+			}
+			return fmt.Errorf("unsupported type %s", t.(*ast.Ident).Name)
+		}
+		if value, ok := pairs["default"]; ok {
+			fmt.Fprintf(&g.body, "\t{\n\t\tv := %s\n\t\tif v == \"\" {\n\t\t\tv = %q\n\t\t}\n", accessor, value)
+		} else {
+			fmt.Fprintf(&g.body, "\tif v := %s; v != \"\" {\n", accessor)
+		}
+		if converter, ok := pairs["converter"]; ok {
+			if in != "path" {
+				return fmt.Errorf("the converter can only be set on path parameters")
+			}
+			fmt.Fprintf(&g.body, "\t\tv, err := soda.Convert(%q, v)\n\t\tif err != nil {\n\t\t\t%s\n\t\t}\n", converter, errorf)
+		}
+		fmt.Fprintf(&g.body, "\t\t%s\n", parseStmt(parsers[ident.Name], errorf))
+		if err := g.generateChecks(in, param, ident.Name, pairs); err != nil {
+			return err
+		}
+		if pointer {
+			fmt.Fprintf(&g.body, "\t\t%s = &x\n\t}\n", field)
+		} else {
+			fmt.Fprintf(&g.body, "\t\t%s = x\n\t}\n", field)
+		}
+	case *ast.ArrayType:
+		elem, ok := t.Elt.(*ast.Ident)
+		if t.Len != nil || !ok || parsers[elem.Name] == "" {
+			return fmt.Errorf("unsupported slice type")
+		}
+		if in != "query" {
+			return fmt.Errorf("only query parameters can be slices")
+		}
+		if _, ok := pairs["converter"]; ok {
+			return fmt.Errorf("the converter can only be set on path parameters")
+		}
+		fmt.Fprintf(&g.body, "\tfor _, b := range c.Context().QueryArgs().PeekMulti(%q) {\n\t\tv := string(b)\n\t\t%s\n", param, parseStmt(parsers[elem.Name], errorf))
+		if err := g.generateChecks(in, param, elem.Name, pairs); err != nil {
+			return err
+		}
+		fmt.Fprintf(&g.body, "\t\t%s = append(%s, x)\n\t}\n", field, field)
+	default:
+		return fmt.Errorf("unsupported type")
+	}
+	return nil
+}
+
+// generateChecks writes the validation of the parsed value x of the builtin type against the constraints of the
+// oai props: the enum, the minimum and the maximum of the numbers, and the length and the pattern of the strings.
+func (g *generator) generateChecks(in, param, typ string, pairs map[string]string) error {
+	check := func(cond, reason string) {
+		fmt.Fprintf(&g.body, "\t\tif %s {\n\t\t\treturn soda.ParamError(%q, %q, errors.New(%q))\n\t\t}\n", cond, in, param, reason)
+	}
+	numeric := typ != "string" && typ != "bool"
+	if value, ok := pairs["enum"]; ok {
+		values := strings.Split(value, ",")
+		cases := make([]string, len(values))
+		for i, v := range values {
+			if typ == "string" {
+				cases[i] = strconv.Quote(v)
+			} else {
+				cases[i] = v
+			}
+		}
+		fmt.Fprintf(&g.body, "\t\tswitch x {\n\t\tcase %s:\n\t\tdefault:\n", strings.Join(cases, ", "))
+		fmt.Fprintf(&g.body, "\t\t\treturn soda.ParamError(%q, %q, errors.New(%q))\n\t\t}\n", in, param, "must be one of "+strings.Join(values, ", "))
+	}
+	for _, bound := range []struct{ props, op, reason string }{
+		{"minimum,min", "<", "must be at least "},
+		{"maximum,max", ">", "must be at most "},
+	} {
+		for _, prop := range strings.Split(bound.props, ",") {
+			if value, ok := pairs[prop]; ok {
+				if !numeric {
+					return fmt.Errorf("the %s of a %s parameter is not supported", prop, typ)
+				}
+				check(fmt.Sprintf("x %s %s", bound.op, value), bound.reason+value)
+			}
+		}
+	}
+	for _, length := range []struct{ prop, op, reason string }{
+		{"minLength", "<", "must be at least %s characters long"},
+		{"maxLength", ">", "must be at most %s characters long"},
+	} {
+		if value, ok := pairs[length.prop]; ok {
+			if typ != "string" {
+				return fmt.Errorf("the %s of a %s parameter is not supported", length.prop, typ)
+			}
+			check(fmt.Sprintf("len([]rune(x)) %s %s", length.op, value), fmt.Sprintf(length.reason, value))
+		}
+	}
+	if value, ok := pairs["pattern"]; ok {
+		if typ != "string" {
+			return fmt.Errorf("the pattern of a %s parameter is not supported", typ)
+		}
+		if _, err := regexp.Compile(value); err != nil {
+			return err
+		}
+		g.patterns++
+		pattern := fmt.Sprintf("sodaPattern%s%d", g.binder, g.patterns)
+		fmt.Fprintf(&g.decls, "\nvar %s = regexp.MustCompile(%q)\n", pattern, value)
+		check("!"+pattern+".MatchString(x)", "must match the pattern "+value)
+	}
+	return nil
+}
+
+// parseStmt returns the statements of the parser, returning the error if any.
+func parseStmt(parse, errorf string) string {
+	stmts := strings.Split(parse, "; ")
+	if !strings.Contains(stmts[0], "err :=") {
+		return parse
+	}
+	stmts = slices.Insert(stmts, 1, "if err != nil {\n"+errorf+"\n}")
+	return strings.Join(stmts, "\n")
+}
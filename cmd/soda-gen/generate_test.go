@@ -0,0 +1,78 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGenerate(t *testing.T) {
+	Convey("Given a package with input structs", t, func() {
+		dir := t.TempDir()
+		src := "package api\n\n" +
+			"type Named string\n\n" +
+			"type Page struct {\n\tLimit int `query:\"limit\"`\n}\n\n" +
+			"type ListInput struct {\n" +
+			"\tPage\n" +
+			"\tSort  string   `query:\"sort\" oai:\"enum=name,age;default=name\"`\n" +
+			"\tName  string   `query:\"name\" oai:\"minLength=2;maxLength=8;pattern=^[a-z]+$\"`\n" +
+			"\tIDs   []int    `query:\"ids\" oai:\"minimum=1\"`\n" +
+			"}\n\n" +
+			"type NestedInput struct {\n\tPage Page `query:\"page\"`\n}\n\n" +
+			"type PointerEmbeddedInput struct {\n\t*Page\n}\n\n" +
+			"type GetInput struct {\n" +
+			"\tID    uint32  `path:\"id\" oai:\"converter=base62\"`\n" +
+			"\tLimit *int    `query:\"limit,omitempty\"`\n" +
+			"\tSkip  string  `query:\"skip\" oai:\"-\"`\n" +
+			"\tNote  string\n" +
+			"}\n\n" +
+			"type NamedInput struct {\n\tName Named `query:\"name\"`\n}\n\n" +
+			"type HeaderSliceInput struct {\n\tIDs []int `header:\"X-Ids\"`\n}\n"
+		So(os.WriteFile(filepath.Join(dir, "api.go"), []byte(src), 0o600), ShouldBeNil)
+
+		Convey("It should generate the binders of the parameters", func() {
+			out, err := Generate(dir, "api", "github.com/neo-f/soda/v3", []string{"GetInput"})
+			So(err, ShouldBeNil)
+			code := string(out)
+			So(code, ShouldStartWith, "// Code generated by soda-gen. DO NOT EDIT.")
+			So(code, ShouldContainSubstring, "func (in *GetInput) BindParams(c *fiber.Ctx) error {")
+			So(code, ShouldContainSubstring, `v, err := soda.Convert("base62", v)`)
+			So(code, ShouldContainSubstring, `strconv.ParseUint(v, 10, 32)`)
+			So(code, ShouldContainSubstring, `if v := c.Query("limit"); v != "" {`)
+			So(code, ShouldContainSubstring, "in.Limit = &x")
+			So(code, ShouldContainSubstring, `if v := c.Query("skip"); v != "" {`)
+			So(code, ShouldNotContainSubstring, "Note")
+			So(code, ShouldNotContainSubstring, "errors")
+		})
+
+		Convey("It should generate the defaults and the validation of the parameters", func() {
+			out, err := Generate(dir, "api", "github.com/neo-f/soda/v3", []string{"ListInput"})
+			So(err, ShouldBeNil)
+			code := string(out)
+			So(code, ShouldContainSubstring, "in.Page.Limit = x")
+			So(code, ShouldContainSubstring, `v = "name"`)
+			So(code, ShouldContainSubstring, `case "name", "age":`)
+			So(code, ShouldContainSubstring, `errors.New("must be one of name, age")`)
+			So(code, ShouldContainSubstring, `if len([]rune(x)) < 2 {`)
+			So(code, ShouldContainSubstring, `errors.New("must be at most 8 characters long")`)
+			So(code, ShouldContainSubstring, `var sodaPatternListInput1 = regexp.MustCompile("^[a-z]+$")`)
+			So(code, ShouldContainSubstring, `if !sodaPatternListInput1.MatchString(x) {`)
+			So(code, ShouldContainSubstring, `return soda.ParamError("query", "ids", errors.New("must be at least 1"))`)
+		})
+
+		Convey("It should reject the unsupported fields", func() {
+			_, err := Generate(dir, "api", "github.com/neo-f/soda/v3", []string{"NestedInput"})
+			So(err.Error(), ShouldContainSubstring, "NestedInput.Page: unsupported type Page")
+			_, err = Generate(dir, "api", "github.com/neo-f/soda/v3", []string{"PointerEmbeddedInput"})
+			So(err.Error(), ShouldContainSubstring, "embedded fields must be structs of the package")
+			_, err = Generate(dir, "api", "github.com/neo-f/soda/v3", []string{"NamedInput"})
+			So(err.Error(), ShouldContainSubstring, "NamedInput.Name: unsupported type Named")
+			_, err = Generate(dir, "api", "github.com/neo-f/soda/v3", []string{"HeaderSliceInput"})
+			So(err.Error(), ShouldContainSubstring, "only query parameters can be slices")
+			_, err = Generate(dir, "api", "github.com/neo-f/soda/v3", []string{"Missing"})
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
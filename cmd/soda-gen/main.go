@@ -0,0 +1,59 @@
+// Command soda-gen generates static binders of the parameters of soda input structs, which the operations prefer
+// to binding them by reflection. It is meant to be run with go generate:
+//
+//	//go:generate go run github.com/neo-f/soda/v3/cmd/soda-gen -type ListUsersInput,GetUserInput
+//
+// The fields of the inputs must be path, query, header or cookie parameters of the builtin string, boolean and
+// numeric types, pointers to them, or slices of them for query parameters; the fields of the embedded structs of
+// the package are bound as well, while the nested structs are not supported. The binders apply the default of the
+// oai tag to a missing parameter and the converter of a path parameter, and validate its enum, minimum, maximum,
+// minLength, maxLength and pattern, the other constraints being only documented. Body fields are left to soda.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("soda-gen: ")
+	var (
+		types   = flag.String("type", "", "comma-separated list of the input struct types; required")
+		pkg     = flag.String("package", os.Getenv("GOPACKAGE"), "name of the package of the types")
+		output  = flag.String("output", "", "output file name; default <first type>_soda.go")
+		srcDir  = flag.String("dir", ".", "directory of the package")
+		modPath = flag.String("soda", "github.com/neo-f/soda/v3", "import path of soda")
+	)
+	flag.Parse()
+	if *types == "" || *pkg == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	names := strings.Split(*types, ",")
+	src, err := Generate(*srcDir, *pkg, *modPath, names)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *output == "" {
+		*output = strings.ToLower(names[0]) + "_soda.go"
+	}
+	if err := os.WriteFile(filepath.Join(*srcDir, *output), src, 0o600); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func init() {
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: soda-gen -type T[,T...] [-package name] [-output file] [-dir dir]\n")
+		fmt.Fprintf(os.Stderr, "Generates the binders of the path, query, header and cookie parameters of the types, with the\n")
+		fmt.Fprintf(os.Stderr, "defaults, the converters and the enum, minimum, maximum, minLength, maxLength and pattern of their oai tags.\n")
+		fmt.Fprintf(os.Stderr, "Nested struct parameters are not supported.\n")
+		flag.PrintDefaults()
+	}
+}
@@ -0,0 +1,108 @@
+package soda
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// coalesceExtension notes the operations whose concurrent identical requests share a single computation.
+const coalesceExtension = "x-coalesced"
+
+// coalescer deduplicates the concurrent requests of an operation with the same key.
+type coalescer struct {
+	key   func(c *fiber.Ctx) string
+	mu    sync.Mutex
+	calls map[string]*coalescedCall
+}
+
+// coalescedCall is the computation shared by the concurrent requests with the same key.
+type coalescedCall struct {
+	done     chan struct{}
+	err      error
+	response *coalescedResponse
+}
+
+// coalescedResponse is the response of a coalesced call, replayed to the requests sharing it.
+type coalescedResponse struct {
+	status  int
+	headers [][2]string
+	body    []byte
+}
+
+// Coalesce deduplicates the concurrent identical requests of the GET operation: the handlers run once for the
+// requests with the same key, whose response, or error, is served to all of them. The key defaults to the URL of
+// the request, and must include whatever else the response depends on, e.g. the user or the Accept header.
+// The requests are still bound and authorized one by one. The behavior is noted with the x-coalesced extension.
+// It panics if the operation isn't a GET one.
+func (op *OperationBuilder) Coalesce(key func(c *fiber.Ctx) string) (self *OperationBuilder) {
+	defer op.recoverMisuse(&self)
+	if op.method != http.MethodGet {
+		panic("only the GET operations can be coalesced, not " + op.method + " " + op.patternFull)
+	}
+	if key == nil {
+		key = func(c *fiber.Ctx) string { return c.OriginalURL() }
+	}
+	op.coalescer = &coalescer{key: key}
+	if op.operation.Extensions == nil {
+		op.operation.Extensions = make(map[string]any)
+	}
+	op.operation.Extensions[coalesceExtension] = true
+	return op
+}
+
+// coalesce runs the next handlers for the first of the concurrent requests with the same key, and serves its
+// response to the others.
+func (co *coalescer) coalesce(c *fiber.Ctx) error {
+	key := co.key(c)
+	co.mu.Lock()
+	if call, ok := co.calls[key]; ok {
+		co.mu.Unlock()
+		<-call.done
+		if call.err != nil {
+			return call.err
+		}
+		call.response.replay(c)
+		return nil
+	}
+	// The error of a panicking call is served to the requests waiting for it, the panic being recovered upstream.
+	call := &coalescedCall{done: make(chan struct{}), err: fiber.ErrInternalServerError}
+	if co.calls == nil {
+		co.calls = make(map[string]*coalescedCall)
+	}
+	co.calls[key] = call
+	co.mu.Unlock()
+	defer func() {
+		co.mu.Lock()
+		delete(co.calls, key)
+		co.mu.Unlock()
+		close(call.done)
+	}()
+
+	call.err = c.Next()
+	if call.err == nil {
+		call.response = captureResponse(c)
+	}
+	return call.err
+}
+
+// captureResponse copies the response of the request, but its cookies which belong to the client.
+func captureResponse(c *fiber.Ctx) *coalescedResponse {
+	resp := &coalescedResponse{status: c.Response().StatusCode(), body: append([]byte(nil), c.Response().Body()...)}
+	c.Response().Header.VisitAll(func(key, value []byte) {
+		if k := string(key); k != fiber.HeaderSetCookie && k != fiber.HeaderContentLength {
+			resp.headers = append(resp.headers, [2]string{k, string(value)})
+		}
+	})
+	return resp
+}
+
+// replay writes the response to the request.
+func (r *coalescedResponse) replay(c *fiber.Ctx) {
+	for _, header := range r.headers {
+		c.Response().Header.Add(header[0], header[1])
+	}
+	c.Status(r.status)
+	c.Response().SetBodyRaw(r.body)
+}
@@ -0,0 +1,77 @@
+package soda_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCoalesce(t *testing.T) {
+	Convey("Given a coalesced operation", t, func() {
+		var calls atomic.Int32
+		release := make(chan struct{})
+		engine := soda.New()
+		engine.Get("/reports/:id", func(c *fiber.Ctx) error {
+			calls.Add(1)
+			<-release
+			c.Set("X-Report", c.Params("id"))
+			return c.SendString("report " + c.Params("id"))
+		}).Coalesce(nil).OK()
+		get := func(target string) (*http.Response, string) {
+			resp, err := engine.App().Test(httptest.NewRequest("GET", target, nil), -1)
+			So(err, ShouldBeNil)
+			body, _ := io.ReadAll(resp.Body)
+			return resp, string(body)
+		}
+
+		Convey("The concurrent identical requests should share one computation", func() {
+			var wg sync.WaitGroup
+			bodies := make([]string, 3)
+			headers := make([]string, 3)
+			for i := range bodies {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					resp, err := engine.App().Test(httptest.NewRequest("GET", "/reports/1", nil), -1)
+					if err != nil {
+						return
+					}
+					body, _ := io.ReadAll(resp.Body)
+					bodies[i], headers[i] = string(body), resp.Header.Get("X-Report")
+				}(i)
+			}
+			time.Sleep(50 * time.Millisecond)
+			close(release)
+			wg.Wait()
+			So(calls.Load(), ShouldEqual, 1)
+			So(bodies, ShouldResemble, []string{"report 1", "report 1", "report 1"})
+			So(headers, ShouldResemble, []string{"1", "1", "1"})
+		})
+
+		Convey("The sequential requests should not be coalesced", func() {
+			close(release)
+			_, body := get("/reports/1")
+			So(body, ShouldEqual, "report 1")
+			_, body = get("/reports/2")
+			So(body, ShouldEqual, "report 2")
+			So(calls.Load(), ShouldEqual, 2)
+		})
+
+		Convey("The behavior should be noted in the spec", func() {
+			So(engine.OpenAPI().Paths.Value("/reports/:id").Get.Extensions["x-coalesced"], ShouldEqual, true)
+		})
+
+		Convey("Coalescing another method should panic", func() {
+			So(func() { engine.Post("/reports", nil).Coalesce(nil) }, ShouldPanicWith,
+				"only the GET operations can be coalesced, not POST /reports")
+		})
+	})
+}
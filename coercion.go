@@ -0,0 +1,105 @@
+package soda
+
+import (
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+)
+
+// Coercion is the set of rules applied to the query, header and cookie parameters before binding, since real
+// clients send messy values which would otherwise be rejected. The headers and the cookies are only coerced when
+// documented as parameters of the operation.
+type Coercion struct {
+	// TrimSpace trims the leading and trailing whitespace of the values.
+	TrimSpace bool
+	// EmptyAsAbsent treats the optional parameters with an empty value as absent, so that they are left nil
+	// rather than bound to an empty string. The required parameters keep their empty value.
+	EmptyAsAbsent bool
+	// LenientBools accepts yes/no, y/n and on/off in any case for the boolean parameters.
+	LenientBools bool
+}
+
+// SetCoercion sets the coercion rules applied to the parameters of the operations.
+func (e *Engine) SetCoercion(coercion Coercion) *Engine {
+	e.coercion = coercion
+	return e
+}
+
+var lenientBools = map[string]string{
+	"1": "true", "t": "true", "true": "true", "y": "true", "yes": "true", "on": "true",
+	"0": "false", "f": "false", "false": "false", "n": "false", "no": "false", "off": "false",
+}
+
+// coerce applies the rules to the value of a parameter, and reports whether the parameter is to be kept.
+func (coercion Coercion) coerce(v string, param *openapi3.Parameter) (string, bool) {
+	if coercion.TrimSpace {
+		v = strings.TrimSpace(v)
+	}
+	if v == "" {
+		return v, !coercion.EmptyAsAbsent || (param != nil && param.Required)
+	}
+	if b, ok := lenientBools[strings.ToLower(v)]; ok && coercion.LenientBools && param != nil && isBoolSchema(param.Schema) {
+		v = b
+	}
+	return v, true
+}
+
+// coerceParams applies the coercion rules of the engine to the parameters of the request.
+func (op *OperationBuilder) coerceParams(ctx *fiber.Ctx) {
+	coercion := op.route.engine.coercion
+	if coercion == (Coercion{}) {
+		return
+	}
+
+	args := ctx.Request().URI().QueryArgs()
+	type arg struct{ key, value string }
+	var coerced []arg
+	args.VisitAll(func(key, value []byte) {
+		k := string(key)
+		v, keep := coercion.coerce(string(value), op.operation.Parameters.GetByInAndName(openapi3.ParameterInQuery, k))
+		if keep {
+			coerced = append(coerced, arg{k, v})
+		}
+	})
+	args.Reset()
+	for _, a := range coerced {
+		args.Add(a.key, a.value)
+	}
+
+	header := &ctx.Request().Header
+	for _, param := range op.operation.Parameters {
+		if param.Value == nil {
+			continue
+		}
+		switch name := param.Value.Name; param.Value.In {
+		case openapi3.ParameterInHeader:
+			if value := header.Peek(name); value != nil {
+				if v, keep := coercion.coerce(string(value), param.Value); keep {
+					header.Set(name, v)
+				} else {
+					header.Del(name)
+				}
+			}
+		case openapi3.ParameterInCookie:
+			if value := header.Cookie(name); value != nil {
+				if v, keep := coercion.coerce(string(value), param.Value); keep {
+					header.SetCookie(name, v)
+				} else {
+					header.DelCookie(name)
+				}
+			}
+		}
+	}
+}
+
+// isBoolSchema reports whether the schema is a boolean or an array of booleans.
+func isBoolSchema(schema *openapi3.SchemaRef) bool {
+	if schema == nil || schema.Value == nil {
+		return false
+	}
+	if schema.Value.Type.Is(openapi3.TypeArray) {
+		return isBoolSchema(schema.Value.Items)
+	}
+	return schema.Value.Type.Is(openapi3.TypeBoolean)
+}
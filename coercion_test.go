@@ -0,0 +1,116 @@
+package soda_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCoercion(t *testing.T) {
+	type input struct {
+		Active *bool   `query:"active"`
+		Limit  *int    `query:"limit"`
+		Name   *string `query:"name"`
+	}
+	type output struct {
+		Active *bool   `json:"active"`
+		Limit  *int    `json:"limit"`
+		Name   *string `json:"name"`
+	}
+	newEngine := func(coercion soda.Coercion) *soda.Engine {
+		engine := soda.New().SetBindErrorStatus(http.StatusBadRequest).SetCoercion(coercion)
+		engine.Get("/items", func(c *fiber.Ctx) error {
+			in := soda.GetInput[input](c)
+			return c.JSON(output(*in))
+		}).SetInput(input{}).OK()
+		return engine
+	}
+	get := func(engine *soda.Engine, target string) (int, output) {
+		resp, err := engine.App().Test(httptest.NewRequest("GET", target, nil))
+		So(err, ShouldBeNil)
+		body, _ := io.ReadAll(resp.Body)
+		var out output
+		_ = json.Unmarshal(body, &out)
+		return resp.StatusCode, out
+	}
+
+	Convey("Given messy query strings", t, func() {
+		Convey("They should be rejected without coercion", func() {
+			engine := newEngine(soda.Coercion{})
+			status, _ := get(engine, "/items?limit=%2010")
+			So(status, ShouldEqual, http.StatusBadRequest)
+			status, _ = get(engine, "/items?active=yes")
+			So(status, ShouldEqual, http.StatusBadRequest)
+			_, out := get(engine, "/items?name=")
+			So(*out.Name, ShouldEqual, "")
+		})
+
+		Convey("They should be coerced with the rules", func() {
+			engine := newEngine(soda.Coercion{TrimSpace: true, EmptyAsAbsent: true, LenientBools: true})
+			_, out := get(engine, "/items?name=")
+			So(out.Name, ShouldBeNil)
+			status, out := get(engine, "/items?limit=&active=YES&name=%20bob%20")
+			So(status, ShouldEqual, http.StatusOK)
+			So(out.Limit, ShouldBeNil)
+			So(*out.Active, ShouldBeTrue)
+			So(*out.Name, ShouldEqual, "bob")
+
+			status, out = get(engine, "/items?limit=%2010%20&active=off")
+			So(status, ShouldEqual, http.StatusOK)
+			So(*out.Limit, ShouldEqual, 10)
+			So(*out.Active, ShouldBeFalse)
+		})
+
+		Convey("The empty required parameters should be kept", func() {
+			type input struct {
+				Term string `query:"term"`
+			}
+			engine := soda.New().SetCoercion(soda.Coercion{EmptyAsAbsent: true})
+			engine.Get("/search", func(c *fiber.Ctx) error {
+				return c.SendString("term=" + soda.GetInput[input](c).Term)
+			}).SetInput(input{}).OK()
+			resp, _ := engine.App().Test(httptest.NewRequest("GET", "/search?term=", nil))
+			body, _ := io.ReadAll(resp.Body)
+			So(string(body), ShouldEqual, "term=")
+			So(engine.OpenAPI().Paths.Find("/search").Get.Parameters[0].Value.Required, ShouldBeTrue)
+		})
+
+		Convey("Lenient bools should only apply to boolean parameters", func() {
+			engine := newEngine(soda.Coercion{LenientBools: true})
+			status, out := get(engine, "/items?name=yes")
+			So(status, ShouldEqual, http.StatusOK)
+			So(*out.Name, ShouldEqual, "yes")
+		})
+	})
+
+	Convey("Given messy headers and cookies", t, func() {
+		type input struct {
+			Tenant *string `header:"X-Tenant"`
+			Trace  *string `header:"X-Trace"`
+			Beta   *bool   `cookie:"beta"`
+		}
+		engine := soda.New().SetCoercion(soda.Coercion{TrimSpace: true, EmptyAsAbsent: true, LenientBools: true})
+		engine.Get("/items", func(c *fiber.Ctx) error {
+			in := soda.GetInput[input](c)
+			return c.JSON(map[string]any{"tenant": in.Tenant, "trace": in.Trace, "beta": in.Beta})
+		}).SetInput(input{}).OK()
+
+		Convey("They should be coerced with the rules", func() {
+			req := httptest.NewRequest("GET", "/items", nil)
+			req.Header.Set("X-Tenant", "  acme ")
+			req.Header.Set("X-Trace", " ")
+			req.Header.Set("Cookie", "beta=on")
+			resp, err := engine.App().Test(req)
+			So(err, ShouldBeNil)
+			So(resp.StatusCode, ShouldEqual, http.StatusOK)
+			body, _ := io.ReadAll(resp.Body)
+			So(string(body), ShouldEqual, `{"beta":true,"tenant":"acme","trace":null}`)
+		})
+	})
+}
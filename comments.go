@@ -0,0 +1,122 @@
+package soda
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// typeComments holds the doc comments of a struct type and of its fields.
+type typeComments struct {
+	doc    string
+	fields map[string]string
+}
+
+var (
+	docCommentsMu sync.RWMutex
+	// docComments maps the qualified name of a type, as in "models.User", to its comments.
+	docComments = make(map[string]typeComments)
+)
+
+// LoadDocComments parses the Go source files of fsys matching the patterns and uses the doc comments of
+// their struct types and fields as descriptions of the generated schemas and parameters,
+// so that they don't have to be repeated with `oai:"description=..."`, which still takes precedence.
+//
+// Source files are usually not shipped with binaries, embed them to use this in production:
+//
+//	//go:embed models/*.go
+//	var sources embed.FS
+//
+//	soda.LoadDocComments(sources, "models/*.go")
+//
+// It must be called before the operations are registered.
+func LoadDocComments(fsys fs.FS, patterns ...string) error {
+	for _, pattern := range patterns {
+		matches, err := fs.Glob(fsys, pattern)
+		if err != nil {
+			return err
+		}
+		for _, name := range matches {
+			if !strings.HasSuffix(name, ".go") {
+				continue
+			}
+			src, err := fs.ReadFile(fsys, name)
+			if err != nil {
+				return err
+			}
+			file, err := parser.ParseFile(token.NewFileSet(), name, src, parser.ParseComments)
+			if err != nil {
+				return err
+			}
+			loadFileComments(file)
+		}
+	}
+	return nil
+}
+
+// loadFileComments collects the comments of the struct types declared in the file.
+func loadFileComments(file *ast.File) {
+	docCommentsMu.Lock()
+	defer docCommentsMu.Unlock()
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			ts := spec.(*ast.TypeSpec)
+			st, ok := ts.Type.(*ast.StructType)
+			if !ok {
+				continue
+			}
+			doc := ts.Doc
+			if doc == nil && len(gen.Specs) == 1 {
+				doc = gen.Doc
+			}
+			comments := typeComments{doc: commentText(doc), fields: make(map[string]string)}
+			for _, field := range st.Fields.List {
+				text := commentText(field.Doc)
+				if text == "" {
+					text = commentText(field.Comment)
+				}
+				for _, name := range field.Names {
+					comments.fields[name.Name] = text
+				}
+			}
+			docComments[file.Name.Name+"."+ts.Name.Name] = comments
+		}
+	}
+}
+
+// commentText returns the text of the comment group without a trailing newline.
+func commentText(group *ast.CommentGroup) string {
+	return strings.TrimSpace(group.Text())
+}
+
+// typeComment returns the doc comment of the type, if loaded.
+func typeComment(t reflect.Type) string {
+	docCommentsMu.RLock()
+	defer docCommentsMu.RUnlock()
+	return docComments[t.String()].doc
+}
+
+// fieldComment returns the doc comment of the field of the struct type t, if loaded.
+func fieldComment(t reflect.Type, f reflect.StructField) string {
+	docCommentsMu.RLock()
+	defer docCommentsMu.RUnlock()
+	return docComments[t.String()].fields[f.Name]
+}
+
+// injectComment sets the comment as the description of the schema unless it already has one.
+// Referenced schemas are shared with other fields and are described by the comment of their type instead.
+func injectComment(schemaRef *openapi3.SchemaRef, comment string) {
+	if schemaRef.Ref == "" && schemaRef.Value != nil && schemaRef.Value.Description == "" {
+		schemaRef.Value.Description = comment
+	}
+}
@@ -0,0 +1,52 @@
+package soda_test
+
+import (
+	"reflect"
+	"testing"
+	"testing/fstest"
+
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestLoadDocComments(t *testing.T) {
+	Convey("Given doc comments loaded from the source", t, func() {
+		fsys := fstest.MapFS{
+			"models/commented.go": {Data: []byte(`package soda_test
+
+// CommentedModel is a model described by its comments.
+type CommentedModel struct {
+	// The name of the model.
+	Name string ` + "`json:\"name\"`" + `
+	Size int ` + "`json:\"size\" oai:\"description=from the tag\"`" + ` // The size of the model.
+	Page int ` + "`query:\"page\"`" + ` // The page to fetch.
+}
+`)},
+			"models/README.md": {Data: []byte("not go")},
+		}
+		So(soda.LoadDocComments(fsys, "models/*"), ShouldBeNil)
+
+		type CommentedModel struct {
+			Name string `json:"name"`
+			Size int    `json:"size" oai:"description=from the tag"`
+			Page int    `query:"page"`
+		}
+
+		Convey("It should describe the schema and its properties", func() {
+			schema := soda.GenerateSchemaRef(CommentedModel{}, "json").Value
+			So(schema.Description, ShouldEqual, "CommentedModel is a model described by its comments.")
+			So(schema.Properties["name"].Value.Description, ShouldEqual, "The name of the model.")
+			So(schema.Properties["size"].Value.Description, ShouldEqual, "from the tag")
+		})
+
+		Convey("It should describe the parameters", func() {
+			params := soda.NewGenerator().GenerateParameters(reflect.TypeOf(CommentedModel{}))
+			So(params.GetByInAndName("query", "page").Description, ShouldEqual, "The page to fetch.")
+		})
+
+		Convey("It should fail on invalid sources", func() {
+			err := soda.LoadDocComments(fstest.MapFS{"bad.go": {Data: []byte("package")}}, "*.go")
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
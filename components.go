@@ -0,0 +1,95 @@
+package soda
+
+import (
+	"strconv"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// AddRequestBodyComponent registers a request body under components.requestBodies, so that operations can
+// share it with UseRequestBody.
+func (e *Engine) AddRequestBodyComponent(name string, body *openapi3.RequestBody) *Engine {
+	e.gen.doc.Components.RequestBodies[name] = &openapi3.RequestBodyRef{Value: body}
+	return e
+}
+
+// AddExampleComponent registers an example under components.examples, so that operations can reference it
+// with AddRequestBodyExample, AddResponseExample and AddParameterExample.
+func (e *Engine) AddExampleComponent(name string, example *openapi3.Example) *Engine {
+	e.gen.doc.Components.Examples[name] = &openapi3.ExampleRef{Value: example}
+	return e
+}
+
+// UseRequestBody documents the request body of the operation as a reference to the request body component,
+// replacing the one generated from the input, which is still used for binding.
+// It panics if the component is not registered.
+func (op *OperationBuilder) UseRequestBody(name string) (self *OperationBuilder) {
+	defer op.recoverMisuse(&self)
+	body, ok := op.route.gen.doc.Components.RequestBodies[name]
+	if !ok {
+		panic("request body component " + name + " is not registered")
+	}
+	op.operation.RequestBody = &openapi3.RequestBodyRef{Ref: "#/components/requestBodies/" + name, Value: body.Value}
+	return op
+}
+
+// AddRequestBodyExample references the example component from every media type of the request body.
+// It must be called after SetInput.
+func (op *OperationBuilder) AddRequestBodyExample(name string) (self *OperationBuilder) {
+	defer op.recoverMisuse(&self)
+	ref := op.exampleRef(name)
+	for _, content := range op.requestBody().Content {
+		addExample(content, name, ref)
+	}
+	return op
+}
+
+// AddResponseExample references the example component from every media type of the response with the status.
+// It panics if the response is not documented.
+func (op *OperationBuilder) AddResponseExample(code int, name string) (self *OperationBuilder) {
+	defer op.recoverMisuse(&self)
+	ref := op.exampleRef(name)
+	response := op.operation.Responses.Status(code)
+	if response == nil || response.Value == nil {
+		panic("the operation has no response with the status " + strconv.Itoa(code))
+	}
+	for _, content := range response.Value.Content {
+		addExample(content, name, ref)
+	}
+	return op
+}
+
+// AddParameterExample references the example component from the parameter of the operation.
+// It must be called after SetInput and panics if the parameter is not documented.
+func (op *OperationBuilder) AddParameterExample(in, param, name string) (self *OperationBuilder) {
+	defer op.recoverMisuse(&self)
+	parameter := op.operation.Parameters.GetByInAndName(in, param)
+	if parameter == nil {
+		panic("the operation has no " + in + " parameter " + param)
+	}
+	// A parameter has either an example or examples.
+	parameter.Example = nil
+	if parameter.Examples == nil {
+		parameter.Examples = make(openapi3.Examples)
+	}
+	parameter.Examples[name] = op.exampleRef(name)
+	return op
+}
+
+// exampleRef returns a reference to the example component, panicking if it is not registered.
+func (op *OperationBuilder) exampleRef(name string) *openapi3.ExampleRef {
+	example, ok := op.route.gen.doc.Components.Examples[name]
+	if !ok {
+		panic("example component " + name + " is not registered")
+	}
+	return &openapi3.ExampleRef{Ref: "#/components/examples/" + name, Value: example.Value}
+}
+
+// addExample adds the example to the media type, which then has examples rather than an example.
+func addExample(content *openapi3.MediaType, name string, ref *openapi3.ExampleRef) {
+	content.Example = nil
+	if content.Examples == nil {
+		content.Examples = make(openapi3.Examples)
+	}
+	content.Examples[name] = ref
+}
@@ -0,0 +1,60 @@
+package soda_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSharedComponents(t *testing.T) {
+	Convey("Given shared request bodies and examples", t, func() {
+		type item struct {
+			Name string `json:"name"`
+		}
+		type input struct {
+			Lang string `query:"lang"`
+			Body item   `body:"json"`
+		}
+		engine := soda.New().
+			AddRequestBodyComponent("Item", openapi3.NewRequestBody().
+				WithDescription("An item").
+				WithJSONSchemaRef(soda.GenerateSchemaRef(item{}, "json"))).
+			AddExampleComponent("soda", openapi3.NewExample(map[string]any{"name": "soda"})).
+			AddExampleComponent("english", openapi3.NewExample("en"))
+		handler := func(c *fiber.Ctx) error { return nil }
+
+		Convey("Operations should reference them", func() {
+			engine.Post("/items", handler).
+				SetInput(&input{}).
+				AddRequestBodyExample("soda").
+				AddJSONResponse(http.StatusCreated, item{}).
+				AddResponseExample(http.StatusCreated, "soda").
+				AddParameterExample("query", "lang", "english").
+				OK()
+			engine.Put("/items/:id", handler).SetInput(&input{}).UseRequestBody("Item").OK()
+
+			post := engine.OpenAPI().Paths.Find("/items").Post
+			So(post.RequestBody.Value.Content.Get("application/json").Examples["soda"].Ref, ShouldEqual, "#/components/examples/soda")
+			So(post.Responses.Status(http.StatusCreated).Value.Content.Get("application/json").Examples["soda"].Ref, ShouldEqual, "#/components/examples/soda")
+			So(post.Parameters.GetByInAndName("query", "lang").Examples["english"].Ref, ShouldEqual, "#/components/examples/english")
+
+			put := engine.OpenAPI().Paths.Find("/items/:id").Put
+			So(put.RequestBody.Ref, ShouldEqual, "#/components/requestBodies/Item")
+
+			spec, err := engine.OpenAPI().MarshalJSON()
+			So(err, ShouldBeNil)
+			So(string(spec), ShouldContainSubstring, `"requestBody":{"$ref":"#/components/requestBodies/Item"}`)
+		})
+
+		Convey("Referencing unregistered components should panic", func() {
+			op := engine.Post("/items", handler).SetInput(&input{})
+			So(func() { op.UseRequestBody("Unknown") }, ShouldPanic)
+			So(func() { op.AddRequestBodyExample("unknown") }, ShouldPanic)
+			So(func() { op.AddResponseExample(http.StatusOK, "soda") }, ShouldPanic)
+		})
+	})
+}
@@ -0,0 +1,65 @@
+package soda
+
+import (
+	"maps"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/compress"
+)
+
+// supportedAcceptEncodings are the response encodings negotiated by soda.
+var supportedAcceptEncodings = []any{"br", "gzip", "deflate", "identity"}
+
+// EnableResponseCompression compresses the responses of the operations registered afterwards with brotli, gzip
+// or deflate, as negotiated by the Accept-Encoding header, which is documented along with the Content-Encoding
+// and Vary headers of the responses. Streaming operations can opt out with DisableCompression.
+func (e *Engine) EnableResponseCompression(level ...compress.Level) *Engine {
+	cfg := compress.Config{}
+	if len(level) != 0 {
+		cfg.Level = level[0]
+	}
+	e.compressor = compress.New(cfg)
+	return e
+}
+
+// DisableCompression opts the operation out of the response compression, e.g. for streaming endpoints whose
+// responses must be flushed as they are written.
+func (op *OperationBuilder) DisableCompression() *OperationBuilder {
+	op.noCompression = true
+	return op
+}
+
+// compressed reports whether the responses of the operation are compressed.
+func (op *OperationBuilder) compressed() bool {
+	return op.route.engine.compressor != nil && !op.noCompression
+}
+
+// documentCompression documents the response encodings negotiated by the operation.
+func (op *OperationBuilder) documentCompression() {
+	if op.operation.Parameters.GetByInAndName(openapi3.ParameterInHeader, fiber.HeaderAcceptEncoding) == nil {
+		op.operation.AddParameter(openapi3.NewHeaderParameter(fiber.HeaderAcceptEncoding).
+			WithDescription("The encodings accepted for the response body, which is compressed accordingly.").
+			WithSchema(openapi3.NewStringSchema()))
+	}
+	for status, response := range op.operation.Responses.Map() {
+		if response.Value == nil || len(response.Value.Content) == 0 {
+			continue
+		}
+		// The response may be shared with other operations.
+		documented := *response.Value
+		documented.Headers = maps.Clone(response.Value.Headers)
+		if documented.Headers == nil {
+			documented.Headers = make(openapi3.Headers)
+		}
+		documented.Headers[fiber.HeaderContentEncoding] = &openapi3.HeaderRef{Value: &openapi3.Header{Parameter: openapi3.Parameter{
+			Description: "The encoding of the response body, as negotiated by Accept-Encoding.",
+			Schema:      openapi3.NewStringSchema().WithEnum(supportedAcceptEncodings...).NewRef(),
+		}}}
+		documented.Headers[fiber.HeaderVary] = &openapi3.HeaderRef{Value: &openapi3.Header{Parameter: openapi3.Parameter{
+			Description: "Accept-Encoding, as the response body depends on the negotiated encoding.",
+			Schema:      openapi3.NewStringSchema().NewRef(),
+		}}}
+		op.operation.Responses.Set(status, &openapi3.ResponseRef{Value: &documented})
+	}
+}
@@ -0,0 +1,66 @@
+package soda_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestResponseCompression(t *testing.T) {
+	Convey("Given an engine compressing the responses", t, func() {
+		payload := strings.Repeat("compressible ", 200)
+		handler := func(c *fiber.Ctx) error { return c.SendString(payload) }
+		engine := soda.New().EnableResponseCompression()
+		engine.Get("/report", handler).AddJSONResponse(http.StatusOK, "").OK()
+		engine.Get("/stream", handler).AddJSONResponse(http.StatusOK, "").DisableCompression().OK()
+		api := engine.Group("/api").AddJSONResponse(http.StatusBadRequest, "")
+		api.Get("/report", handler).OK()
+		api.Get("/stream", handler).DisableCompression().OK()
+		engine.Get("/missing", func(c *fiber.Ctx) error {
+			return soda.NewError(http.StatusNotFound, "not_found", payload)
+		}).OK()
+
+		call := func(target, encoding string) *http.Response {
+			req := httptest.NewRequest("GET", target, nil)
+			req.Header.Set("Accept-Encoding", encoding)
+			resp, err := engine.App().Test(req)
+			So(err, ShouldBeNil)
+			return resp
+		}
+
+		Convey("The responses should be compressed as negotiated", func() {
+			resp := call("/report", "gzip")
+			So(resp.Header.Get("Content-Encoding"), ShouldEqual, "gzip")
+			So(resp.Header.Get("Vary"), ShouldEqual, "Accept-Encoding")
+			So(call("/report", "br").Header.Get("Content-Encoding"), ShouldEqual, "br")
+			So(call("/report", "").Header.Get("Content-Encoding"), ShouldBeEmpty)
+			So(call("/missing", "gzip").Header.Get("Content-Encoding"), ShouldEqual, "gzip")
+		})
+
+		Convey("The operations opting out should not be compressed", func() {
+			So(call("/stream", "gzip").Header.Get("Content-Encoding"), ShouldBeEmpty)
+		})
+
+		Convey("The negotiation should be documented", func() {
+			operation := engine.OpenAPI().Paths.Value("/report").Get
+			So(operation.Parameters.GetByInAndName("header", "Accept-Encoding"), ShouldNotBeNil)
+			headers := operation.Responses.Status(http.StatusOK).Value.Headers
+			So(headers, ShouldContainKey, "Content-Encoding")
+			So(headers, ShouldContainKey, "Vary")
+
+			operation = engine.OpenAPI().Paths.Value("/stream").Get
+			So(operation.Parameters.GetByInAndName("header", "Accept-Encoding"), ShouldBeNil)
+		})
+
+		Convey("The responses shared by the operations should not be changed", func() {
+			paths := engine.OpenAPI().Paths
+			So(paths.Value("/api/report").Get.Responses.Status(http.StatusBadRequest).Value.Headers, ShouldContainKey, "Vary")
+			So(paths.Value("/api/stream").Get.Responses.Status(http.StatusBadRequest).Value.Headers, ShouldNotContainKey, "Vary")
+		})
+	})
+}
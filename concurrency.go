@@ -0,0 +1,39 @@
+package soda
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// defaultConcurrencyRetryAfter is the delay advised to the clients of a saturated operation by default.
+const defaultConcurrencyRetryAfter = time.Second
+
+// SetMaxConcurrency limits the number of requests of the operation handled concurrently, e.g. for expensive
+// reports or exports. The requests beyond the limit are rejected right away with a 503 response, whose Retry-After
+// header advises the delay given, one second by default, rather than queued. The 503 response is documented.
+func (op *OperationBuilder) SetMaxConcurrency(n int, retryAfter ...time.Duration) (self *OperationBuilder) {
+	defer op.recoverMisuse(&self)
+	if n <= 0 {
+		panic("the max concurrency must be positive")
+	}
+	op.concurrency = make(chan struct{}, n)
+	op.concurrencyRetryAfter = defaultConcurrencyRetryAfter
+	if len(retryAfter) != 0 {
+		op.concurrencyRetryAfter = retryAfter[0]
+	}
+	return op.AddRetryableResponse(http.StatusServiceUnavailable, "Too many concurrent requests",
+		RetryHint{Backoff: BackoffConstant, InitialDelay: op.concurrencyRetryAfter})
+}
+
+// limitConcurrency handles the request if the operation is not saturated, and rejects it otherwise.
+func (op *OperationBuilder) limitConcurrency(c *fiber.Ctx) error {
+	select {
+	case op.concurrency <- struct{}{}:
+		defer func() { <-op.concurrency }()
+		return c.Next()
+	default:
+		return RetryAfter(c, http.StatusServiceUnavailable, op.concurrencyRetryAfter, "too many concurrent requests")
+	}
+}
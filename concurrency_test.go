@@ -0,0 +1,60 @@
+package soda_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestMaxConcurrency(t *testing.T) {
+	Convey("Given an operation limited to one request at a time", t, func() {
+		engine := soda.New()
+		started, release := make(chan struct{}), make(chan struct{})
+		engine.Get("/reports", func(c *fiber.Ctx) error {
+			started <- struct{}{}
+			<-release
+			return c.SendString("report")
+		}).SetMaxConcurrency(1, 1500*time.Millisecond).OK()
+
+		Convey("The requests beyond the limit should be rejected", func() {
+			done := make(chan int)
+			go func() {
+				resp, err := engine.App().Test(httptest.NewRequest("GET", "/reports", nil), -1)
+				if err != nil {
+					done <- 0
+					return
+				}
+				done <- resp.StatusCode
+			}()
+			<-started
+
+			resp, err := engine.App().Test(httptest.NewRequest("GET", "/reports", nil))
+			So(err, ShouldBeNil)
+			So(resp.StatusCode, ShouldEqual, http.StatusServiceUnavailable)
+			So(resp.Header.Get("Retry-After"), ShouldEqual, "2")
+
+			close(release)
+			So(<-done, ShouldEqual, http.StatusOK)
+
+			go func() { <-started }()
+			resp, err = engine.App().Test(httptest.NewRequest("GET", "/reports", nil))
+			So(err, ShouldBeNil)
+			So(resp.StatusCode, ShouldEqual, http.StatusOK)
+		})
+
+		Convey("The 503 response should be documented", func() {
+			resp := engine.OpenAPI().Paths.Value("/reports").Get.Responses.Status(http.StatusServiceUnavailable)
+			So(resp, ShouldNotBeNil)
+			So(resp.Value.Headers, ShouldContainKey, "Retry-After")
+		})
+
+		Convey("A non-positive limit should panic", func() {
+			So(func() { engine.Get("/exports", nil).SetMaxConcurrency(0) }, ShouldPanicWith, "the max concurrency must be positive")
+		})
+	})
+}
@@ -19,8 +19,9 @@ var (
 
 // parameter props.
 const (
-	propExplode = "explode"
-	propStyle   = "style"
+	propExplode  = "explode"
+	propStyle    = "style"
+	propExamples = "examples"
 )
 
 // schema props.
@@ -38,6 +39,10 @@ const (
 	propDefault         = "default"
 	propExample         = "example"
 	propRequired        = "required"
+	propSensitive       = "sensitive"
+	propRedact          = "redact"
+	propRequiredIf      = "requiredIf"
+	propConst           = "const"
 	// string specified properties.
 	propMinLength = "minLength"
 	propMaxLength = "maxLength"
@@ -55,12 +60,31 @@ const (
 	propMinItems    = "minItems"
 	propMaxItems    = "maxItems"
 	propUniqueItems = "uniqueItems"
+	// object specified properties.
+	propPatternProperties = "patternProperties"
+	propPropertyNames     = "propertyNames"
+	propMinProperties     = "minProperties"
+	propMaxProperties     = "maxProperties"
+	// cross-field properties, comparing the field to a sibling field.
+	propLtField  = "ltField"
+	propLteField = "lteField"
+	propGtField  = "gtField"
+	propGteField = "gteField"
+	propNeField  = "neField"
+	// interface properties, naming the schema provider documenting the field.
+	propSchema = "schema"
+	// parameter properties, naming the deprecated names of the parameter and the converter of its values.
+	propAlias     = "alias"
+	propConverter = "converter"
 )
 
 type ck string
 
 const (
-	KeyInput ck = "soda::input"
+	KeyInput          ck = "soda::input"
+	KeyProvidedFields ck = "soda::provided-fields"
+
+	keyOperationBuilder ck = "soda::operation-builder"
 )
 
 const (
@@ -0,0 +1,83 @@
+package soda
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func init() {
+	fieldRuleBuilders[propConst] = constRule
+}
+
+// injectOAIConst restricts the schema of a field tagged with `oai:"const=v1"` to the value, with an enum of one
+// as OpenAPI 3.0 has no const.
+func (f *tagsResolver) injectOAIConst(schema *openapi3.Schema) {
+	val, ok := f.pairs[propConst]
+	if !ok {
+		return
+	}
+	var value any = val
+	switch {
+	case schema.Type.Is(typeInteger):
+		if num, err := toIntE(val); err == nil {
+			value = num
+		}
+	case schema.Type.Is(typeNumber):
+		if num, err := toFloatE(val); err == nil {
+			value = num
+		}
+	case schema.Type.Is(typeBoolean):
+		value = toBool(val)
+	}
+	schema.Enum = []any{value}
+}
+
+// constRule builds the rule enforcing the value of a const prop, the fields left unset by optional pointers
+// being skipped.
+func constRule(_ reflect.Type, f reflect.StructField, value string) fieldRule {
+	t := derefType(f.Type)
+	var equal func(v reflect.Value) bool
+	switch t.Kind() {
+	case reflect.String:
+		equal = func(v reflect.Value) bool { return v.String() == value }
+	case reflect.Bool:
+		want := toBool(value)
+		equal = func(v reflect.Value) bool { return v.Bool() == want }
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		want, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			panic(fmt.Sprintf("the const %q of the field %s is not an integer", value, f.Name))
+		}
+		equal = func(v reflect.Value) bool { return v.Int() == want }
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		want, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			panic(fmt.Sprintf("the const %q of the field %s is not an unsigned integer", value, f.Name))
+		}
+		equal = func(v reflect.Value) bool { return v.Uint() == want }
+	case reflect.Float32, reflect.Float64:
+		want, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			panic(fmt.Sprintf("the const %q of the field %s is not a number", value, f.Name))
+		}
+		equal = func(v reflect.Value) bool { return v.Float() == want }
+	default:
+		panic(fmt.Sprintf("the const of the field %s is not supported on %s", f.Name, f.Type))
+	}
+	reason := "must be " + value
+	return func(_, v reflect.Value) string {
+		for v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return ""
+			}
+			v = v.Elem()
+		}
+		if !equal(v) {
+			return reason
+		}
+		return ""
+	}
+}
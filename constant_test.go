@@ -0,0 +1,71 @@
+package soda_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type versionedEvent struct {
+	Type    string `json:"type"    oai:"const=order.created"`
+	Version int    `json:"version" oai:"const=2"`
+	Live    *bool  `json:"live"    oai:"const=true"`
+}
+
+func TestConst(t *testing.T) {
+	Convey("Given a body with const fields", t, func() {
+		type input struct {
+			Body versionedEvent `body:"json"`
+		}
+		engine := soda.New().SetBindErrorStatus(http.StatusBadRequest)
+		engine.Post("/events", func(c *fiber.Ctx) error { return c.SendStatus(http.StatusAccepted) }).SetInput(input{}).OK()
+
+		post := func(body string) (int, string) {
+			req := httptest.NewRequest("POST", "/events", strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := engine.App().Test(req)
+			So(err, ShouldBeNil)
+			data, _ := io.ReadAll(resp.Body)
+			return resp.StatusCode, string(data)
+		}
+
+		Convey("The values should be enforced at bind time", func() {
+			status, _ := post(`{"type":"order.created","version":2}`)
+			So(status, ShouldEqual, http.StatusAccepted)
+
+			status, body := post(`{"type":"order.deleted","version":2}`)
+			So(status, ShouldEqual, http.StatusBadRequest)
+			So(body, ShouldContainSubstring, "/type must be order.created")
+
+			_, body = post(`{"type":"order.created","version":1}`)
+			So(body, ShouldContainSubstring, "/version must be 2")
+
+			_, body = post(`{"type":"order.created","version":2,"live":false}`)
+			So(body, ShouldContainSubstring, "/live must be true")
+		})
+
+		Convey("The values should be documented as enums of one", func() {
+			schema := engine.OpenAPI().Paths.Value("/events").Post.RequestBody.Value.Content.Get("application/json").Schema.Value
+			So(schema.Properties["type"].Value.Enum, ShouldResemble, []any{"order.created"})
+			So(schema.Properties["version"].Value.Enum, ShouldResemble, []any{2})
+			So(schema.Properties["live"].Value.Enum, ShouldResemble, []any{true})
+		})
+
+		Convey("Invalid consts should panic", func() {
+			type invalid struct {
+				Body struct {
+					Version int `json:"version" oai:"const=two"`
+				} `body:"json"`
+			}
+			So(func() {
+				soda.New().Post("/invalid", func(c *fiber.Ctx) error { return nil }).SetInput(invalid{}).OK()
+			}, ShouldPanicWith, `the const "two" of the field Version is not an integer`)
+		})
+	})
+}
@@ -0,0 +1,111 @@
+package soda
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// ControllerRoute routes a method of a controller.
+type ControllerRoute struct {
+	// Method is the HTTP method of the route, e.g. http.MethodGet.
+	Method string
+	// Pattern is the pattern of the route relative to the router.
+	Pattern string
+	// Handler is the name of the method of the controller handling the route.
+	Handler string
+	// Setup customizes the operation before its registration, e.g. with a summary or tags.
+	Setup func(op *OperationBuilder)
+}
+
+// Controller is implemented by the controllers routing their methods with a table rather than by convention.
+type Controller interface {
+	Routes() []ControllerRoute
+}
+
+// controllerVerbs are the HTTP methods of the handlers named by convention, e.g. GetUserByID.
+var controllerVerbs = []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete}
+
+// Register registers the operations handled by the methods of the controller at once, e.g.
+// func (c *Users) GetUserByID(ctx *fiber.Ctx, in *GetUser) (*User, error).
+// The routes are those of the Routes method of a Controller, or derived from the names of the methods starting
+// with an HTTP verb otherwise: the words up to "By" form the path, and the words after it a path parameter,
+// so that GetOrderItemsByID handles GET /order-items/:id. The methods are injected handlers, as with Inject,
+// whose first parameter after the context is the input when it is a pointer to a struct without provider,
+// and whose output is documented as the 200 response.
+func (r *Router) Register(controller any) *Router {
+	v := reflect.ValueOf(controller)
+	var routes []ControllerRoute
+	if c, ok := controller.(Controller); ok {
+		routes = c.Routes()
+	} else {
+		routes = conventionalRoutes(v.Type())
+	}
+	return r.registerRoutes(v, routes)
+}
+
+// registerRoutes registers the operations of the routes handled by the methods of the controller.
+func (r *Router) registerRoutes(v reflect.Value, routes []ControllerRoute) *Router {
+	defer r.recoverMisuse(v.Type().String())
+	for _, route := range routes {
+		method := v.MethodByName(route.Handler)
+		if !method.IsValid() {
+			panic(fmt.Sprintf("the controller %s has no method %s", v.Type(), route.Handler))
+		}
+		op := r.Add(route.Method, route.Pattern)
+		if t := method.Type(); t.NumIn() > 1 && r.controllerInput(t.In(1)) {
+			op.SetInput(reflect.New(t.In(1).Elem()).Elem().Interface())
+		}
+		if route.Setup != nil {
+			route.Setup(op)
+		}
+		op.Inject(method.Interface())
+		op.OK()
+	}
+	return r
+}
+
+// controllerInput reports whether the parameter of a controller method is its input rather than a dependency.
+func (r *Router) controllerInput(t reflect.Type) bool {
+	if t.Kind() != reflect.Ptr || t.Elem().Kind() != reflect.Struct {
+		return false
+	}
+	_, provided := r.engine.providers[t]
+	return !provided
+}
+
+// conventionalRoutes derives the routes of the methods of the controller type named after an HTTP verb whose
+// first parameter is the context.
+func conventionalRoutes(t reflect.Type) []ControllerRoute {
+	var routes []ControllerRoute
+	for i := 0; i < t.NumMethod(); i++ {
+		m := t.Method(i)
+		// The receiver is the first parameter of the method of the type.
+		if m.Type.NumIn() < 2 || m.Type.In(1) != ctxType {
+			continue
+		}
+		words := splitWords(m.Name)
+		verb := strings.ToUpper(words[0])
+		if !slices.Contains(controllerVerbs, verb) {
+			continue
+		}
+		routes = append(routes, ControllerRoute{Method: verb, Pattern: conventionalPattern(words[1:]), Handler: m.Name})
+	}
+	return routes
+}
+
+// conventionalPattern returns the pattern named by the words of a method name following the verb, such as
+// "/order-items/:id" for "order", "items", "by", "id".
+func conventionalPattern(words []string) string {
+	resource, param := words, []string(nil)
+	if i := slices.Index(words, "by"); i >= 0 {
+		resource, param = words[:i], words[i+1:]
+	}
+	pattern := "/" + strings.Join(resource, "-")
+	if len(param) != 0 {
+		pattern = strings.TrimSuffix(pattern, "/") + "/:" + strings.Join(param, "_")
+	}
+	return pattern
+}
@@ -0,0 +1,133 @@
+package soda_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type controllerItem struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type getItemInput struct {
+	ID string `path:"id"`
+}
+
+type createItemInput struct {
+	Body struct {
+		Name string `json:"name"`
+	} `body:"json"`
+}
+
+type itemStore struct {
+	prefix string
+}
+
+// itemsController routes its methods by convention.
+type itemsController struct{}
+
+func (itemsController) GetItems(c *fiber.Ctx) ([]controllerItem, error) {
+	return []controllerItem{{ID: "1", Name: "chair"}}, nil
+}
+
+func (itemsController) GetItemsByID(c *fiber.Ctx, in *getItemInput, store *itemStore) (*controllerItem, error) {
+	return &controllerItem{ID: store.prefix + in.ID}, nil
+}
+
+func (itemsController) PostItems(c *fiber.Ctx, in *createItemInput) (*controllerItem, error) {
+	return &controllerItem{ID: "2", Name: in.Body.Name}, nil
+}
+
+func (itemsController) DeleteItemsByID(c *fiber.Ctx, in *getItemInput) error {
+	return c.SendStatus(http.StatusNoContent)
+}
+
+// Describe is not a handler, as its first parameter is not the context.
+func (itemsController) Describe() string { return "items" }
+
+// ordersController routes its methods with a table.
+type ordersController struct{}
+
+func (ordersController) Routes() []soda.ControllerRoute {
+	return []soda.ControllerRoute{
+		{Method: http.MethodGet, Pattern: "/orders/:id", Handler: "Find", Setup: func(op *soda.OperationBuilder) {
+			op.SetSummary("Find an order").AddTags("orders")
+		}},
+	}
+}
+
+func (ordersController) Find(c *fiber.Ctx, in *getItemInput) (*controllerItem, error) {
+	return &controllerItem{ID: in.ID, Name: "order"}, nil
+}
+
+func TestRegisterController(t *testing.T) {
+	Convey("Given controllers registered at once", t, func() {
+		engine := soda.New()
+		engine.Provide(&itemStore{prefix: "item-"})
+		engine.Group("/api").Register(itemsController{})
+		engine.Register(ordersController{})
+
+		send := func(method, target, body string) (int, string) {
+			req := httptest.NewRequest(method, target, strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := engine.App().Test(req)
+			So(err, ShouldBeNil)
+			data, _ := io.ReadAll(resp.Body)
+			return resp.StatusCode, string(data)
+		}
+
+		Convey("The methods named after verbs should be routed by convention", func() {
+			status, body := send("GET", "/api/items", "")
+			So(status, ShouldEqual, http.StatusOK)
+			So(body, ShouldEqual, `[{"id":"1","name":"chair"}]`)
+
+			_, body = send("GET", "/api/items/7", "")
+			So(body, ShouldEqual, `{"id":"item-7","name":""}`)
+
+			_, body = send("POST", "/api/items", `{"name":"desk"}`)
+			So(body, ShouldEqual, `{"id":"2","name":"desk"}`)
+
+			status, _ = send("DELETE", "/api/items/7", "")
+			So(status, ShouldEqual, http.StatusNoContent)
+			So(engine.Routes(), ShouldHaveLength, 5)
+		})
+
+		Convey("The inputs and outputs should be inferred from the signatures", func() {
+			get := engine.OpenAPI().Paths.Value("/api/items/:id").Get
+			So(get.Parameters, ShouldHaveLength, 1)
+			So(get.Parameters[0].Value.Name, ShouldEqual, "id")
+			So(get.Responses.Status(http.StatusOK).Value.Content.Get("application/json").Schema.Ref,
+				ShouldEqual, "#/components/schemas/soda_test.controllerItem")
+			So(engine.OpenAPI().Paths.Value("/api/items").Post.RequestBody, ShouldNotBeNil)
+		})
+
+		Convey("The methods of a routes table should be registered", func() {
+			status, body := send("GET", "/orders/3", "")
+			So(status, ShouldEqual, http.StatusOK)
+			So(body, ShouldEqual, `{"id":"3","name":"order"}`)
+			op := engine.OpenAPI().Paths.Value("/orders/:id").Get
+			So(op.Summary, ShouldEqual, "Find an order")
+			So(op.Tags, ShouldResemble, []string{"orders"})
+		})
+
+		Convey("Routing an unknown method should panic", func() {
+			So(func() {
+				engine.Register(badController{})
+			}, ShouldPanicWith, "the controller soda_test.badController has no method Missing")
+		})
+	})
+}
+
+type badController struct{}
+
+func (badController) Routes() []soda.ControllerRoute {
+	return []soda.ControllerRoute{{Method: http.MethodGet, Pattern: "/bad", Handler: "Missing"}}
+}
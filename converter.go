@@ -0,0 +1,123 @@
+package soda
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// converterExtension names the converter of a path parameter.
+const converterExtension = "x-converter"
+
+// Converter converts the values of the path parameters tagged with its name, e.g. `path:"id" oai:"converter=base62"`,
+// before they are bound, by reflection or by the binders generated with soda-gen, so that the conversion is declared
+// once. The schema of the parameters is the string schema of the values before the conversion.
+type Converter struct {
+	// Convert converts the value of the parameter into the one bound, the request being rejected with a 400
+	// response if it fails.
+	Convert func(value string) (string, error)
+	// Pattern and Format document the values of the parameters before the conversion.
+	Pattern string
+	Format  string
+}
+
+var (
+	convertersMu sync.RWMutex
+	// converters are the converters of the path parameters, by name.
+	converters = map[string]Converter{
+		"base62": {Convert: decodeBase62, Pattern: "^[0-9A-Za-z]{1,11}$", Format: "base62"},
+		"slug":   {Convert: normalizeSlug, Pattern: "^[0-9A-Za-z_ -]+$", Format: "slug"},
+	}
+)
+
+// RegisterConverter registers the converter under the name the path parameters refer to with the converter prop.
+// The built-in converters are base62, decoding base62 IDs into their decimal value for integer fields, and slug,
+// normalizing slugs to lowercase with hyphens.
+func RegisterConverter(name string, converter Converter) {
+	convertersMu.Lock()
+	defer convertersMu.Unlock()
+	converters[name] = converter
+}
+
+// converterOf returns the converter registered under the name.
+func converterOf(name string) (Converter, bool) {
+	convertersMu.RLock()
+	defer convertersMu.RUnlock()
+	converter, ok := converters[name]
+	return converter, ok
+}
+
+// Convert converts the value of a path parameter with the converter registered under the name. It is used by the
+// binders generated with soda-gen, which bind the path parameters in place of the reflection binding.
+func Convert(name, value string) (string, error) {
+	converter, ok := converterOf(name)
+	if !ok {
+		return "", fmt.Errorf("the converter %s is not registered", name)
+	}
+	return converter.Convert(value)
+}
+
+// applyConverter documents the converter of the path parameter, if any, with the schema of its values before
+// the conversion.
+func applyConverter(parameter *openapi3.Parameter, field *tagsResolver) {
+	name, ok := field.pairs[propConverter]
+	if !ok {
+		return
+	}
+	if parameter.In != openapi3.ParameterInPath {
+		panic(fmt.Sprintf("the converter of the parameter %s can only be set on path parameters, not %s ones", parameter.Name, parameter.In))
+	}
+	converter, ok := converterOf(name)
+	if !ok {
+		panic(fmt.Sprintf("the converter %s of the parameter %s is not registered", name, parameter.Name))
+	}
+	schema := openapi3.NewStringSchema().WithPattern(converter.Pattern).WithFormat(converter.Format)
+	parameter.Schema = schema.NewRef()
+	parameter.Extensions = map[string]any{converterExtension: name}
+}
+
+// pathConvertersOf returns the converters of the path parameters, by parameter.
+func pathConvertersOf(parameters openapi3.Parameters) map[string]Converter {
+	var pathConverters map[string]Converter
+	for _, param := range parameters {
+		name, ok := param.Value.Extensions[converterExtension].(string)
+		if !ok || param.Value.In != openapi3.ParameterInPath {
+			continue
+		}
+		if pathConverters == nil {
+			pathConverters = make(map[string]Converter)
+		}
+		pathConverters[param.Value.Name], _ = converterOf(name)
+	}
+	return pathConverters
+}
+
+// base62Digits are the digits of the base62 IDs.
+const base62Digits = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// decodeBase62 decodes the base62 ID into its decimal value.
+func decodeBase62(value string) (string, error) {
+	if value == "" {
+		return "", fmt.Errorf("empty base62 ID")
+	}
+	var n uint64
+	for _, r := range value {
+		digit := strings.IndexRune(base62Digits, r)
+		if digit < 0 {
+			return "", fmt.Errorf("invalid base62 ID %q", value)
+		}
+		if n > (1<<63-1-uint64(digit))/62 {
+			return "", fmt.Errorf("base62 ID %q overflows int64", value)
+		}
+		n = n*62 + uint64(digit)
+	}
+	return strconv.FormatUint(n, 10), nil
+}
+
+// normalizeSlug lowercases the slug and replaces its spaces and underscores with hyphens.
+func normalizeSlug(value string) (string, error) {
+	return strings.ToLower(strings.NewReplacer(" ", "-", "_", "-").Replace(strings.TrimSpace(value))), nil
+}
@@ -0,0 +1,75 @@
+package soda_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type converterInput struct {
+	ID   int64  `path:"id"   oai:"converter=base62"`
+	Slug string `path:"slug" oai:"converter=slug"`
+	Code string `path:"code" oai:"converter=upper"`
+}
+
+func TestConverters(t *testing.T) {
+	soda.RegisterConverter("upper", soda.Converter{
+		Convert: func(value string) (string, error) { return strings.ToUpper(value), nil },
+		Pattern: "^[a-z]{2}$",
+	})
+
+	Convey("Given an operation whose path parameters are converted", t, func() {
+		engine := soda.New().SetBindErrorStatus(http.StatusBadRequest)
+		engine.Get("/posts/:id/:slug/:code", func(c *fiber.Ctx) error {
+			in := soda.GetInput[converterInput](c)
+			return c.JSON(in)
+		}).SetInput(converterInput{}).OK()
+		get := func(path string) (int, string) {
+			resp, err := engine.App().Test(httptest.NewRequest("GET", path, nil))
+			So(err, ShouldBeNil)
+			body, _ := io.ReadAll(resp.Body)
+			return resp.StatusCode, string(body)
+		}
+
+		Convey("The values should be converted before binding", func() {
+			status, body := get("/posts/1z/Hello_World/fr")
+			So(status, ShouldEqual, http.StatusOK)
+			So(body, ShouldEqual, `{"ID":123,"Slug":"hello-world","Code":"FR"}`)
+		})
+
+		Convey("The values failing the conversion should be rejected", func() {
+			status, body := get("/posts/1-z/hello/fr")
+			So(status, ShouldEqual, http.StatusBadRequest)
+			So(body, ShouldContainSubstring, `invalid base62 ID`)
+		})
+
+		Convey("The schema should document the values before the conversion", func() {
+			params := engine.OpenAPI().Paths.Value("/posts/:id/:slug/:code").Get.Parameters
+			id := params.GetByInAndName("path", "id")
+			So(id.Schema.Value.Type.Is("string"), ShouldBeTrue)
+			So(id.Schema.Value.Pattern, ShouldEqual, "^[0-9A-Za-z]{1,11}$")
+			So(id.Schema.Value.Format, ShouldEqual, "base62")
+			So(id.Extensions["x-converter"], ShouldEqual, "base62")
+			So(params.GetByInAndName("path", "code").Schema.Value.Pattern, ShouldEqual, "^[a-z]{2}$")
+		})
+
+		Convey("The converters should only be set on path parameters", func() {
+			So(func() {
+				engine.Get("/search", nil).SetInput(struct {
+					Q string `query:"q" oai:"converter=slug"`
+				}{})
+			}, ShouldPanicWith, "the converter of the parameter q can only be set on path parameters, not query ones")
+			So(func() {
+				engine.Get("/tags/:name", nil).SetInput(struct {
+					Name string `path:"name" oai:"converter=unknown"`
+				}{})
+			}, ShouldPanicWith, "the converter unknown of the parameter name is not registered")
+		})
+	})
+}
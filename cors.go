@@ -0,0 +1,130 @@
+package soda
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// CORS is the cross-origin resource sharing policy of the operations, whose allowed methods are those routed on
+// the path of each request rather than a static list.
+type CORS struct {
+	// AllowOrigins are the origins allowed to send requests, e.g. "https://app.example.com", or "*" for any.
+	AllowOrigins []string `json:"allowOrigins"`
+	// AllowHeaders are the request headers allowed in the requests, those requested by the preflight requests if
+	// empty.
+	AllowHeaders []string `json:"allowHeaders,omitempty"`
+	// ExposeHeaders are the response headers exposed to the clients.
+	ExposeHeaders []string `json:"exposeHeaders,omitempty"`
+	// AllowCredentials allows the requests with credentials, which the wildcard origin "*" cannot be allowed with.
+	AllowCredentials bool `json:"allowCredentials,omitempty"`
+	// MaxAge is the duration the results of the preflight requests can be cached.
+	MaxAge time.Duration `json:"maxAge,omitempty"`
+}
+
+// CORSPolicy is the effective CORS policy of the engine, with the methods allowed on the paths of the routes.
+type CORSPolicy struct {
+	CORS
+	Paths map[string][]string `json:"paths"`
+}
+
+// EnableCORS answers the preflight requests to the paths routed through soda with the methods routed on them, and
+// sets the CORS headers of the responses to the allowed origins. It must be enabled before registering the routes
+// of the app, the preflight requests being answered before routing.
+// It panics if the credentials are allowed from any origin, which would let any site send credentialed requests.
+func (e *Engine) EnableCORS(cors CORS) *Engine {
+	if cors.AllowCredentials && slices.Contains(cors.AllowOrigins, "*") {
+		panic("the credentials cannot be allowed from the wildcard origin *, list the allowed origins instead")
+	}
+	if e.cors == nil {
+		e.app.Use(e.handleCORS)
+	}
+	e.cors = &cors
+	return e
+}
+
+// CORSPolicy returns the effective CORS policy, with the methods allowed on the paths of the routes.
+func (e *Engine) CORSPolicy() CORSPolicy {
+	policy := CORSPolicy{Paths: make(map[string][]string)}
+	if e.cors != nil {
+		policy.CORS = *e.cors
+	}
+	for _, route := range e.routes {
+		if methods := policy.Paths[route.Path]; !slices.Contains(methods, route.Method) {
+			methods = append(methods, route.Method)
+			slices.Sort(methods)
+			policy.Paths[route.Path] = methods
+		}
+	}
+	return policy
+}
+
+// ServeCORSPolicy serves the effective CORS policy as JSON, to debug the rejected cross-origin requests.
+// It is meant for development and should not be exposed in production.
+func (e *Engine) ServeCORSPolicy(pattern string) *Engine {
+	e.serveDoc(pattern, func(c *fiber.Ctx) error {
+		return c.JSON(e.CORSPolicy())
+	})
+	return e
+}
+
+// handleCORS answers the preflight requests and sets the CORS headers of the requests from the allowed origins.
+func (e *Engine) handleCORS(c *fiber.Ctx) error {
+	cors := e.cors
+	origin := c.Get(fiber.HeaderOrigin)
+	if origin == "" {
+		return c.Next()
+	}
+	c.Vary(fiber.HeaderOrigin)
+	allowOrigin := cors.allowOrigin(origin)
+	if allowOrigin == "" {
+		return c.Next()
+	}
+	preflight := c.Method() == http.MethodOptions && c.Get(fiber.HeaderAccessControlRequestMethod) != ""
+	if !preflight {
+		c.Set(fiber.HeaderAccessControlAllowOrigin, allowOrigin)
+		if cors.AllowCredentials {
+			c.Set(fiber.HeaderAccessControlAllowCredentials, "true")
+		}
+		if len(cors.ExposeHeaders) != 0 {
+			c.Set(fiber.HeaderAccessControlExposeHeaders, strings.Join(cors.ExposeHeaders, ", "))
+		}
+		return c.Next()
+	}
+	methods := e.allowedMethods(c.Path())
+	if len(methods) == 0 {
+		return c.Next()
+	}
+	c.Vary(fiber.HeaderAccessControlRequestMethod, fiber.HeaderAccessControlRequestHeaders)
+	c.Set(fiber.HeaderAccessControlAllowOrigin, allowOrigin)
+	c.Set(fiber.HeaderAccessControlAllowMethods, strings.Join(methods, ", "))
+	if len(cors.AllowHeaders) != 0 {
+		c.Set(fiber.HeaderAccessControlAllowHeaders, strings.Join(cors.AllowHeaders, ", "))
+	} else if headers := c.Get(fiber.HeaderAccessControlRequestHeaders); headers != "" {
+		c.Set(fiber.HeaderAccessControlAllowHeaders, headers)
+	}
+	if cors.AllowCredentials {
+		c.Set(fiber.HeaderAccessControlAllowCredentials, "true")
+	}
+	if cors.MaxAge > 0 {
+		c.Set(fiber.HeaderAccessControlMaxAge, strconv.Itoa(int(cors.MaxAge.Seconds())))
+	}
+	return c.SendStatus(http.StatusNoContent)
+}
+
+// allowOrigin returns the value of the Access-Control-Allow-Origin header of the origin, "" if it isn't allowed.
+func (cors *CORS) allowOrigin(origin string) string {
+	for _, allowed := range cors.AllowOrigins {
+		switch {
+		case allowed == "*":
+			return "*"
+		case strings.EqualFold(allowed, origin):
+			return origin
+		}
+	}
+	return ""
+}
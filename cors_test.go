@@ -0,0 +1,81 @@
+package soda_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCORS(t *testing.T) {
+	Convey("Given an engine with CORS enabled", t, func() {
+		engine := soda.New().EnableCORS(soda.CORS{
+			AllowOrigins:  []string{"https://app.example.com"},
+			ExposeHeaders: []string{"X-Request-Id"},
+			MaxAge:        10 * time.Minute,
+		})
+		handler := func(c *fiber.Ctx) error { return c.SendString("ok") }
+		engine.Get("/users/:id", handler).OK()
+		engine.Delete("/users/:id", handler).OK()
+		engine.Post("/users", handler).OK()
+		engine.ServeCORSPolicy("/debug/cors")
+		preflight := func(origin, target string) *http.Response {
+			req := httptest.NewRequest("OPTIONS", target, nil)
+			req.Header.Set("Origin", origin)
+			req.Header.Set("Access-Control-Request-Method", "DELETE")
+			req.Header.Set("Access-Control-Request-Headers", "Authorization")
+			resp, err := engine.App().Test(req)
+			So(err, ShouldBeNil)
+			return resp
+		}
+
+		Convey("The preflight requests should be answered with the methods routed on the path", func() {
+			resp := preflight("https://app.example.com", "/users/42")
+			So(resp.StatusCode, ShouldEqual, http.StatusNoContent)
+			So(resp.Header.Get("Access-Control-Allow-Origin"), ShouldEqual, "https://app.example.com")
+			So(resp.Header.Get("Access-Control-Allow-Methods"), ShouldEqual, "DELETE, GET")
+			So(resp.Header.Get("Access-Control-Allow-Headers"), ShouldEqual, "Authorization")
+			So(resp.Header.Get("Access-Control-Max-Age"), ShouldEqual, "600")
+
+			So(preflight("https://app.example.com", "/users").Header.Get("Access-Control-Allow-Methods"), ShouldEqual, "POST")
+		})
+
+		Convey("The requests from other origins should not be allowed", func() {
+			resp := preflight("https://evil.example.com", "/users/42")
+			So(resp.Header.Get("Access-Control-Allow-Origin"), ShouldBeEmpty)
+		})
+
+		Convey("The actual requests should be allowed", func() {
+			req := httptest.NewRequest("GET", "/users/42", nil)
+			req.Header.Set("Origin", "https://app.example.com")
+			resp, err := engine.App().Test(req)
+			So(err, ShouldBeNil)
+			So(resp.StatusCode, ShouldEqual, http.StatusOK)
+			So(resp.Header.Get("Access-Control-Allow-Origin"), ShouldEqual, "https://app.example.com")
+			So(resp.Header.Get("Access-Control-Expose-Headers"), ShouldEqual, "X-Request-Id")
+		})
+
+		Convey("The credentials should not be allowed from any origin", func() {
+			So(func() {
+				soda.New().EnableCORS(soda.CORS{AllowOrigins: []string{"*"}, AllowCredentials: true})
+			}, ShouldPanicWith, "the credentials cannot be allowed from the wildcard origin *, list the allowed origins instead")
+		})
+
+		Convey("The effective policy should be served", func() {
+			resp, err := engine.App().Test(httptest.NewRequest("GET", "/debug/cors", nil))
+			So(err, ShouldBeNil)
+			var policy soda.CORSPolicy
+			So(json.NewDecoder(resp.Body).Decode(&policy), ShouldBeNil)
+			So(policy.AllowOrigins, ShouldResemble, []string{"https://app.example.com"})
+			So(policy.Paths, ShouldResemble, map[string][]string{
+				"/users/:id": {"DELETE", "GET"},
+				"/users":     {"POST"},
+			})
+		})
+	})
+}
@@ -0,0 +1,210 @@
+package soda
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// constraintsExtension documents the cross-field constraints of an object schema which OpenAPI cannot express,
+// such as start < end.
+const constraintsExtension = "x-constraints"
+
+// fieldComparison is a comparison of a field to a sibling field, e.g. `oai:"ltField=end"`.
+type fieldComparison struct {
+	verb  string
+	holds func(cmp int) bool
+}
+
+var fieldComparisons = map[string]fieldComparison{
+	propLtField:  {"less than", func(cmp int) bool { return cmp < 0 }},
+	propLteField: {"less than or equal to", func(cmp int) bool { return cmp <= 0 }},
+	propGtField:  {"greater than", func(cmp int) bool { return cmp > 0 }},
+	propGteField: {"greater than or equal to", func(cmp int) bool { return cmp >= 0 }},
+	propNeField:  {"different from", func(cmp int) bool { return cmp != 0 }},
+}
+
+func init() {
+	for prop := range fieldComparisons {
+		fieldRuleBuilders[prop] = fieldComparisonRule(prop)
+	}
+}
+
+// fieldComparisonRule returns the builder of the rule comparing a field to the sibling field named by the prop.
+func fieldComparisonRule(prop string) fieldRuleBuilder {
+	comparison := fieldComparisons[prop]
+	return func(t reflect.Type, f reflect.StructField, value string) fieldRule {
+		sibling, ok := siblingField(t, value, "json")
+		if !ok {
+			panic(fmt.Sprintf("the %s of the field %s refers to the unknown field %s", prop, f.Name, value))
+		}
+		if derefType(sibling.Type) != derefType(f.Type) || !orderable(derefType(f.Type)) {
+			panic(fmt.Sprintf("the %s of the field %s cannot compare %s to %s", prop, f.Name, f.Type, sibling.Type))
+		}
+		reason := "must be " + comparison.verb + " " + value
+		return func(parent, v reflect.Value) string {
+			cmp, ok := compareValues(v, parent.FieldByIndex(sibling.Index))
+			if ok && !comparison.holds(cmp) {
+				return reason
+			}
+			return ""
+		}
+	}
+}
+
+// orderable reports whether the values of the type can be ordered by compareValues.
+func orderable(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64, reflect.String:
+		return true
+	}
+	return t == wnTime
+}
+
+// compareValues compares two values of the same orderable type, reporting false if either is unset.
+func compareValues(a, b reflect.Value) (int, bool) {
+	a, aok := derefValue(a)
+	b, bok := derefValue(b)
+	if !aok || !bok {
+		return 0, false
+	}
+	if a.Type() == wnTime {
+		at, bt := a.Interface().(time.Time), b.Interface().(time.Time)
+		if at.IsZero() || bt.IsZero() {
+			return 0, false
+		}
+		return at.Compare(bt), true
+	}
+	switch a.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return compareOrdered(a.Int(), b.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return compareOrdered(a.Uint(), b.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return compareOrdered(a.Float(), b.Float()), true
+	}
+	return compareOrdered(a.String(), b.String()), true
+}
+
+// derefValue dereferences the pointers to the value, reporting false on nil ones.
+func derefValue(v reflect.Value) (reflect.Value, bool) {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return v, false
+		}
+		v = v.Elem()
+	}
+	return v, true
+}
+
+func compareOrdered[T int64 | uint64 | float64 | string](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// Constraint is a cross-field rule of a struct type, such as "the end is after the start", documented on its schema
+// and enforced at bind time, so that the custom validation logic and its documentation are kept together.
+type Constraint struct {
+	// Name identifies the constraint, e.g. "period".
+	Name string
+	// Description describes the rule, e.g. "start must be before end".
+	Description string
+	// Fields lists the JSON names of the fields involved, the first one locating the violations.
+	Fields []string
+	// Schema expresses the rule in OpenAPI, when possible, e.g. with anyOf. It is added to the allOf of the schema
+	// of the type, the constraints without schema being documented in the x-constraints extension instead.
+	Schema *openapi3.Schema
+	// Check reports whether the value of the type satisfies the rule.
+	Check func(value any) bool
+}
+
+// Constrained is implemented by the types having cross-field constraints.
+type Constrained interface {
+	Constraints() []Constraint
+}
+
+var constrainedType = reflect.TypeOf((*Constrained)(nil)).Elem()
+
+// constraintsOf returns the constraints of the struct type, panicking if they are not well-formed.
+func constraintsOf(t reflect.Type) []Constraint {
+	if !reflect.PointerTo(t).Implements(constrainedType) {
+		return nil
+	}
+	constraints := reflect.New(t).Interface().(Constrained).Constraints()
+	for _, c := range constraints {
+		if c.Name == "" || c.Check == nil {
+			panic(fmt.Sprintf("the constraints of %s must have a name and a check", t))
+		}
+		for _, name := range c.Fields {
+			if _, ok := siblingField(t, name, "json"); !ok {
+				panic(fmt.Sprintf("the constraint %s of %s refers to the unknown field %s", c.Name, t, name))
+			}
+		}
+	}
+	return constraints
+}
+
+// checkConstraints checks the value of a struct against its constraints, returning the JSON pointer of the first
+// violation and its reason.
+func checkConstraints(v reflect.Value, constraints []Constraint, pointer string) (string, string) {
+	for _, c := range constraints {
+		if c.Check(v.Interface()) {
+			continue
+		}
+		if len(c.Fields) != 0 {
+			pointer += "/" + escapePointer(c.Fields[0])
+		}
+		reason := "violates the constraint " + c.Name
+		if c.Description != "" {
+			reason += ": " + c.Description
+		}
+		return pointer, reason
+	}
+	return "", ""
+}
+
+// documentConstraints documents the cross-field comparisons of the properties and the constraints of the type
+// on its schema.
+func documentConstraints(schema *openapi3.Schema, t reflect.Type, comparisons map[string]map[string]string) {
+	var docs []map[string]any
+	for _, propName := range sortedKeys(comparisons) {
+		for _, prop := range sortedKeys(comparisons[propName]) {
+			other := comparisons[propName][prop]
+			docs = append(docs, map[string]any{
+				"name":        prop,
+				"description": propName + " must be " + fieldComparisons[prop].verb + " " + other,
+				"fields":      []string{propName, other},
+			})
+		}
+	}
+	for _, c := range constraintsOf(t) {
+		if c.Schema != nil {
+			s := *c.Schema
+			s.Title, s.Description = c.Name, c.Description
+			schema.AllOf = append(schema.AllOf, s.NewRef())
+			continue
+		}
+		doc := map[string]any{"name": c.Name, "description": c.Description}
+		if len(c.Fields) != 0 {
+			doc["fields"] = slices.Clone(c.Fields)
+		}
+		docs = append(docs, doc)
+	}
+	if len(docs) == 0 {
+		return
+	}
+	if schema.Extensions == nil {
+		schema.Extensions = make(map[string]any)
+	}
+	schema.Extensions[constraintsExtension] = docs
+}
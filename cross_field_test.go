@@ -0,0 +1,127 @@
+package soda_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type booking struct {
+	Start    time.Time `json:"start"`
+	End      time.Time `json:"end"     oai:"gtField=start"`
+	MinGuest int       `json:"minGuest"`
+	MaxGuest *int      `json:"maxGuest" oai:"gteField=minGuest"`
+	Email    string    `json:"email"`
+	Phone    string    `json:"phone"`
+}
+
+func (booking) Constraints() []soda.Constraint {
+	return []soda.Constraint{
+		{
+			Name:        "contact",
+			Description: "email or phone must be set",
+			Fields:      []string{"email", "phone"},
+			Schema: &openapi3.Schema{AnyOf: openapi3.SchemaRefs{
+				{Value: &openapi3.Schema{Required: []string{"email"}}},
+				{Value: &openapi3.Schema{Required: []string{"phone"}}},
+			}},
+			Check: func(v any) bool {
+				b := v.(booking)
+				return b.Email != "" || b.Phone != ""
+			},
+		},
+		{
+			Name:        "stay",
+			Description: "a stay lasts at most 30 days",
+			Fields:      []string{"end"},
+			Check: func(v any) bool {
+				b := v.(booking)
+				return b.End.Sub(b.Start) <= 30*24*time.Hour
+			},
+		},
+	}
+}
+
+func TestCrossFieldConstraints(t *testing.T) {
+	Convey("Given a body with cross-field constraints", t, func() {
+		type input struct {
+			Body booking `body:"json"`
+		}
+		engine := soda.New().SetBindErrorStatus(http.StatusBadRequest)
+		engine.Post("/bookings", func(c *fiber.Ctx) error { return c.SendStatus(http.StatusNoContent) }).SetInput(input{}).OK()
+
+		post := func(body string) (int, string) {
+			req := httptest.NewRequest("POST", "/bookings", strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := engine.App().Test(req)
+			So(err, ShouldBeNil)
+			data, _ := io.ReadAll(resp.Body)
+			return resp.StatusCode, string(data)
+		}
+
+		Convey("The comparisons should be enforced at bind time", func() {
+			status, _ := post(`{"start":"2026-01-01T00:00:00Z","end":"2026-01-03T00:00:00Z","minGuest":2,"maxGuest":2,"email":"a@b.c"}`)
+			So(status, ShouldEqual, http.StatusNoContent)
+
+			status, body := post(`{"start":"2026-01-03T00:00:00Z","end":"2026-01-01T00:00:00Z","email":"a@b.c"}`)
+			So(status, ShouldEqual, http.StatusBadRequest)
+			So(body, ShouldContainSubstring, `"pointer":"/end"`)
+			So(body, ShouldContainSubstring, `/end must be greater than start`)
+
+			_, body = post(`{"minGuest":3,"maxGuest":2,"email":"a@b.c"}`)
+			So(body, ShouldContainSubstring, `/maxGuest must be greater than or equal to minGuest`)
+		})
+
+		Convey("The constraints of the type should be enforced at bind time", func() {
+			status, body := post(`{"minGuest":1}`)
+			So(status, ShouldEqual, http.StatusBadRequest)
+			So(body, ShouldContainSubstring, `/email violates the constraint contact: email or phone must be set`)
+
+			_, body = post(`{"start":"2026-01-01T00:00:00Z","end":"2026-03-01T00:00:00Z","phone":"123"}`)
+			So(body, ShouldContainSubstring, `/end violates the constraint stay: a stay lasts at most 30 days`)
+		})
+
+		Convey("The constraints should be documented", func() {
+			schema := engine.OpenAPI().Paths.Value("/bookings").Post.RequestBody.Value.Content.Get("application/json").Schema.Value
+			So(schema.AllOf, ShouldHaveLength, 1)
+			So(schema.AllOf[0].Value.Title, ShouldEqual, "contact")
+			So(schema.AllOf[0].Value.AnyOf, ShouldHaveLength, 2)
+
+			docs := schema.Extensions["x-constraints"].([]map[string]any)
+			So(docs, ShouldHaveLength, 3)
+			So(docs[0]["description"], ShouldEqual, "end must be greater than start")
+			So(docs[1]["description"], ShouldEqual, "maxGuest must be greater than or equal to minGuest")
+			So(docs[2]["name"], ShouldEqual, "stay")
+			So(docs[2]["fields"], ShouldResemble, []string{"end"})
+		})
+
+		Convey("Comparing to unknown or incomparable fields should panic", func() {
+			type unknown struct {
+				Body struct {
+					End int `json:"end" oai:"gtField=begin"`
+				} `body:"json"`
+			}
+			So(func() {
+				soda.New().Post("/unknown", func(c *fiber.Ctx) error { return nil }).SetInput(unknown{}).OK()
+			}, ShouldPanicWith, "the gtField of the field End refers to the unknown field begin")
+
+			type incomparable struct {
+				Body struct {
+					Start string `json:"start"`
+					End   int    `json:"end" oai:"gtField=start"`
+				} `body:"json"`
+			}
+			So(func() {
+				soda.New().Post("/incomparable", func(c *fiber.Ctx) error { return nil }).SetInput(incomparable{}).OK()
+			}, ShouldPanicWith, "the gtField of the field End cannot compare int to string")
+		})
+	})
+}
@@ -0,0 +1,67 @@
+package soda
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+)
+
+// supportedContentEncodings are the request body encodings decompressed by soda.
+var supportedContentEncodings = []any{"gzip", "x-gzip", "deflate", "identity"}
+
+// EnableRequestDecompression transparently decompresses gzip and deflate encoded request bodies before binding them,
+// refusing bodies larger than maxSize bytes once decompressed. Operations with a request body registered afterwards
+// document the supported encodings through the Content-Encoding header parameter.
+func (e *Engine) EnableRequestDecompression(maxSize int) *Engine {
+	e.maxDecompressedBodySize = maxSize
+	return e
+}
+
+// decompressBody replaces a compressed request body with its decompressed content.
+func decompressBody(ctx *fiber.Ctx, maxSize int) error {
+	var (
+		reader io.ReadCloser
+		err    error
+	)
+	switch strings.ToLower(strings.TrimSpace(ctx.Get(fiber.HeaderContentEncoding))) {
+	case "", "identity":
+		return nil
+	case "gzip", "x-gzip":
+		reader, err = gzip.NewReader(bytes.NewReader(ctx.Request().Body()))
+	case "deflate":
+		reader, err = zlib.NewReader(bytes.NewReader(ctx.Request().Body()))
+	default:
+		return fiber.ErrUnsupportedMediaType
+	}
+	if err != nil {
+		return fiber.NewError(http.StatusBadRequest, "invalid compressed request body")
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(io.LimitReader(reader, int64(maxSize)+1))
+	if err != nil {
+		return fiber.NewError(http.StatusBadRequest, "invalid compressed request body")
+	}
+	if len(data) > maxSize {
+		return fiber.ErrRequestEntityTooLarge
+	}
+	ctx.Request().SetBody(data)
+	ctx.Request().Header.Del(fiber.HeaderContentEncoding)
+	return nil
+}
+
+// documentContentEncoding documents the request body encodings accepted by the operation.
+func (op *OperationBuilder) documentContentEncoding() {
+	parameter := openapi3.NewHeaderParameter(fiber.HeaderContentEncoding).
+		WithDescription("The encoding of the request body, decompressed before binding.").
+		WithSchema(openapi3.NewStringSchema().WithEnum(supportedContentEncodings...))
+	op.operation.AddParameter(parameter)
+	op.operation.AddResponse(http.StatusUnsupportedMediaType, op.route.gen.GenerateResponse(http.StatusUnsupportedMediaType, nil, "", ""))
+	op.operation.AddResponse(http.StatusRequestEntityTooLarge, op.route.gen.GenerateResponse(http.StatusRequestEntityTooLarge, nil, "", ""))
+}
@@ -0,0 +1,87 @@
+package soda_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRequestDecompression(t *testing.T) {
+	Convey("Given an engine decompressing request bodies", t, func() {
+		type input struct {
+			Body struct {
+				Name string `json:"name"`
+			} `body:"json"`
+		}
+		engine := soda.New().EnableRequestDecompression(64)
+		engine.Post("/names", func(c *fiber.Ctx) error {
+			return c.SendString(soda.GetInput[input](c).Body.Name)
+		}).SetInput(input{}).OK()
+
+		post := func(encoding string, body []byte) (*http.Response, string) {
+			request, _ := http.NewRequest("POST", "/names", bytes.NewReader(body))
+			request.Header.Add("Content-Type", "application/json")
+			request.Header.Add("Content-Encoding", encoding)
+			response, _ := engine.App().Test(request)
+			data, _ := io.ReadAll(response.Body)
+			return response, string(data)
+		}
+		compress := func(w io.WriteCloser, buf *bytes.Buffer, payload string) []byte {
+			_, _ = w.Write([]byte(payload))
+			_ = w.Close()
+			return buf.Bytes()
+		}
+
+		Convey("The supported encodings should be documented", func() {
+			operation := engine.OpenAPI().Paths.Find("/names").Post
+			parameter := operation.Parameters.GetByInAndName("header", "Content-Encoding")
+			So(parameter, ShouldNotBeNil)
+			So(parameter.Schema.Value.Enum, ShouldResemble, []any{"gzip", "x-gzip", "deflate", "identity"})
+			So(operation.Responses.Status(http.StatusUnsupportedMediaType), ShouldNotBeNil)
+		})
+
+		Convey("A gzip body should be decompressed", func() {
+			buf := &bytes.Buffer{}
+			response, body := post("gzip", compress(gzip.NewWriter(buf), buf, `{"name": "gzip"}`))
+			So(response.StatusCode, ShouldEqual, http.StatusOK)
+			So(body, ShouldEqual, "gzip")
+
+			buf = &bytes.Buffer{}
+			response, body = post("x-gzip", compress(gzip.NewWriter(buf), buf, `{"name": "x-gzip"}`))
+			So(response.StatusCode, ShouldEqual, http.StatusOK)
+			So(body, ShouldEqual, "x-gzip")
+		})
+
+		Convey("A deflate body should be decompressed", func() {
+			buf := &bytes.Buffer{}
+			response, body := post("deflate", compress(zlib.NewWriter(buf), buf, `{"name": "deflate"}`))
+			So(response.StatusCode, ShouldEqual, http.StatusOK)
+			So(body, ShouldEqual, "deflate")
+		})
+
+		Convey("An oversized body should be refused", func() {
+			buf := &bytes.Buffer{}
+			payload := `{"name": "` + strings.Repeat("a", 100) + `"}`
+			response, _ := post("gzip", compress(gzip.NewWriter(buf), buf, payload))
+			So(response.StatusCode, ShouldEqual, http.StatusRequestEntityTooLarge)
+		})
+
+		Convey("A corrupted body should be a bad request", func() {
+			response, _ := post("gzip", []byte("not gzip"))
+			So(response.StatusCode, ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("An unknown encoding should be unsupported", func() {
+			response, _ := post("br", []byte(`{}`))
+			So(response.StatusCode, ShouldEqual, http.StatusUnsupportedMediaType)
+		})
+	})
+}
@@ -0,0 +1,118 @@
+package soda
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func init() {
+	fieldRuleBuilders[propRequiredIf] = requiredIfRule
+}
+
+// requiredIf is the condition of a field tagged with `oai:"requiredIf=type=card"`, required when the sibling
+// field is set to one of the values, or with `oai:"requiredIf=type"`, required when the sibling field is set.
+type requiredIf struct {
+	field  string
+	values []string
+}
+
+func parseRequiredIf(value string) requiredIf {
+	field, values, ok := strings.Cut(value, "=")
+	cond := requiredIf{field: strings.TrimSpace(field)}
+	if ok {
+		cond.values = strings.Split(values, SeparatorPropItem)
+	}
+	return cond
+}
+
+// describe describes the condition, such as "type is card".
+func (cond requiredIf) describe() string {
+	if len(cond.values) == 0 {
+		return cond.field + " is set"
+	}
+	return cond.field + " is " + strings.Join(cond.values, " or ")
+}
+
+// siblingField returns the field of the struct named name in the documents of the name tag.
+func siblingField(t reflect.Type, name, nameTag string) (reflect.StructField, bool) {
+	for _, f := range reflect.VisibleFields(t) {
+		if !f.Anonymous && f.IsExported() && newTagsResolver(f).name(nameTag) == name {
+			return f, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// requiredIfRule builds the rule enforcing the condition of a requiredIf prop.
+func requiredIfRule(t reflect.Type, f reflect.StructField, value string) fieldRule {
+	cond := parseRequiredIf(value)
+	sibling, ok := siblingField(t, cond.field, "json")
+	if !ok {
+		panic(fmt.Sprintf("the requiredIf condition of the field %s refers to the unknown field %s", f.Name, cond.field))
+	}
+	reason := "is required when " + cond.describe()
+	return func(parent, value reflect.Value) string {
+		other := parent.FieldByIndex(sibling.Index)
+		for other.Kind() == reflect.Ptr {
+			if other.IsNil() {
+				return ""
+			}
+			other = other.Elem()
+		}
+		if len(cond.values) == 0 && other.IsZero() {
+			return ""
+		}
+		if len(cond.values) != 0 && !slices.Contains(cond.values, fmt.Sprint(other.Interface())) {
+			return ""
+		}
+		if value.IsZero() {
+			return reason
+		}
+		return ""
+	}
+}
+
+// requiredIfSchema returns the schema requiring the property when the condition of its requiredIf prop holds.
+// OpenAPI 3.1 documents express it with dependentRequired or if/then; OpenAPI 3.0 has neither, so it is expressed
+// with anyOf there: either the condition doesn't hold, or the property is present.
+func (g *Generator) requiredIfSchema(schema *openapi3.Schema, property, value string) *openapi3.SchemaRef {
+	cond := parseRequiredIf(value)
+	required := openapi3.NewSchema().WithRequired([]string{property})
+	if len(cond.values) == 0 {
+		if g.openAPI31() {
+			return &openapi3.SchemaRef{Value: &openapi3.Schema{Extensions: map[string]any{
+				"dependentRequired": map[string][]string{cond.field: {property}},
+			}}}
+		}
+		unmet := openapi3.NewSchema()
+		unmet.Not = openapi3.NewSchema().WithRequired([]string{cond.field}).NewRef()
+		return openapi3.NewAnyOfSchema(unmet, required).NewRef()
+	}
+	typ := typeString
+	if ref, ok := schema.Properties[cond.field]; ok && ref != nil {
+		if s := derefSchema(g.doc, ref); s.Type != nil && len(s.Type.Slice()) == 1 {
+			typ = s.Type.Slice()[0]
+		}
+	}
+	values := toSlice(strings.Join(cond.values, SeparatorPropItem), typ)
+	if values == nil {
+		values = make([]any, len(cond.values))
+		for i, v := range cond.values {
+			values[i] = v
+		}
+	}
+	if g.openAPI31() {
+		met := openapi3.NewSchema().WithRequired([]string{cond.field})
+		met.Properties = openapi3.Schemas{cond.field: openapi3.NewSchema().WithEnum(values...).NewRef()}
+		return &openapi3.SchemaRef{Value: &openapi3.Schema{Extensions: map[string]any{"if": met, "then": required}}}
+	}
+	unmet := openapi3.NewSchema()
+	unmet.Properties = openapi3.Schemas{
+		cond.field: &openapi3.SchemaRef{Value: &openapi3.Schema{Not: openapi3.NewSchema().WithEnum(values...).NewRef()}},
+	}
+	return openapi3.NewAnyOfSchema(unmet, required).NewRef()
+}
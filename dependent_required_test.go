@@ -0,0 +1,114 @@
+package soda_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type paymentMethod struct {
+	Type       string  `json:"type"`
+	CardNumber *string `json:"card_number" oai:"requiredIf=type=card"`
+	IBAN       string  `json:"iban"        oai:"requiredIf=type=transfer,sepa"`
+	Reference  string  `json:"reference"   oai:"requiredIf=memo"`
+	Memo       string  `json:"memo,omitempty"`
+}
+
+type checkoutInput struct {
+	Body struct {
+		Methods []paymentMethod `json:"methods"`
+	} `body:"json"`
+}
+
+func TestRequiredIf(t *testing.T) {
+	Convey("Given a body with conditionally required fields", t, func() {
+		engine := soda.New().SetBindErrorStatus(http.StatusBadRequest)
+		engine.Post("/checkout", func(c *fiber.Ctx) error { return c.SendStatus(http.StatusNoContent) }).
+			SetInput(checkoutInput{}).OK()
+
+		post := func(body string) (int, string) {
+			req := httptest.NewRequest("POST", "/checkout", strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := engine.App().Test(req)
+			So(err, ShouldBeNil)
+			data, _ := io.ReadAll(resp.Body)
+			return resp.StatusCode, string(data)
+		}
+
+		Convey("The conditions should be enforced at bind time", func() {
+			status, _ := post(`{"methods":[{"type":"card","card_number":"4242"},{"type":"sepa","iban":"FR76"},{"type":"cash"}]}`)
+			So(status, ShouldEqual, http.StatusNoContent)
+
+			status, body := post(`{"methods":[{"type":"cash"},{"type":"card"}]}`)
+			So(status, ShouldEqual, http.StatusBadRequest)
+			So(body, ShouldContainSubstring, `"code":"invalid_value"`)
+			So(body, ShouldContainSubstring, `"pointer":"/methods/1/card_number"`)
+			So(body, ShouldContainSubstring, "/methods/1/card_number is required when type is card")
+
+			_, body = post(`{"methods":[{"type":"transfer"}]}`)
+			So(body, ShouldContainSubstring, "/methods/0/iban is required when type is transfer or sepa")
+
+			_, body = post(`{"methods":[{"type":"cash","memo":"gift"}]}`)
+			So(body, ShouldContainSubstring, "/methods/0/reference is required when memo is set")
+		})
+
+		Convey("The violations should be sent and documented with the status of the bind errors", func() {
+			responses := engine.OpenAPI().Paths.Find("/checkout").Post.Responses
+			So(responses.Status(http.StatusBadRequest), ShouldNotBeNil)
+
+			engine := soda.New()
+			engine.Post("/checkout", func(c *fiber.Ctx) error { return c.SendStatus(http.StatusNoContent) }).
+				SetInput(checkoutInput{}).OK()
+			req := httptest.NewRequest("POST", "/checkout", strings.NewReader(`{"methods":[{"type":"card"}]}`))
+			req.Header.Set("Content-Type", "application/json")
+			resp, _ := engine.App().Test(req)
+			So(resp.StatusCode, ShouldEqual, http.StatusInternalServerError)
+		})
+
+		Convey("The conditions should be documented as schemas", func() {
+			schema := engine.OpenAPI().Components.Schemas["soda_test.paymentMethod"].Value
+			So(schema.Required, ShouldNotContain, "card_number")
+			So(schema.Required, ShouldNotContain, "iban")
+			So(schema.AllOf, ShouldHaveLength, 3)
+
+			valid := map[string]any{"type": "card", "card_number": "4242", "iban": "", "reference": ""}
+			So(schema.VisitJSON(valid), ShouldBeNil)
+			invalid := map[string]any{"type": "card", "iban": "", "reference": ""}
+			So(schema.VisitJSON(invalid, openapi3.MultiErrors()), ShouldNotBeNil)
+			unconditioned := map[string]any{"type": "cash", "iban": "", "reference": ""}
+			So(schema.VisitJSON(unconditioned), ShouldBeNil)
+		})
+
+		Convey("The conditions should be documented with dependentRequired and if/then in 3.1 documents", func() {
+			engine31 := soda.New()
+			engine31.OpenAPI().OpenAPI = "3.1.0"
+			engine31.Post("/checkout", func(c *fiber.Ctx) error { return nil }).SetInput(checkoutInput{}).OK()
+			schema := engine31.OpenAPI().Components.Schemas["soda_test.paymentMethod"].Value
+			So(schema.AllOf, ShouldHaveLength, 3)
+			data, err := json.Marshal(schema.AllOf)
+			So(err, ShouldBeNil)
+			So(string(data), ShouldContainSubstring, `{"if":{"properties":{"type":{"enum":["card"]}},"required":["type"]},"then":{"required":["card_number"]}}`)
+			So(string(data), ShouldContainSubstring, `{"dependentRequired":{"memo":["reference"]}}`)
+			So(string(data), ShouldNotContainSubstring, "anyOf")
+		})
+
+		Convey("A condition on an unknown field should panic", func() {
+			type input struct {
+				Body struct {
+					Number string `json:"number" oai:"requiredIf=kind=card"`
+				} `body:"json"`
+			}
+			So(func() {
+				soda.New().Post("/invalid", func(c *fiber.Ctx) error { return nil }).SetInput(input{}).OK()
+			}, ShouldPanicWith, "the requiredIf condition of the field Number refers to the unknown field kind")
+		})
+	})
+}
@@ -1,9 +1,13 @@
 package soda
 
 import (
+	"reflect"
+	"slices"
+	"sync"
+
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/gofiber/fiber/v2"
-	"gopkg.in/yaml.v3"
+	"github.com/gofiber/fiber/v2/middleware/basicauth"
 )
 
 type Engine struct {
@@ -11,6 +15,75 @@ type Engine struct {
 	app            *fiber.App
 	cachedSpecYAML []byte
 	cachedSpecJSON []byte
+	specMu         sync.Mutex
+	frozen         bool
+
+	maxDecompressedBodySize int
+
+	routes     []RouteInfo
+	routeSites map[string]string
+
+	methodNotAllowed         bool
+	describeMethodNotAllowed bool
+	routeRegexps             sync.Map
+	routing                  *Routing
+	cors                     *CORS
+
+	docsHidden      bool
+	docsMiddlewares []fiber.Handler
+	specReload      string
+
+	tagMiddlewares map[string][]fiber.Handler
+	traceContext   bool
+
+	securityHeaders map[string]string
+
+	specTransformer *SpecTransformer
+	specLocalized   bool
+	specVariants    sync.Map
+
+	minStability Stability
+	flagProvider FlagProvider
+
+	coercion        Coercion
+	jsonDecoding    *JSONDecoding
+	bindErrorStatus int
+
+	pathTranslation bool
+	policyEvaluator PolicyEvaluator
+	auditSink       AuditSink
+
+	modules   map[string]bool
+	providers map[reflect.Type]provider
+
+	compressor fiber.Handler
+
+	events    []event
+	eventsGen *Generator
+
+	breakers   map[string]*breakerMetrics
+	breakersMu sync.Mutex
+
+	maxResponseSize int
+	responseSizes   []*responseSizeGuard
+	responseSizesMu sync.Mutex
+
+	bindingMetricsConfig *BindingMetricsConfig
+	bindings             []*bindingMetrics
+	bindingsMu           sync.Mutex
+
+	errorCodes map[string]ErrorCode
+
+	lintConfig *LintConfig
+	linted     bool
+
+	lenient   bool
+	misuses   []error
+	misusesMu sync.Mutex
+
+	startHooks    []LifecycleHook
+	shutdownHooks []LifecycleHook
+	shutdownOnce  sync.Once
 }
 
 func (e *Engine) OpenAPI() *openapi3.T {
@@ -22,46 +95,97 @@ func (e *Engine) App() *fiber.App {
 }
 
 func (e *Engine) ServeDocUI(pattern string, ui UIRender) *Engine {
-	e.app.Get(pattern, func(c *fiber.Ctx) error {
+	e.serveDoc(pattern, func(c *fiber.Ctx) error {
 		c.Context().SetContentType("text/html; charset=utf-8")
-		return c.SendString(ui.Render(e.gen.doc))
+		doc := e.gen.doc
+		if e.specCustomized() {
+			doc = e.specFor(c).doc
+		}
+		html := ui.Render(doc)
+		if e.specReload != "" {
+			html += e.reloadScript()
+		}
+		return c.SendString(html)
 	})
 	return e
 }
 
 func (e *Engine) ServeSpecJSON(pattern string) *Engine {
-	if e.cachedSpecJSON == nil {
-		e.cachedSpecJSON, _ = e.gen.doc.MarshalJSON()
-	}
-	e.app.Get(pattern, func(c *fiber.Ctx) error {
+	e.serveDoc(pattern, func(c *fiber.Ctx) error {
 		c.Context().SetContentType("application/json; charset=utf-8")
-		return c.Send(e.cachedSpecJSON)
+		if e.specCustomized() {
+			return c.Send(e.specFor(c).marshalJSON())
+		}
+		return c.Send(e.specJSON())
 	})
 	return e
 }
 
 func (e *Engine) ServeSpecYAML(pattern string) *Engine {
-	if e.cachedSpecYAML == nil {
-		spec, _ := yaml.Marshal(e.gen.doc)
-		e.cachedSpecYAML = spec
-	}
-	e.app.Get(pattern, func(c *fiber.Ctx) error {
+	e.serveDoc(pattern, func(c *fiber.Ctx) error {
 		c.Context().SetContentType("text/yaml; charset=utf-8")
-		return c.Send(e.cachedSpecYAML)
+		if e.specCustomized() {
+			return c.Send(e.specFor(c).marshalYAML())
+		}
+		return c.Send(e.specYAML())
 	})
 	return e
 }
 
+// SetDocsExposed sets whether the documentation endpoints, namely the spec, the UI and the routes, are served.
+// Disabling them, e.g. in production, makes the subsequent Serve* calls no-ops.
+func (e *Engine) SetDocsExposed(exposed bool) *Engine {
+	e.docsHidden = !exposed
+	return e
+}
+
+// UseDocsMiddleware adds middlewares, such as DocsBasicAuth, in front of the documentation endpoints
+// served afterwards.
+func (e *Engine) UseDocsMiddleware(handlers ...fiber.Handler) *Engine {
+	e.docsMiddlewares = append(e.docsMiddlewares, handlers...)
+	return e
+}
+
+// DocsBasicAuth returns a middleware protecting the documentation endpoints with basic auth,
+// the users mapping usernames to passwords.
+func DocsBasicAuth(users map[string]string) fiber.Handler {
+	return basicauth.New(basicauth.Config{Users: users, Realm: "Documentation"})
+}
+
+// serveDoc serves a documentation endpoint, unless they are hidden.
+func (e *Engine) serveDoc(pattern string, handler fiber.Handler) {
+	if e.docsHidden {
+		return
+	}
+	handlers := append(slices.Clone(e.docsMiddlewares), handler)
+	e.app.Get(pattern, handlers...)
+}
+
+// UseForTag adds middlewares to the operations tagged with the tag, e.g. an auth middleware for "admin",
+// so that cross-cutting policies key off the API semantics rather than path prefixes.
+// They run before the input is bound, in the order of the tags of the operation,
+// and only apply to the operations finalized afterwards.
+func (e *Engine) UseForTag(tag string, handlers ...fiber.Handler) *Engine {
+	if e.tagMiddlewares == nil {
+		e.tagMiddlewares = make(map[string][]fiber.Handler)
+	}
+	e.tagMiddlewares[tag] = append(e.tagMiddlewares[tag], handlers...)
+	return e
+}
+
+// New creates an engine with a new fiber app, whose error handler is ErrorHandler.
 func New() *Engine {
-	return NewWith(fiber.New())
+	return NewWith(fiber.New(fiber.Config{ErrorHandler: ErrorHandler}))
 }
 
 func NewWith(app *fiber.App) *Engine {
-	return &Engine{
+	engine := &Engine{
 		app: app,
 		Router: &Router{
 			gen: NewGenerator(),
 			Raw: app,
 		},
 	}
+	engine.Router.engine = engine
+	return engine
 }
@@ -67,6 +67,56 @@ func TestEngine(t *testing.T) {
 			})
 		})
 
+		Convey("When the documentation is hidden", func() {
+			engine.SetDocsExposed(false).ServeSpecJSON("/spec.json").ServeDocUI("/doc", &mockUIRender{})
+
+			Convey("The endpoints should not be served", func() {
+				resp, _ := engine.App().Test(httptest.NewRequest("GET", "/spec.json", nil))
+				So(resp.StatusCode, ShouldEqual, 404)
+				resp, _ = engine.App().Test(httptest.NewRequest("GET", "/doc", nil))
+				So(resp.StatusCode, ShouldEqual, 404)
+			})
+		})
+
+		Convey("When the documentation is protected with basic auth", func() {
+			engine.UseDocsMiddleware(soda.DocsBasicAuth(map[string]string{"admin": "secret"})).ServeSpecJSON("/spec.json")
+
+			Convey("It should require the credentials", func() {
+				resp, _ := engine.App().Test(httptest.NewRequest("GET", "/spec.json", nil))
+				So(resp.StatusCode, ShouldEqual, 401)
+
+				req := httptest.NewRequest("GET", "/spec.json", nil)
+				req.SetBasicAuth("admin", "secret")
+				resp, _ = engine.App().Test(req)
+				So(resp.StatusCode, ShouldEqual, 200)
+			})
+		})
+
+		Convey("When a middleware is used for a tag", func() {
+			engine.UseForTag("admin", func(c *fiber.Ctx) error {
+				if c.Get("X-Admin") == "" {
+					return c.SendStatus(403)
+				}
+				return c.Next()
+			})
+			handler := func(c *fiber.Ctx) error { return c.SendStatus(200) }
+			engine.Get("/admin", handler).AddTags("admin").OK()
+			engine.Get("/public", handler).AddTags("public").OK()
+
+			Convey("It should apply to the tagged operations only", func() {
+				resp, _ := engine.App().Test(httptest.NewRequest("GET", "/admin", nil))
+				So(resp.StatusCode, ShouldEqual, 403)
+
+				req := httptest.NewRequest("GET", "/admin", nil)
+				req.Header.Set("X-Admin", "1")
+				resp, _ = engine.App().Test(req)
+				So(resp.StatusCode, ShouldEqual, 200)
+
+				resp, _ = engine.App().Test(httptest.NewRequest("GET", "/public", nil))
+				So(resp.StatusCode, ShouldEqual, 200)
+			})
+		})
+
 		Convey("When creating a new engine with a custom fiber App", func() {
 			app := fiber.New()
 			newEngine := soda.NewWith(app)
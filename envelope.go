@@ -0,0 +1,89 @@
+package soda
+
+import (
+	"encoding/json"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	// keyEnvelopeMeta is the key of the meta of the response envelope in the context locals.
+	keyEnvelopeMeta ck = "soda::envelope-meta"
+	// keyEnveloped is the key of the flag of the responses already wrapped in an envelope in the context locals.
+	keyEnveloped ck = "soda::enveloped"
+)
+
+// Envelope is the standard envelope wrapping the responses when enabled by UseResponseEnvelope.
+// Success responses are held by Data and error responses, with a 4xx or 5xx status, by Error.
+type Envelope struct {
+	Data  any            `json:"data"`
+	Error any            `json:"error"`
+	Meta  map[string]any `json:"meta,omitempty"`
+}
+
+// UseResponseEnvelope wraps the responses in an Envelope, both in the responses documented afterwards and in the
+// values serialized by Respond or sent as JSON directly through fiber, e.g. by c.JSON, by the operations
+// registered afterwards. Protobuf and XML responses are never wrapped.
+func (e *Engine) UseResponseEnvelope() *Engine {
+	e.gen.envelope = true
+	return e
+}
+
+// SetEnvelopeMeta sets a meta value of the envelope of the response, such as pagination details.
+func SetEnvelopeMeta(c *fiber.Ctx, key string, value any) {
+	meta, _ := c.Locals(keyEnvelopeMeta).(map[string]any)
+	if meta == nil {
+		meta = make(map[string]any)
+		c.Locals(keyEnvelopeMeta, meta)
+	}
+	meta[key] = value
+}
+
+// envelopes reports whether responses of the media type are wrapped in an envelope.
+func envelopes(mediaType string) bool {
+	return responseNameTag(mediaType) == "json" && !isProtobuf(mediaType)
+}
+
+// wrapEnvelope wraps the value sent with the status in an envelope.
+func wrapEnvelope(c *fiber.Ctx, status int, v any) Envelope {
+	c.Locals(keyEnveloped, true)
+	meta, _ := c.Locals(keyEnvelopeMeta).(map[string]any)
+	if status >= 400 {
+		return Envelope{Error: v, Meta: meta}
+	}
+	return Envelope{Data: v, Meta: meta}
+}
+
+// wrapResponse wraps the JSON responses sent by the handlers which are not wrapped yet.
+func (op *OperationBuilder) wrapResponse(c *fiber.Ctx) error {
+	if err := c.Next(); err != nil {
+		return err
+	}
+	body := c.Response().Body()
+	if wrapped, _ := c.Locals(keyEnveloped).(bool); wrapped || len(body) == 0 ||
+		!isJSONMediaType(requestMediaType(string(c.Response().Header.ContentType()))) {
+		return nil
+	}
+	data, err := op.route.gen.naming.Marshal(wrapEnvelope(c, c.Response().StatusCode(), json.RawMessage(body)))
+	if err != nil {
+		return err
+	}
+	c.Response().SetBodyRaw(data)
+	return nil
+}
+
+// envelopeSchema returns the schema of the envelope of a response with the status described by the schema.
+func envelopeSchema(status int, schema *openapi3.SchemaRef) *openapi3.SchemaRef {
+	null := openapi3.NewSchema().WithNullable().NewRef()
+	data, err := schema, null
+	if status >= 400 {
+		data, err = null, schema
+	}
+	return openapi3.NewObjectSchema().
+		WithPropertyRef("data", data).
+		WithPropertyRef("error", err).
+		WithProperty("meta", openapi3.NewObjectSchema().WithAnyAdditionalProperties()).
+		WithRequired([]string{"data", "error"}).
+		NewRef()
+}
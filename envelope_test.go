@@ -0,0 +1,83 @@
+package soda_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestResponseEnvelope(t *testing.T) {
+	Convey("Given an engine wrapping the responses in an envelope", t, func() {
+		type item struct {
+			Name string `json:"name"`
+		}
+		type failure struct {
+			Reason string `json:"reason"`
+		}
+		engine := soda.New().UseResponseEnvelope()
+		engine.Get("/items/:name", func(c *fiber.Ctx) error {
+			if c.Params("name") == "missing" {
+				return soda.Respond(c, http.StatusNotFound, failure{Reason: "not found"})
+			}
+			soda.SetEnvelopeMeta(c, "version", 2)
+			return soda.Respond(c, http.StatusOK, item{Name: c.Params("name")})
+		}).
+			AddResponse(http.StatusOK, item{}).
+			AddResponse(http.StatusNotFound, failure{}).
+			OK()
+		engine.Get("/plain/:name", func(c *fiber.Ctx) error {
+			if c.Params("name") == "missing" {
+				return c.Status(http.StatusNotFound).JSON(failure{Reason: "not found"})
+			}
+			soda.SetEnvelopeMeta(c, "version", 2)
+			return c.JSON(item{Name: c.Params("name")})
+		}).AddJSONResponse(http.StatusOK, item{}).AddJSONResponse(http.StatusNotFound, failure{}).OK()
+		engine.Get("/unavailable", func(c *fiber.Ctx) error {
+			return fiber.NewError(http.StatusServiceUnavailable, "try again later")
+		}).OK()
+
+		Convey("The documented schemas should be wrapped", func() {
+			responses := engine.OpenAPI().Paths.Find("/items/:name").Get.Responses
+			ok := responses.Status(http.StatusOK).Value.Content.Get("application/json").Schema.Value
+			So(ok.Properties["data"].Ref, ShouldEqual, "#/components/schemas/soda_test.item")
+			So(ok.Properties["error"].Value.Nullable, ShouldBeTrue)
+			So(ok.Properties, ShouldContainKey, "meta")
+			notFound := responses.Status(http.StatusNotFound).Value.Content.Get("application/json").Schema.Value
+			So(notFound.Properties["error"].Ref, ShouldEqual, "#/components/schemas/soda_test.failure")
+		})
+
+		Convey("The serialized responses should be wrapped", func() {
+			resp, _ := engine.App().Test(httptest.NewRequest("GET", "/items/soda", nil))
+			body, _ := io.ReadAll(resp.Body)
+			So(string(body), ShouldEqual, `{"data":{"name":"soda"},"error":null,"meta":{"version":2}}`)
+
+			resp, _ = engine.App().Test(httptest.NewRequest("GET", "/items/missing", nil))
+			So(resp.StatusCode, ShouldEqual, http.StatusNotFound)
+			body, _ = io.ReadAll(resp.Body)
+			So(string(body), ShouldEqual, `{"data":null,"error":{"reason":"not found"}}`)
+		})
+
+		Convey("The JSON responses sent directly should be wrapped", func() {
+			resp, _ := engine.App().Test(httptest.NewRequest("GET", "/plain/soda", nil))
+			body, _ := io.ReadAll(resp.Body)
+			So(string(body), ShouldEqual, `{"data":{"name":"soda"},"error":null,"meta":{"version":2}}`)
+
+			resp, _ = engine.App().Test(httptest.NewRequest("GET", "/plain/missing", nil))
+			So(resp.StatusCode, ShouldEqual, http.StatusNotFound)
+			body, _ = io.ReadAll(resp.Body)
+			So(string(body), ShouldEqual, `{"data":null,"error":{"reason":"not found"}}`)
+		})
+
+		Convey("The fiber errors should be wrapped as HTTP errors", func() {
+			resp, _ := engine.App().Test(httptest.NewRequest("GET", "/unavailable", nil))
+			So(resp.StatusCode, ShouldEqual, http.StatusServiceUnavailable)
+			body, _ := io.ReadAll(resp.Body)
+			So(string(body), ShouldEqual, `{"data":null,"error":{"code":"service_unavailable","message":"try again later"}}`)
+		})
+	})
+}
@@ -0,0 +1,122 @@
+package soda
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+)
+
+// errorCodesExtension documents the error code catalog of the API at the root of the spec, and the codes of the
+// error responses of the operations.
+const errorCodesExtension = "x-error-codes"
+
+// ErrorCode is an entry of the error code catalog of the API.
+type ErrorCode struct {
+	// Code is the machine-readable code of the error, e.g. "order_not_found".
+	Code string `json:"code"`
+	// Status is the HTTP status of the responses of the error.
+	Status int `json:"status"`
+	// Message is the fmt format of the message of the error, formatted with the arguments of the CodedError.
+	// A message registered under the code with RegisterCatalog takes precedence in its language.
+	Message string `json:"message"`
+}
+
+// CodedError is an error of the error code catalog returned by the handlers, hooks and middlewares of an
+// operation, sent to clients as the HTTPError of its entry.
+type CodedError struct {
+	Code string
+	Args []any
+}
+
+// NewCodedError creates a CodedError of the code, whose message is formatted with the arguments.
+func NewCodedError(code string, args ...any) *CodedError {
+	return &CodedError{Code: code, Args: args}
+}
+
+func (e *CodedError) Error() string {
+	return e.Code
+}
+
+// RegisterErrorCodes registers entries of the error code catalog, documented by the x-error-codes extension of
+// the spec. The CodedError of an unregistered code is sent as a 500 HTTPError, and the HTTPError of a registered
+// code is sent with the status of its entry, so that the responses match the catalog.
+func (e *Engine) RegisterErrorCodes(codes ...ErrorCode) *Engine {
+	e.invalidateSpec()
+	if e.errorCodes == nil {
+		e.errorCodes = make(map[string]ErrorCode)
+	}
+	for _, code := range codes {
+		e.errorCodes[code.Code] = code
+	}
+	catalog := make([]ErrorCode, 0, len(e.errorCodes))
+	for _, code := range sortedKeys(e.errorCodes) {
+		catalog = append(catalog, e.errorCodes[code])
+	}
+	doc := e.gen.doc
+	if doc.Extensions == nil {
+		doc.Extensions = make(map[string]any)
+	}
+	doc.Extensions[errorCodesExtension] = catalog
+	return e
+}
+
+// AddErrorCodes documents the responses of the statuses of the registered error codes with the HTTPError model,
+// their codes being listed by the x-error-codes extension of the responses. It panics if a code isn't registered.
+func (op *OperationBuilder) AddErrorCodes(codes ...string) (self *OperationBuilder) {
+	defer op.recoverMisuse(&self)
+	byStatus := make(map[int][]string)
+	for _, code := range codes {
+		entry, ok := op.route.engine.errorCodes[code]
+		if !ok {
+			panic("the error code " + code + " is not registered")
+		}
+		byStatus[entry.Status] = append(byStatus[entry.Status], code)
+	}
+	for status, codes := range byStatus {
+		op.AddErrorResponses(status)
+		response := op.operation.Responses.Status(status).Value
+		existing, _ := response.Extensions[errorCodesExtension].([]string)
+		for _, code := range codes {
+			if !slices.Contains(existing, code) {
+				existing = append(existing, code)
+			}
+		}
+		slices.Sort(existing)
+		// The response may be shared with other operations.
+		documented := *response
+		documented.Extensions = map[string]any{errorCodesExtension: existing}
+		op.operation.Responses.Set(strconv.Itoa(status), &openapi3.ResponseRef{Value: &documented})
+	}
+	return op
+}
+
+// resolveErrorCode turns the CodedError into the HTTPError of its entry, and sets the status of the entry of the
+// code of the HTTPError, if any.
+func (e *Engine) resolveErrorCode(c *fiber.Ctx, err error) error {
+	var coded *CodedError
+	if errors.As(err, &coded) {
+		entry, ok := e.errorCodes[coded.Code]
+		if !ok {
+			return NewError(http.StatusInternalServerError, coded.Code, http.StatusText(http.StatusInternalServerError))
+		}
+		message := Translate(c, entry.Code, coded.Args...)
+		if message == entry.Code {
+			message = fmt.Sprintf(entry.Message, coded.Args...)
+		}
+		return NewError(entry.Status, entry.Code, message)
+	}
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		if entry, ok := e.errorCodes[httpErr.Code]; ok && entry.Status != httpErr.Status {
+			resolved := *httpErr
+			resolved.Status = entry.Status
+			return &resolved
+		}
+	}
+	return err
+}
@@ -0,0 +1,82 @@
+package soda_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestErrorCodes(t *testing.T) {
+	Convey("Given an engine with an error code catalog", t, func() {
+		engine := soda.New().RegisterErrorCodes(
+			soda.ErrorCode{Code: "order_not_found", Status: http.StatusNotFound, Message: "the order %s was not found"},
+			soda.ErrorCode{Code: "order_closed", Status: http.StatusConflict, Message: "the order is closed"},
+			soda.ErrorCode{Code: "order_locked", Status: http.StatusConflict, Message: "the order is locked"},
+		)
+		engine.Get("/orders/:id", func(c *fiber.Ctx) error {
+			switch c.Params("id") {
+			case "closed":
+				return soda.NewError(http.StatusBadRequest, "order_closed", "closed")
+			case "unknown":
+				return soda.NewCodedError("order_vanished")
+			}
+			return soda.NewCodedError("order_not_found", c.Params("id"))
+		}).AddErrorCodes("order_not_found", "order_closed", "order_locked").OK()
+		get := func(id string) (int, map[string]any) {
+			resp, err := engine.App().Test(httptest.NewRequest("GET", "/orders/"+id, nil))
+			So(err, ShouldBeNil)
+			data, _ := io.ReadAll(resp.Body)
+			var body map[string]any
+			So(json.Unmarshal(data, &body), ShouldBeNil)
+			return resp.StatusCode, body
+		}
+
+		Convey("The catalog should be documented", func() {
+			catalog := engine.OpenAPI().Extensions["x-error-codes"].([]soda.ErrorCode)
+			So(catalog, ShouldHaveLength, 3)
+			So(catalog[0].Code, ShouldEqual, "order_closed")
+
+			responses := engine.OpenAPI().Paths.Value("/orders/:id").Get.Responses
+			So(responses.Status(http.StatusNotFound).Value.Extensions["x-error-codes"], ShouldResemble, []string{"order_not_found"})
+			So(responses.Status(http.StatusConflict).Value.Extensions["x-error-codes"], ShouldResemble,
+				[]string{"order_closed", "order_locked"})
+			So(func() { engine.Get("/carts", nil).AddErrorCodes("cart_empty") }, ShouldPanicWith,
+				"the error code cart_empty is not registered")
+		})
+
+		Convey("The errors returned by code should be sent as documented", func() {
+			status, body := get("42")
+			So(status, ShouldEqual, http.StatusNotFound)
+			So(body, ShouldResemble, map[string]any{"code": "order_not_found", "message": "the order 42 was not found"})
+		})
+
+		Convey("The HTTP errors of a registered code should be sent with its status", func() {
+			status, body := get("closed")
+			So(status, ShouldEqual, http.StatusConflict)
+			So(body["code"], ShouldEqual, "order_closed")
+		})
+
+		Convey("The errors of an unregistered code should be sent as internal errors", func() {
+			status, body := get("unknown")
+			So(status, ShouldEqual, http.StatusInternalServerError)
+			So(body["code"], ShouldEqual, "order_vanished")
+		})
+
+		Convey("The messages should be translated when a catalog has the code", func() {
+			soda.RegisterCatalog("fr", soda.Catalog{"order_not_found": "la commande %s est introuvable"})
+			req := httptest.NewRequest("GET", "/orders/42", nil)
+			req.Header.Set("Accept-Language", "fr")
+			resp, err := engine.App().Test(req)
+			So(err, ShouldBeNil)
+			var body map[string]any
+			So(json.NewDecoder(resp.Body).Decode(&body), ShouldBeNil)
+			So(body["message"], ShouldEqual, "la commande 42 est introuvable")
+		})
+	})
+}
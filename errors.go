@@ -0,0 +1,88 @@
+package soda
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// HTTPError is an error with an HTTP status, sent to clients as the documented error model
+// when returned by the handlers, hooks and middlewares of an operation.
+type HTTPError struct {
+	Status  int    `json:"-"       xml:"-"`
+	Code    string `json:"code"    xml:"code"    oai:"description=machine-readable error code"`
+	Message string `json:"message" xml:"message" oai:"description=human-readable error message"`
+	// Pointer and Expected locate the invalid value of a JSON request body and the type it should have.
+	Pointer  string `json:"pointer,omitempty"  xml:"pointer,omitempty"  oai:"description=JSON pointer to the invalid value of the request body"`
+	Expected string `json:"expected,omitempty" xml:"expected,omitempty" oai:"description=expected type of the invalid value"`
+	// err is the error the HTTPError was made from, such as the one binding the input.
+	err error
+}
+
+// NewError creates an HTTPError.
+func NewError(status int, code, message string) *HTTPError {
+	return &HTTPError{Status: status, Code: code, Message: message}
+}
+
+func (e *HTTPError) Error() string {
+	return e.Code + ": " + e.Message
+}
+
+// Unwrap returns the error the HTTPError was made from, if any, e.g. for the error handlers of the apps.
+func (e *HTTPError) Unwrap() error {
+	return e.err
+}
+
+// AddErrorResponses documents the statuses as responses of the shared HTTPError model.
+func (op *OperationBuilder) AddErrorResponses(statuses ...int) *OperationBuilder {
+	for _, status := range statuses {
+		op.AddJSONResponse(status, HTTPError{}, http.StatusText(status))
+	}
+	return op
+}
+
+// ErrorHandler is the fiber error handler sending the HTTPErrors as the documented error model, wrapped in an
+// Envelope if enabled for the operation, and leaving the other errors to fiber.DefaultErrorHandler. The
+// *fiber.Errors, such as the 406 of the content negotiation, are enveloped too, as HTTPErrors whose code is derived
+// from their status. It is the error handler of the apps created by New, which the apps given to NewWith can set,
+// or call from their own.
+func ErrorHandler(ctx *fiber.Ctx, err error) error {
+	op, _ := ctx.Locals(keyOperationBuilder).(*OperationBuilder)
+	enveloped := op != nil && op.route.gen.envelope
+	var httpErr *HTTPError
+	var fiberErr *fiber.Error
+	switch {
+	case errors.As(err, &httpErr):
+	case enveloped && errors.As(err, &fiberErr):
+		httpErr = NewError(fiberErr.Code, statusErrorCode(fiberErr.Code), fiberErr.Message)
+	default:
+		return fiber.DefaultErrorHandler(ctx, err)
+	}
+	var body any = httpErr
+	if enveloped {
+		body = wrapEnvelope(ctx, httpErr.Status, httpErr)
+	}
+	return ctx.Status(httpErr.Status).JSON(body)
+}
+
+// statusErrorCode returns the error code of the status, its text in snake case, e.g. not_acceptable for 406.
+func statusErrorCode(status int) string {
+	return strings.ToLower(strings.ReplaceAll(http.StatusText(status), " ", "_"))
+}
+
+// handle runs the handlers of the operation and sends the error they return, if any, or the HTTPError of the entry
+// of the CodedError, with the error handler of the app, so that the error responses go through the middlewares of
+// the operation, such as the compression.
+func (op *OperationBuilder) handle(ctx *fiber.Ctx) error {
+	ctx.Locals(keyOperationBuilder, op)
+	if err := ctx.Next(); err != nil {
+		return ctx.App().ErrorHandler(ctx, op.route.engine.resolveErrorCode(ctx, err))
+	}
+	if isNoContent(ctx.Response().StatusCode()) {
+		ctx.Response().ResetBody()
+		ctx.Response().Header.Del(fiber.HeaderContentType)
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package soda_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestHTTPError(t *testing.T) {
+	Convey("Given an operation returning HTTP errors", t, func() {
+		engine := soda.New()
+		engine.Get("/items/:id", func(c *fiber.Ctx) error {
+			switch c.Params("id") {
+			case "missing":
+				return soda.NewError(http.StatusNotFound, "item_not_found", "no such item")
+			case "wrapped":
+				return fmt.Errorf("lookup: %w", soda.NewError(http.StatusConflict, "item_locked", "item is locked"))
+			case "other":
+				return errors.New("boom")
+			}
+			return c.SendStatus(http.StatusOK)
+		}).AddErrorResponses(http.StatusNotFound, http.StatusConflict).OK()
+
+		request := func(id string) (int, string) {
+			resp, _ := engine.App().Test(httptest.NewRequest("GET", "/items/"+id, nil))
+			body, _ := io.ReadAll(resp.Body)
+			return resp.StatusCode, string(body)
+		}
+
+		Convey("The error statuses should be documented with the shared error model", func() {
+			responses := engine.OpenAPI().Paths.Find("/items/:id").Get.Responses
+			for _, status := range []int{http.StatusNotFound, http.StatusConflict} {
+				schema := responses.Status(status).Value.Content.Get("application/json").Schema
+				So(schema.Ref, ShouldEqual, "#/components/schemas/soda.HTTPError")
+			}
+			So(engine.OpenAPI().Components.Schemas["soda.HTTPError"].Value.Properties, ShouldNotContainKey, "Status")
+		})
+
+		Convey("The errors should be sent with their status", func() {
+			status, body := request("missing")
+			So(status, ShouldEqual, http.StatusNotFound)
+			So(body, ShouldEqual, `{"code":"item_not_found","message":"no such item"}`)
+
+			status, body = request("wrapped")
+			So(status, ShouldEqual, http.StatusConflict)
+			So(body, ShouldEqual, `{"code":"item_locked","message":"item is locked"}`)
+		})
+
+		Convey("Other errors should be left to fiber", func() {
+			status, body := request("other")
+			So(status, ShouldEqual, http.StatusInternalServerError)
+			So(body, ShouldEqual, "boom")
+		})
+
+		Convey("The errors should be sent by the error handler of the app", func() {
+			var handled error
+			engine := soda.NewWith(fiber.New(fiber.Config{ErrorHandler: func(c *fiber.Ctx, err error) error {
+				handled = err
+				return c.Status(http.StatusTeapot).SendString("handled")
+			}}))
+			engine.Get("/items", func(c *fiber.Ctx) error {
+				return soda.NewError(http.StatusNotFound, "item_not_found", "no such item")
+			}).OK()
+
+			resp, _ := engine.App().Test(httptest.NewRequest("GET", "/items", nil))
+			body, _ := io.ReadAll(resp.Body)
+			So(resp.StatusCode, ShouldEqual, http.StatusTeapot)
+			So(string(body), ShouldEqual, "handled")
+			var httpErr *soda.HTTPError
+			So(errors.As(handled, &httpErr), ShouldBeTrue)
+			So(httpErr.Code, ShouldEqual, "item_not_found")
+		})
+	})
+}
@@ -0,0 +1,235 @@
+package soda
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// evolutionMaxDepth bounds the depth of the nested properties compared by BreakingChanges.
+const evolutionMaxDepth = 8
+
+// BreakingChange is a change of an operation from a previously published spec which breaks its clients.
+type BreakingChange struct {
+	Method  string
+	Path    string
+	Message string
+}
+
+func (c BreakingChange) String() string {
+	return fmt.Sprintf("%s %s: %s", c.Method, c.Path, c.Message)
+}
+
+// BreakingChanges compares the documented operations with those of the previously published spec and returns the
+// changes breaking their clients, sorted by path and method: the removed operations, success responses and media
+// types, the parameters and the request body properties becoming required or no longer accepting a value, and the
+// response properties removed or no longer guaranteed. It is meant to be called once all the operations are
+// registered.
+func (e *Engine) BreakingChanges(previous *openapi3.T) []BreakingChange {
+	var changes []BreakingChange
+	paths := previous.Paths.Map()
+	for _, path := range sortedKeys(paths) {
+		operations := paths[path].Operations()
+		for _, method := range sortedKeys(operations) {
+			d := specDiff{previous: previous, current: e.gen.doc}
+			if item := e.gen.doc.Paths.Value(path); item == nil || item.GetOperation(method) == nil {
+				d.add("the operation was removed")
+			} else {
+				d.diffOperation(operations[method], item.GetOperation(method))
+			}
+			for _, message := range d.messages {
+				changes = append(changes, BreakingChange{Method: method, Path: path, Message: message})
+			}
+		}
+	}
+	return changes
+}
+
+// WarnBreakingChanges loads the previously published spec from the JSON or YAML file at the location, or from the
+// http(s) URL, when the engine starts, and logs a warning for each of its BreakingChanges with the logger,
+// slog.Default() if none, so that the clients about to break are noticed before they do. A spec which can't be
+// loaded is logged without aborting the start.
+func (e *Engine) WarnBreakingChanges(location string, logger ...*slog.Logger) *Engine {
+	return e.OnStart(func(ctx context.Context) error {
+		log := slog.Default()
+		if len(logger) != 0 && logger[0] != nil {
+			log = logger[0]
+		}
+		previous, err := loadSpec(ctx, location)
+		if err != nil {
+			log.WarnContext(ctx, "previous spec unavailable", slog.String("location", location), slog.Any("error", err))
+			return nil
+		}
+		for _, change := range e.BreakingChanges(previous) {
+			log.WarnContext(ctx, "breaking change",
+				slog.String("method", change.Method),
+				slog.String("path", change.Path),
+				slog.String("change", change.Message),
+				slog.String("previous", location),
+			)
+		}
+		return nil
+	})
+}
+
+// loadSpec loads the spec from the file or the http(s) URL.
+func loadSpec(ctx context.Context, location string) (*openapi3.T, error) {
+	var data []byte
+	if strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://") {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, location, nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("unexpected status %s", resp.Status)
+		}
+		if data, err = io.ReadAll(resp.Body); err != nil {
+			return nil, err
+		}
+	} else {
+		var err error
+		if data, err = os.ReadFile(location); err != nil {
+			return nil, err
+		}
+	}
+	return openapi3.NewLoader().LoadFromData(data)
+}
+
+// specDiff collects the breaking changes of an operation.
+type specDiff struct {
+	previous, current *openapi3.T
+	messages          []string
+}
+
+func (d *specDiff) add(format string, args ...any) {
+	d.messages = append(d.messages, fmt.Sprintf(format, args...))
+}
+
+// diffOperation compares the parameters, the request body and the success responses of the operation.
+func (d *specDiff) diffOperation(previous, current *openapi3.Operation) {
+	for _, param := range current.Parameters {
+		if param.Value == nil {
+			continue
+		}
+		name := fmt.Sprintf("the %s parameter %s", param.Value.In, param.Value.Name)
+		old := previous.Parameters.GetByInAndName(param.Value.In, param.Value.Name)
+		if old == nil {
+			if param.Value.Required {
+				d.add("%s was added as required", name)
+			}
+			continue
+		}
+		if param.Value.Required && !old.Required {
+			d.add("%s became required", name)
+		}
+		if old.Schema != nil && param.Value.Schema != nil {
+			d.diffSchema(name, derefSchema(d.previous, old.Schema), derefSchema(d.current, param.Value.Schema), true, 0)
+		}
+	}
+
+	if body := current.RequestBody; body != nil && body.Value != nil {
+		if previous.RequestBody == nil || previous.RequestBody.Value == nil {
+			if body.Value.Required {
+				d.add("the request body was added as required")
+			}
+		} else {
+			if body.Value.Required && !previous.RequestBody.Value.Required {
+				d.add("the request body became required")
+			}
+			d.diffContent("the request body", previous.RequestBody.Value.Content, body.Value.Content, true)
+		}
+	}
+
+	for status, response := range previous.Responses.Map() {
+		if !strings.HasPrefix(status, "2") || response.Value == nil {
+			continue
+		}
+		name := "the " + status + " response"
+		updated := current.Responses.Value(status)
+		if updated == nil || updated.Value == nil {
+			d.add("%s was removed", name)
+			continue
+		}
+		d.diffContent(name, response.Value.Content, updated.Value.Content, false)
+	}
+	slices.Sort(d.messages)
+}
+
+// diffContent compares the schemas of the media types of the content present before and after the change.
+func (d *specDiff) diffContent(name string, previous, current openapi3.Content, request bool) {
+	for _, mediaType := range sortedKeys(previous) {
+		old, media := previous[mediaType], current[mediaType]
+		if media == nil {
+			if request {
+				d.add("%s is no longer accepted as %s", name, mediaType)
+			} else {
+				d.add("%s is no longer sent as %s", name, mediaType)
+			}
+			continue
+		}
+		if old.Schema != nil && media.Schema != nil {
+			d.diffSchema(name, derefSchema(d.previous, old.Schema), derefSchema(d.current, media.Schema), request, 0)
+		}
+	}
+}
+
+// diffSchema compares the schemas of a request, whose values must still be accepted, or of a response, whose
+// values must still be understood by the clients.
+func (d *specDiff) diffSchema(name string, previous, current *openapi3.Schema, request bool, depth int) {
+	if depth >= evolutionMaxDepth {
+		return
+	}
+	if previous.Type != nil && current.Type != nil && len(previous.Type.Slice()) != 0 && len(current.Type.Slice()) != 0 &&
+		!slices.Equal(previous.Type.Slice(), current.Type.Slice()) {
+		d.add("the type of %s changed from %s to %s", name, strings.Join(previous.Type.Slice(), ","),
+			strings.Join(current.Type.Slice(), ","))
+		return
+	}
+	if request {
+		if len(current.Enum) != 0 {
+			for _, value := range previous.Enum {
+				if !slices.ContainsFunc(current.Enum, func(v any) bool { return fmt.Sprint(v) == fmt.Sprint(value) }) {
+					d.add("%s no longer accepts %v", name, value)
+				}
+			}
+		}
+		for _, prop := range current.Required {
+			if !slices.Contains(previous.Required, prop) {
+				d.add("the property %s of %s became required", prop, name)
+			}
+		}
+	} else {
+		for _, prop := range previous.Required {
+			if previous.Properties[prop] != nil && current.Properties[prop] != nil && !slices.Contains(current.Required, prop) {
+				d.add("the property %s of %s is no longer required", prop, name)
+			}
+		}
+		for _, prop := range sortedKeys(previous.Properties) {
+			if current.Properties[prop] == nil {
+				d.add("the property %s of %s was removed", prop, name)
+			}
+		}
+	}
+	for _, prop := range sortedKeys(previous.Properties) {
+		if old, cur := previous.Properties[prop], current.Properties[prop]; old != nil && cur != nil {
+			d.diffSchema(fmt.Sprintf("the property %s of %s", prop, name), derefSchema(d.previous, old),
+				derefSchema(d.current, cur), request, depth+1)
+		}
+	}
+	if previous.Items != nil && current.Items != nil {
+		d.diffSchema("the items of "+name, derefSchema(d.previous, previous.Items), derefSchema(d.current, current.Items),
+			request, depth+1)
+	}
+}
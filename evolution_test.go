@@ -0,0 +1,96 @@
+package soda_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type evolutionInputV1 struct {
+	ID     string `path:"id"`
+	Expand string `query:"expand" oai:"required=false"`
+}
+
+type evolutionOrderV1 struct {
+	ID   string `json:"id"`
+	Note string `json:"note"`
+}
+
+type evolutionCreateV1 struct {
+	Body evolutionOrderV2 `body:"json"`
+}
+
+type evolutionCreateV2 struct {
+	Body evolutionOrderV2 `body:"xml"`
+}
+
+type evolutionInputV2 struct {
+	ID     string `path:"id"`
+	Expand string `query:"expand"`
+}
+
+type evolutionOrderV2 struct {
+	ID string `json:"id"`
+}
+
+func TestBreakingChanges(t *testing.T) {
+	Convey("Given the spec previously published", t, func() {
+		handler := func(c *fiber.Ctx) error { return nil }
+		published := soda.New()
+		published.Get("/orders/:id", handler).SetInput(evolutionInputV1{}).AddJSONResponse(200, evolutionOrderV1{}).OK()
+		published.Post("/orders", handler).SetInput(evolutionCreateV1{}).AddJSONResponse(201, nil).OK()
+		published.Delete("/orders/:id", handler).SetInput(evolutionInputV1{}).AddJSONResponse(204, nil).OK()
+		data, err := published.OpenAPI().MarshalJSON()
+		So(err, ShouldBeNil)
+		location := filepath.Join(t.TempDir(), "openapi.json")
+		So(os.WriteFile(location, data, 0o600), ShouldBeNil)
+
+		engine := soda.New()
+		engine.Get("/orders/:id", handler).SetInput(evolutionInputV2{}).AddJSONResponse(200, evolutionOrderV2{}).OK()
+		engine.Post("/orders", handler).SetInput(evolutionCreateV2{}).AddJSONResponse(201, nil).OK()
+
+		Convey("The breaking changes should be reported by operation", func() {
+			previous := soda.New().OpenAPI()
+			So(previous.UnmarshalJSON(data), ShouldBeNil)
+			var changes []string
+			for _, change := range engine.BreakingChanges(previous) {
+				changes = append(changes, change.String())
+			}
+			So(changes, ShouldResemble, []string{
+				"POST /orders: the request body is no longer accepted as application/json",
+				"DELETE /orders/:id: the operation was removed",
+				"GET /orders/:id: the property note of the 200 response was removed",
+				"GET /orders/:id: the query parameter expand became required",
+			})
+		})
+
+		Convey("An unchanged spec should have no breaking change", func() {
+			So(published.BreakingChanges(published.OpenAPI()), ShouldBeEmpty)
+		})
+
+		Convey("The breaking changes should be logged at startup", func() {
+			var logs bytes.Buffer
+			engine.WarnBreakingChanges(location, slog.New(slog.NewJSONHandler(&logs, nil)))
+			engine.OnStart(func(context.Context) error { return errors.New("stop") })
+			So(engine.Listen("127.0.0.1:0"), ShouldBeError, "stop")
+			So(logs.String(), ShouldContainSubstring, `"msg":"breaking change","method":"DELETE","path":"/orders/:id","change":"the operation was removed"`)
+			So(bytes.Count(logs.Bytes(), []byte("breaking change")), ShouldEqual, 4)
+		})
+
+		Convey("A missing spec should be logged without aborting the start", func() {
+			var logs bytes.Buffer
+			engine.WarnBreakingChanges(filepath.Join(t.TempDir(), "missing.json"), slog.New(slog.NewJSONHandler(&logs, nil)))
+			engine.OnStart(func(context.Context) error { return errors.New("stop") })
+			So(engine.Listen("127.0.0.1:0"), ShouldBeError, "stop")
+			So(logs.String(), ShouldContainSubstring, "previous spec unavailable")
+		})
+	})
+}
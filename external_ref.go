@@ -0,0 +1,32 @@
+package soda
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// externalRefs are the URLs of the schemas of the types hosted externally.
+var externalRefs = map[reflect.Type]string{}
+
+// RegisterExternalRef documents the type of the model, e.g. Money{}, with a reference to the schema hosted at the
+// URL, e.g. "https://schemas.corp/money.json", rather than generating it, so that the canonical schemas of an
+// organization are referenced by its services. The values of the type are still bound as usual.
+func RegisterExternalRef(model any, url string) {
+	externalRefs[derefType(reflect.TypeOf(model))] = url
+}
+
+// externalRef returns the reference to the external schema of the type, if registered.
+func externalRef(t reflect.Type) (*openapi3.SchemaRef, bool) {
+	url, ok := externalRefs[t]
+	if !ok {
+		return nil, false
+	}
+	return openapi3.NewSchemaRef(url, nil), true
+}
+
+// isExternalRef reports whether the schema is a reference to a schema outside of the document.
+func isExternalRef(ref *openapi3.SchemaRef) bool {
+	return ref.Value == nil && ref.Ref != "" && !strings.HasPrefix(ref.Ref, "#/")
+}
@@ -0,0 +1,67 @@
+package soda_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type ExternalMoney struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+type ExternalAuditable struct {
+	CreatedBy string `json:"created_by"`
+}
+
+type ExternalInvoice struct {
+	ExternalAuditable
+	Total ExternalMoney            `json:"total"`
+	Lines []ExternalMoney          `json:"lines"`
+	Taxes map[string]ExternalMoney `json:"taxes"`
+}
+
+func TestExternalRef(t *testing.T) {
+	soda.RegisterExternalRef(ExternalMoney{}, "https://schemas.corp/money.json")
+	soda.RegisterExternalRef(&ExternalAuditable{}, "https://schemas.corp/auditable.json")
+
+	Convey("Given an operation whose models refer to externally hosted schemas", t, func() {
+		engine := soda.New()
+		engine.Post("/invoices", func(c *fiber.Ctx) error {
+			in := soda.GetInput[struct {
+				Body ExternalInvoice `body:"json"`
+			}](c)
+			return c.JSON(in.Body.Total)
+		}).SetInput(struct {
+			Body ExternalInvoice `body:"json"`
+		}{}).OK()
+
+		Convey("The schemas of the types should be referenced rather than generated", func() {
+			doc := engine.OpenAPI()
+			So(doc.Components.Schemas, ShouldNotContainKey, "ExternalMoney")
+			So(doc.Components.Schemas, ShouldNotContainKey, "ExternalAuditable")
+
+			invoice := doc.Paths.Value("/invoices").Post.RequestBody.Value.Content.Get("application/json").Schema.Value
+			So(invoice.Properties["total"].Ref, ShouldEqual, "https://schemas.corp/money.json")
+			So(invoice.Properties["lines"].Value.Items.Ref, ShouldEqual, "https://schemas.corp/money.json")
+			So(invoice.Properties["taxes"].Value.AdditionalProperties.Schema.Ref, ShouldEqual, "https://schemas.corp/money.json")
+			So(invoice.AllOf, ShouldHaveLength, 1)
+			So(invoice.AllOf[0].Ref, ShouldEqual, "https://schemas.corp/auditable.json")
+			So(invoice.Properties, ShouldNotContainKey, "created_by")
+		})
+
+		Convey("The values of the types should still be bound", func() {
+			req := httptest.NewRequest("POST", "/invoices", strings.NewReader(`{"total":{"amount":42,"currency":"EUR"}}`))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := engine.App().Test(req)
+			So(err, ShouldBeNil)
+			So(resp.StatusCode, ShouldEqual, http.StatusOK)
+		})
+	})
+}
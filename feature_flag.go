@@ -0,0 +1,49 @@
+package soda
+
+import (
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// FlagProvider reports whether the feature flag is enabled for the request.
+type FlagProvider func(c *fiber.Ctx, flag string) bool
+
+// CodeFeatureDisabled is the code of the HTTPError returned by the operations whose feature flag is off.
+const CodeFeatureDisabled = "feature_disabled"
+
+// featureFlagExtension documents the feature flag gating an operation.
+const featureFlagExtension = "x-feature-flag"
+
+// SetFlagProvider sets the provider of the feature flags gating the operations.
+// Without a provider, every flag is off.
+func (e *Engine) SetFlagProvider(provider FlagProvider) *Engine {
+	e.flagProvider = provider
+	return e
+}
+
+// FeatureFlag gates the operation behind the feature flag, enabling dark launches without touching the handlers:
+// when the flag is off for a request, it is answered with the status, 404 Not Found by default, or 403 Forbidden
+// for entitlements. The status is documented; combine with IgnoreAPIDoc to hide the operation from the spec.
+func (op *OperationBuilder) FeatureFlag(name string, status ...int) *OperationBuilder {
+	code := http.StatusNotFound
+	if len(status) > 0 {
+		code = status[0]
+	}
+	op.featureFlag = name
+	op.featureFlagStatus = code
+	if op.operation.Extensions == nil {
+		op.operation.Extensions = make(map[string]any)
+	}
+	op.operation.Extensions[featureFlagExtension] = name
+	return op.AddErrorResponses(code)
+}
+
+// checkFeatureFlag rejects the requests for which the feature flag of the operation is off.
+func (op *OperationBuilder) checkFeatureFlag(c *fiber.Ctx) error {
+	provider := op.route.engine.flagProvider
+	if provider == nil || !provider(c, op.featureFlag) {
+		return NewError(op.featureFlagStatus, CodeFeatureDisabled, http.StatusText(op.featureFlagStatus))
+	}
+	return c.Next()
+}
@@ -0,0 +1,52 @@
+package soda_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestFeatureFlag(t *testing.T) {
+	Convey("Given operations gated by feature flags", t, func() {
+		handler := func(c *fiber.Ctx) error { return c.SendString("ok") }
+		engine := soda.New().SetFlagProvider(func(c *fiber.Ctx, flag string) bool {
+			return flag == "new-search" && c.Get("X-Beta") == "1"
+		})
+		engine.Get("/search", handler).FeatureFlag("new-search").OK()
+		engine.Get("/reports", handler).FeatureFlag("reports", http.StatusForbidden).OK()
+
+		get := func(target, beta string) (int, string) {
+			req := httptest.NewRequest("GET", target, nil)
+			req.Header.Set("X-Beta", beta)
+			resp, err := engine.App().Test(req)
+			So(err, ShouldBeNil)
+			body, _ := io.ReadAll(resp.Body)
+			return resp.StatusCode, string(body)
+		}
+
+		Convey("They should be routed when the flag is on", func() {
+			status, body := get("/search", "1")
+			So(status, ShouldEqual, http.StatusOK)
+			So(body, ShouldEqual, "ok")
+		})
+
+		Convey("They should be rejected when the flag is off", func() {
+			status, body := get("/search", "0")
+			So(status, ShouldEqual, http.StatusNotFound)
+			So(body, ShouldContainSubstring, soda.CodeFeatureDisabled)
+			status, _ = get("/reports", "1")
+			So(status, ShouldEqual, http.StatusForbidden)
+		})
+
+		Convey("The flag and its status should be documented", func() {
+			operation := engine.OpenAPI().Paths.Find("/reports").Get
+			So(operation.Extensions["x-feature-flag"], ShouldEqual, "reports")
+			So(operation.Responses.Status(http.StatusForbidden), ShouldNotBeNil)
+		})
+	})
+}
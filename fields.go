@@ -0,0 +1,168 @@
+package soda
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+)
+
+// fieldsParameter is the name of the query parameter, and of its component, selecting the fields of the response.
+const fieldsParameter = "fields"
+
+// fieldsMaxDepth bounds the depth of the nested fields which can be selected.
+const fieldsMaxDepth = 8
+
+// fieldTree holds the selected fields by name, a nil subtree selecting the field whole.
+type fieldTree map[string]fieldTree
+
+// SparseFields lets clients select the fields of the JSON response with the fields query parameter, such as
+// ?fields=id,name,address.city, the nested fields being separated by dots. The parameter is documented as
+// a reference to the fields parameter component, and the selected fields are validated against the schema
+// of the success response, which must be documented before OK, unknown fields being rejected with a 400 error.
+func (op *OperationBuilder) SparseFields() *OperationBuilder {
+	components := op.route.gen.doc.Components.Parameters
+	if _, ok := components[fieldsParameter]; !ok {
+		components[fieldsParameter] = &openapi3.ParameterRef{Value: openapi3.NewQueryParameter(fieldsParameter).
+			WithDescription("The comma-separated fields of the response to return, nested fields being separated by dots.").
+			WithSchema(openapi3.NewArraySchema().WithItems(openapi3.NewStringSchema()))}
+		components[fieldsParameter].Value.Explode = openapi3.BoolPtr(false)
+	}
+	op.operation.Parameters = append(op.operation.Parameters, &openapi3.ParameterRef{
+		Ref:   "#/components/parameters/" + fieldsParameter,
+		Value: components[fieldsParameter].Value,
+	})
+	op.AddErrorResponses(http.StatusBadRequest)
+	op.sparseFields = true
+	return op
+}
+
+// filterFields returns the handler pruning the JSON response of the operation to the selected fields.
+func (op *OperationBuilder) filterFields() fiber.Handler {
+	known := make(map[string]bool)
+	if schema := op.successSchema(); schema != nil {
+		collectFields(op.route.gen.doc, schema, "", 0, known)
+	}
+	envelope := op.route.gen.envelope
+	return func(c *fiber.Ctx) error {
+		query := c.Query(fieldsParameter)
+		if query == "" {
+			return c.Next()
+		}
+		tree := make(fieldTree)
+		var unknown []string
+		for _, field := range strings.Split(query, ",") {
+			field = strings.TrimSpace(field)
+			if field == "" {
+				continue
+			}
+			if !known[field] {
+				unknown = append(unknown, field)
+				continue
+			}
+			tree.add(strings.Split(field, "."))
+		}
+		if len(unknown) != 0 {
+			return NewError(http.StatusBadRequest, MsgInvalidFields, Translate(c, MsgInvalidFields, strings.Join(unknown, ", ")))
+		}
+
+		if err := c.Next(); err != nil {
+			return err
+		}
+		if status := c.Response().StatusCode(); status < 200 || status >= 300 ||
+			!isJSONMediaType(requestMediaType(string(c.Response().Header.ContentType()))) {
+			return nil
+		}
+		var body any
+		if err := json.Unmarshal(c.Response().Body(), &body); err != nil {
+			return nil
+		}
+		if object, ok := body.(map[string]any); ok && envelope {
+			object["data"] = tree.prune(object["data"])
+		} else {
+			body = tree.prune(body)
+		}
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		c.Response().SetBodyRaw(data)
+		return nil
+	}
+}
+
+// successSchema returns the schema of the data of the first documented JSON success response.
+func (op *OperationBuilder) successSchema() *openapi3.Schema {
+	for code := http.StatusOK; code < http.StatusMultipleChoices; code++ {
+		response := op.operation.Responses.Status(code)
+		if response == nil || response.Value == nil {
+			continue
+		}
+		content := response.Value.Content.Get(fiber.MIMEApplicationJSON)
+		if content == nil || content.Schema == nil {
+			continue
+		}
+		schema := derefSchema(op.route.gen.doc, content.Schema)
+		if op.route.gen.envelope && schema.Properties["data"] != nil {
+			schema = derefSchema(op.route.gen.doc, schema.Properties["data"])
+		}
+		return schema
+	}
+	return nil
+}
+
+// collectFields collects the dotted paths of the properties of the schema, of its items if it is an array.
+func collectFields(doc *openapi3.T, schema *openapi3.Schema, prefix string, depth int, known map[string]bool) {
+	if depth >= fieldsMaxDepth {
+		return
+	}
+	if schema.Items != nil {
+		collectFields(doc, derefSchema(doc, schema.Items), prefix, depth, known)
+		return
+	}
+	for name, property := range schema.Properties {
+		known[prefix+name] = true
+		collectFields(doc, derefSchema(doc, property), prefix+name+".", depth+1, known)
+	}
+}
+
+// add selects the field at the path.
+func (t fieldTree) add(path []string) {
+	sub, ok := t[path[0]]
+	if ok && sub == nil {
+		// The field is already selected whole.
+		return
+	}
+	if len(path) == 1 {
+		t[path[0]] = nil
+		return
+	}
+	if sub == nil {
+		sub = make(fieldTree)
+		t[path[0]] = sub
+	}
+	sub.add(path[1:])
+}
+
+// prune removes the fields which are not selected from the JSON value, or from its items if it is an array.
+func (t fieldTree) prune(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for name, value := range v {
+			sub, ok := t[name]
+			switch {
+			case !ok:
+				delete(v, name)
+			case sub != nil:
+				v[name] = sub.prune(value)
+			}
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = t.prune(item)
+		}
+	}
+	return v
+}
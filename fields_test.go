@@ -0,0 +1,73 @@
+package soda_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type sparseAddress struct {
+	City    string `json:"city"`
+	Country string `json:"country"`
+}
+
+type sparseUser struct {
+	ID      int           `json:"id"`
+	Name    string        `json:"name"`
+	Email   string        `json:"email"`
+	Address sparseAddress `json:"address"`
+}
+
+func TestSparseFields(t *testing.T) {
+	Convey("Given operations with sparse fieldsets", t, func() {
+		user := sparseUser{ID: 1, Name: "ada", Email: "ada@example.com", Address: sparseAddress{City: "London", Country: "UK"}}
+		engine := soda.New()
+		engine.Get("/users/:id", func(c *fiber.Ctx) error { return c.JSON(user) }).
+			AddJSONResponse(http.StatusOK, sparseUser{}).SparseFields().OK()
+		engine.Get("/users", func(c *fiber.Ctx) error { return c.JSON([]sparseUser{user, user}) }).
+			AddJSONResponse(http.StatusOK, []sparseUser{}).SparseFields().OK()
+
+		get := func(target string) (int, string) {
+			resp, err := engine.App().Test(httptest.NewRequest("GET", target, nil))
+			So(err, ShouldBeNil)
+			body, _ := io.ReadAll(resp.Body)
+			return resp.StatusCode, string(body)
+		}
+
+		Convey("The response should be pruned to the selected fields", func() {
+			_, body := get("/users/1?fields=id,address.city")
+			So(body, ShouldEqual, `{"address":{"city":"London"},"id":1}`)
+			_, body = get("/users?fields=name")
+			So(body, ShouldEqual, `[{"name":"ada"},{"name":"ada"}]`)
+			_, body = get("/users/1?fields=address,address.city")
+			So(body, ShouldEqual, `{"address":{"city":"London","country":"UK"}}`)
+		})
+
+		Convey("The whole response should be sent without selection", func() {
+			_, body := get("/users/1")
+			So(body, ShouldEqual, `{"id":1,"name":"ada","email":"ada@example.com","address":{"city":"London","country":"UK"}}`)
+		})
+
+		Convey("Unknown fields should be rejected", func() {
+			status, body := get("/users/1?fields=id,password,address.zip")
+			So(status, ShouldEqual, http.StatusBadRequest)
+			So(body, ShouldContainSubstring, `"code":"invalid_fields"`)
+			So(body, ShouldContainSubstring, "unknown fields: password, address.zip")
+		})
+
+		Convey("The parameter should be documented as a shared component", func() {
+			doc := engine.OpenAPI()
+			So(doc.Components.Parameters, ShouldContainKey, "fields")
+			for _, path := range []string{"/users/:id", "/users"} {
+				operation := doc.Paths.Value(path).Get
+				So(operation.Parameters[len(operation.Parameters)-1].Ref, ShouldEqual, "#/components/parameters/fields")
+				So(operation.Responses.Status(http.StatusBadRequest), ShouldNotBeNil)
+			}
+		})
+	})
+}
@@ -0,0 +1,90 @@
+package soda
+
+import (
+	"github.com/getkin/kin-openapi/openapi3"
+	"gopkg.in/yaml.v3"
+)
+
+// Freeze finalizes the spec once all the operations are registered: it is built once and for all, and registering
+// operations afterwards panics. Before Freeze, the spec is built lazily when served and rebuilt after the
+// registration of operations. The spec is linted beforehand if enabled, see EnableLint.
+func (e *Engine) Freeze() *Engine {
+	if err := e.lintSpec(); err != nil {
+		panic(err.Error())
+	}
+	e.specMu.Lock()
+	defer e.specMu.Unlock()
+	e.frozen = true
+	e.buildSpec()
+	return e
+}
+
+// Frozen reports whether the engine is frozen.
+func (e *Engine) Frozen() bool {
+	e.specMu.Lock()
+	defer e.specMu.Unlock()
+	return e.frozen
+}
+
+// UpdateSpec applies the change to the spec while it is not being served, and discards the built spec,
+// so that tools can amend it at runtime, e.g. with harvested examples. It reports false, without applying the
+// change, if the engine is frozen.
+func (e *Engine) UpdateSpec(change func(doc *openapi3.T)) bool {
+	e.specMu.Lock()
+	defer e.specMu.Unlock()
+	if e.frozen {
+		return false
+	}
+	change(e.gen.doc)
+	e.discardSpec()
+	return true
+}
+
+// specJSON returns the spec marshaled as JSON, building it if needed.
+func (e *Engine) specJSON() []byte {
+	e.specMu.Lock()
+	defer e.specMu.Unlock()
+	if e.cachedSpecJSON == nil {
+		e.cachedSpecJSON, _ = e.gen.doc.MarshalJSON()
+	}
+	return e.cachedSpecJSON
+}
+
+// specYAML returns the spec marshaled as YAML, building it if needed.
+func (e *Engine) specYAML() []byte {
+	e.specMu.Lock()
+	defer e.specMu.Unlock()
+	if e.cachedSpecYAML == nil {
+		e.cachedSpecYAML, _ = yaml.Marshal(e.gen.doc)
+	}
+	return e.cachedSpecYAML
+}
+
+// buildSpec marshals the spec unless it is already. The caller must hold specMu.
+func (e *Engine) buildSpec() {
+	if e.cachedSpecJSON == nil {
+		e.cachedSpecJSON, _ = e.gen.doc.MarshalJSON()
+	}
+	if e.cachedSpecYAML == nil {
+		e.cachedSpecYAML, _ = yaml.Marshal(e.gen.doc)
+	}
+}
+
+// invalidateSpec discards the built spec after a change, or panics if the engine is frozen.
+func (e *Engine) invalidateSpec() {
+	e.specMu.Lock()
+	defer e.specMu.Unlock()
+	if e.frozen {
+		panic("the engine is frozen: operations can't be registered after Freeze")
+	}
+	e.discardSpec()
+}
+
+// discardSpec discards the built spec and its variants. The caller must hold specMu.
+func (e *Engine) discardSpec() {
+	e.cachedSpecJSON, e.cachedSpecYAML = nil, nil
+	e.specVariants.Range(func(key, _ any) bool {
+		e.specVariants.Delete(key)
+		return true
+	})
+}
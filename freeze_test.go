@@ -0,0 +1,53 @@
+package soda_test
+
+import (
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestFreeze(t *testing.T) {
+	Convey("Given an engine serving its spec", t, func() {
+		handler := func(c *fiber.Ctx) error { return nil }
+		engine := soda.New().ServeSpecJSON("/openapi.json").ServeSpecYAML("/openapi.yaml")
+		engine.Get("/users", handler).OK()
+
+		get := func(target string) string {
+			resp, err := engine.App().Test(httptest.NewRequest("GET", target, nil))
+			So(err, ShouldBeNil)
+			body, _ := io.ReadAll(resp.Body)
+			return string(body)
+		}
+
+		Convey("The spec should be built lazily, including the operations registered afterwards", func() {
+			So(get("/openapi.json"), ShouldContainSubstring, "/users")
+			engine.Get("/orders", handler).OK()
+			So(get("/openapi.json"), ShouldContainSubstring, "/orders")
+			So(get("/openapi.yaml"), ShouldContainSubstring, "/orders")
+		})
+
+		Convey("Registering operations after Freeze should panic", func() {
+			So(engine.Frozen(), ShouldBeFalse)
+			engine.Freeze()
+			So(engine.Frozen(), ShouldBeTrue)
+			So(func() { engine.Get("/orders", handler).OK() }, ShouldPanicWith,
+				"the engine is frozen: operations can't be registered after Freeze")
+			So(get("/openapi.json"), ShouldNotContainSubstring, "/orders")
+			So(get("/openapi.yaml"), ShouldContainSubstring, "/users")
+		})
+
+		Convey("The spec should be updated until Freeze", func() {
+			So(get("/openapi.json"), ShouldNotContainSubstring, "Updated")
+			So(engine.UpdateSpec(func(doc *openapi3.T) { doc.Info.Title = "Updated" }), ShouldBeTrue)
+			So(get("/openapi.json"), ShouldContainSubstring, "Updated")
+			engine.Freeze()
+			So(engine.UpdateSpec(func(doc *openapi3.T) { doc.Info.Title = "Frozen" }), ShouldBeFalse)
+			So(get("/openapi.yaml"), ShouldNotContainSubstring, "Frozen")
+		})
+	})
+}
@@ -0,0 +1,62 @@
+package soda
+
+import (
+	"net/http"
+	"reflect"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+)
+
+// GraphQLRequest is the body of the requests to a GraphQL endpoint.
+type GraphQLRequest struct {
+	Query         string         `json:"query" oai:"description=The GraphQL document to execute"`
+	OperationName string         `json:"operationName,omitempty" oai:"description=The operation of the document to execute"`
+	Variables     map[string]any `json:"variables,omitempty" oai:"description=The values of the variables of the operation"`
+}
+
+// GraphQLResponse is the body of the responses of a GraphQL endpoint.
+type GraphQLResponse struct {
+	Data   any            `json:"data,omitempty"`
+	Errors []GraphQLError `json:"errors,omitempty"`
+}
+
+// GraphQLError is an error of a GraphQLResponse.
+type GraphQLError struct {
+	Message    string         `json:"message"`
+	Path       []any          `json:"path,omitempty"`
+	Extensions map[string]any `json:"extensions,omitempty"`
+}
+
+// graphqlInput is the input of GraphQL endpoints.
+type graphqlInput struct {
+	Body GraphQLRequest `body:"json"`
+}
+
+// graphqlExample is the example of the body of the requests to GraphQL endpoints.
+var graphqlExample = GraphQLRequest{
+	Query:     "query User($id: ID!) { user(id: $id) { id name } }",
+	Variables: map[string]any{"id": "1"},
+}
+
+// GraphQL registers a GraphQL endpoint served by the handlers of a GraphQL server, so that its schema stays the
+// source of truth while the endpoint is documented with the REST operations: as a POST with a generic
+// query/variables body and response. The bound body is available through GetGraphQLRequest but the
+// handlers are free to read the raw body instead. The response is never wrapped in an envelope.
+func (r *Router) GraphQL(pattern string, handlers ...fiber.Handler) *OperationBuilder {
+	op := r.Post(pattern, handlers...).
+		SetSummary("GraphQL endpoint").
+		SetInput(graphqlInput{})
+	op.requestBody().Content.Get("application/json").Example = graphqlExample
+	schema := op.route.gen.generateSchemaRef(nil, reflect.TypeOf(GraphQLResponse{}), "json")
+	response := openapi3.NewResponse().
+		WithDescription(http.StatusText(http.StatusOK)).
+		WithContent(openapi3.NewContentWithJSONSchemaRef(schema))
+	op.mergeResponse(http.StatusOK, response, "")
+	return op
+}
+
+// GetGraphQLRequest gets the body of the request to a GraphQL endpoint.
+func GetGraphQLRequest(c *fiber.Ctx) *GraphQLRequest {
+	return &GetInput[graphqlInput](c).Body
+}
@@ -0,0 +1,46 @@
+package soda_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGraphQL(t *testing.T) {
+	Convey("Given a GraphQL endpoint", t, func() {
+		engine := soda.New()
+		engine.GraphQL("/graphql", func(c *fiber.Ctx) error {
+			req := soda.GetGraphQLRequest(c)
+			return c.JSON(soda.GraphQLResponse{Data: map[string]any{"query": req.Query, "id": req.Variables["id"]}})
+		}).OK()
+
+		Convey("It should be documented as a POST with a generic body and example", func() {
+			operation := engine.OpenAPI().Paths.Find("/graphql").Post
+			So(operation, ShouldNotBeNil)
+			content := operation.RequestBody.Value.Content.Get("application/json")
+			So(content.Schema.Ref, ShouldEqual, "#/components/schemas/post--graphql-body")
+			So(content.Example, ShouldNotBeNil)
+			response := operation.Responses.Status(http.StatusOK).Value.Content.Get("application/json")
+			So(response.Schema.Ref, ShouldEqual, "#/components/schemas/soda.GraphQLResponse")
+		})
+
+		Convey("The handlers should receive the bound request", func() {
+			body := `{"query":"{ user(id: $id) { id } }","variables":{"id":"1"}}`
+			req := httptest.NewRequest("POST", "/graphql", strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			resp, _ := engine.App().Test(req)
+			So(resp.StatusCode, ShouldEqual, http.StatusOK)
+			data, _ := io.ReadAll(resp.Body)
+			var got soda.GraphQLResponse
+			So(json.Unmarshal(data, &got), ShouldBeNil)
+			So(got.Data, ShouldResemble, map[string]any{"query": "{ user(id: $id) { id } }", "id": "1"})
+		})
+	})
+}
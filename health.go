@@ -0,0 +1,80 @@
+package soda
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Health statuses of HealthReport and HealthCheckResult.
+const (
+	HealthUp   = "up"
+	HealthDown = "down"
+)
+
+// HealthCheck checks a dependency of the application, such as a database.
+type HealthCheck struct {
+	Name  string
+	Check func(ctx context.Context) error
+}
+
+// HealthCheckResult is the result of a HealthCheck.
+type HealthCheckResult struct {
+	Status string `json:"status"          oai:"enum=up,down"`
+	Error  string `json:"error,omitempty"`
+}
+
+// HealthReport is the response of the health endpoints.
+type HealthReport struct {
+	Status string                       `json:"status"           oai:"enum=up,down"`
+	Checks map[string]HealthCheckResult `json:"checks,omitempty"`
+}
+
+// Health registers a documented health endpoint running the checks concurrently, which responds 200 when all of
+// them pass and 503 otherwise, with the status of each. Without checks it serves as a liveness endpoint.
+func (r *Router) Health(pattern string, checks ...HealthCheck) *Router {
+	r.Get(pattern, func(c *fiber.Ctx) error {
+		report := runHealthChecks(c.UserContext(), checks)
+		status := http.StatusOK
+		if report.Status == HealthDown {
+			status = http.StatusServiceUnavailable
+		}
+		return c.Status(status).JSON(report)
+	}).
+		SetSummary("Health check").
+		AddJSONResponse(http.StatusOK, HealthReport{}, "All checks pass").
+		AddJSONResponse(http.StatusServiceUnavailable, HealthReport{}, "Some checks fail").
+		OK()
+	return r
+}
+
+// runHealthChecks runs the checks concurrently.
+func runHealthChecks(ctx context.Context, checks []HealthCheck) HealthReport {
+	report := HealthReport{Status: HealthUp}
+	if len(checks) == 0 {
+		return report
+	}
+	report.Checks = make(map[string]HealthCheckResult, len(checks))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, check := range checks {
+		wg.Add(1)
+		go func(check HealthCheck) {
+			defer wg.Done()
+			result := HealthCheckResult{Status: HealthUp}
+			if err := check.Check(ctx); err != nil {
+				result = HealthCheckResult{Status: HealthDown, Error: err.Error()}
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[check.Name] = result
+			if result.Status == HealthDown {
+				report.Status = HealthDown
+			}
+		}(check)
+	}
+	wg.Wait()
+	return report
+}
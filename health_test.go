@@ -0,0 +1,54 @@
+package soda_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestHealth(t *testing.T) {
+	Convey("Given liveness and readiness endpoints", t, func() {
+		var dbErr error
+		engine := soda.New()
+		engine.Health("/livez")
+		engine.Health("/readyz",
+			soda.HealthCheck{Name: "db", Check: func(ctx context.Context) error { return dbErr }},
+			soda.HealthCheck{Name: "cache", Check: func(ctx context.Context) error { return nil }},
+		)
+
+		request := func(path string) (int, string) {
+			resp, _ := engine.App().Test(httptest.NewRequest("GET", path, nil))
+			body, _ := io.ReadAll(resp.Body)
+			return resp.StatusCode, string(body)
+		}
+
+		Convey("They should be documented", func() {
+			responses := engine.OpenAPI().Paths.Find("/readyz").Get.Responses
+			So(responses.Status(http.StatusOK), ShouldNotBeNil)
+			So(responses.Status(http.StatusServiceUnavailable), ShouldNotBeNil)
+		})
+
+		Convey("The liveness endpoint should be up", func() {
+			status, body := request("/livez")
+			So(status, ShouldEqual, http.StatusOK)
+			So(body, ShouldEqual, `{"status":"up"}`)
+		})
+
+		Convey("The readiness endpoint should report each dependency", func() {
+			status, body := request("/readyz")
+			So(status, ShouldEqual, http.StatusOK)
+			So(body, ShouldEqual, `{"status":"up","checks":{"cache":{"status":"up"},"db":{"status":"up"}}}`)
+
+			dbErr = errors.New("connection refused")
+			status, body = request("/readyz")
+			So(status, ShouldEqual, http.StatusServiceUnavailable)
+			So(body, ShouldEqual, `{"status":"down","checks":{"cache":{"status":"up"},"db":{"status":"down","error":"connection refused"}}}`)
+		})
+	})
+}
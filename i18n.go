@@ -0,0 +1,135 @@
+package soda
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Keys of the messages of the errors sent by soda, which are also the codes of the errors.
+const (
+	MsgInvalidPath   = "invalid_path"
+	MsgInvalidHeader = "invalid_header"
+	MsgInvalidQuery  = "invalid_query"
+	MsgInvalidCookie = "invalid_cookie"
+	MsgInvalidBody   = "invalid_body"
+	// MsgInvalidBodyType is formatted with the JSON pointer of the invalid value, its expected type and its type.
+	MsgInvalidBodyType = "invalid_body_type"
+	// MsgInvalidFields is formatted with the unknown fields selected by the fields query parameter.
+	MsgInvalidFields = "invalid_fields"
+	// MsgInvalidValue is formatted with the JSON pointer of the invalid value of the request body and the reason.
+	MsgInvalidValue = "invalid_value"
+	// MsgMethodNotAllowed is formatted with the method of the request.
+	MsgMethodNotAllowed = "method_not_allowed"
+	// MsgResponseTooLarge is formatted with the size of the response and the limit.
+	MsgResponseTooLarge = "response_too_large"
+	// MsgPreconditionFailed is the message of the conditional requests whose precondition doesn't hold.
+	MsgPreconditionFailed = "precondition_failed"
+)
+
+// Catalog maps message keys to the fmt formats of the messages in a language.
+type Catalog map[string]string
+
+// DefaultLanguage is the language of the messages when none of the languages accepted by the client has a catalog.
+var DefaultLanguage = "en"
+
+var (
+	catalogsMu sync.RWMutex
+	catalogs   = map[string]Catalog{
+		"en": {
+			MsgInvalidPath:        "invalid path parameters: %v",
+			MsgInvalidHeader:      "invalid headers: %v",
+			MsgInvalidQuery:       "invalid query parameters: %v",
+			MsgInvalidCookie:      "invalid cookies: %v",
+			MsgInvalidBody:        "invalid request body: %v",
+			MsgInvalidBodyType:    "invalid request body: %s must be %s, not %s",
+			MsgInvalidFields:      "unknown fields: %s",
+			MsgInvalidValue:       "invalid request body: %s %s",
+			MsgMethodNotAllowed:   "method %s is not allowed",
+			MsgResponseTooLarge:   "the response of %d bytes exceeds the limit of %d bytes",
+			MsgPreconditionFailed: "the resource has been modified",
+		},
+	}
+)
+
+// RegisterCatalog registers the messages of a language, e.g. "fr" or "pt-BR",
+// merging them into the messages already registered for it.
+func RegisterCatalog(lang string, catalog Catalog) {
+	catalogsMu.Lock()
+	defer catalogsMu.Unlock()
+	if catalogs[lang] == nil {
+		catalogs[lang] = make(Catalog, len(catalog))
+	}
+	for key, format := range catalog {
+		catalogs[lang][key] = format
+	}
+}
+
+// Translate formats the message of the key in the language that best matches the Accept-Language header
+// of the request, falling back to DefaultLanguage, then to the key itself.
+func Translate(c *fiber.Ctx, key string, args ...any) string {
+	catalogsMu.RLock()
+	defer catalogsMu.RUnlock()
+	langs := []string{DefaultLanguage}
+	for lang, catalog := range catalogs {
+		if _, ok := catalog[key]; ok && lang != DefaultLanguage {
+			langs = append(langs, lang)
+		}
+	}
+	slices.Sort(langs[1:])
+	format, ok := catalogs[c.AcceptsLanguages(langs...)][key]
+	if !ok {
+		if format, ok = catalogs[DefaultLanguage][key]; !ok {
+			return key
+		}
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// SetBindErrorStatus sets the status of the responses to the requests whose input can't be bound, e.g. a string
+// sent for an integer, 500 by default. The status is documented as an HTTPError response of the operations with
+// an input registered afterwards.
+func (e *Engine) SetBindErrorStatus(status int) *Engine {
+	e.bindErrorStatus = status
+	return e
+}
+
+// documentBindError documents the response to the requests whose input can't be bound, if its status is set.
+func (op *OperationBuilder) documentBindError() {
+	status := op.route.engine.bindErrorStatus
+	if status != 0 && op.input != nil && op.operation.Responses.Status(status) == nil {
+		op.AddErrorResponses(status)
+	}
+}
+
+// bindErrorStatus returns the status of the errors binding the input, the one set by SetBindErrorStatus or 500.
+func (op *OperationBuilder) bindErrorStatus() int {
+	if status := op.route.engine.bindErrorStatus; status != 0 {
+		return status
+	}
+	return http.StatusInternalServerError
+}
+
+// bindError turns an error binding the input into an HTTPError with a translated message, whose status is the
+// one set by SetBindErrorStatus, 500 by default, and which unwraps to the error. Errors which already have a
+// status, such as those of the decompression of the body, are returned as is.
+func (op *OperationBuilder) bindError(c *fiber.Ctx, key string, err error) error {
+	var fiberErr *fiber.Error
+	var httpErr *HTTPError
+	if errors.As(err, &fiberErr) || errors.As(err, &httpErr) {
+		return err
+	}
+	status := op.bindErrorStatus()
+	if pointer, expected, actual, ok := jsonTypeMismatch(err); ok {
+		httpErr = NewError(status, MsgInvalidBodyType, Translate(c, MsgInvalidBodyType, pointer, expected, actual))
+		httpErr.Pointer, httpErr.Expected = pointer, expected
+	} else {
+		httpErr = NewError(status, key, Translate(c, key, err))
+	}
+	httpErr.err = err
+	return httpErr
+}
@@ -0,0 +1,87 @@
+package soda_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestTranslation(t *testing.T) {
+	Convey("Given a catalog of French messages", t, func() {
+		soda.RegisterCatalog("fr", soda.Catalog{
+			soda.MsgInvalidQuery: "paramètres de requête invalides : %v",
+		})
+		type input struct {
+			Page int `query:"page"`
+		}
+		engine := soda.New()
+		engine.Get("/items", func(c *fiber.Ctx) error { return nil }).SetInput(&input{}).OK()
+
+		request := func(lang string) (int, string) {
+			req := httptest.NewRequest("GET", "/items?page=first", nil)
+			if lang != "" {
+				req.Header.Set("Accept-Language", lang)
+			}
+			resp, _ := engine.App().Test(req)
+			body, _ := io.ReadAll(resp.Body)
+			return resp.StatusCode, string(body)
+		}
+
+		Convey("Bind errors should be translated in the accepted language", func() {
+			status, body := request("fr-FR,fr;q=0.9")
+			So(status, ShouldEqual, http.StatusInternalServerError)
+			So(body, ShouldStartWith, `{"code":"invalid_query","message":"paramètres de requête invalides : `)
+		})
+
+		Convey("Bind errors should fall back to the default language", func() {
+			_, body := request("de")
+			So(body, ShouldStartWith, `{"code":"invalid_query","message":"invalid query parameters: `)
+			_, body = request("")
+			So(body, ShouldStartWith, `{"code":"invalid_query","message":"invalid query parameters: `)
+		})
+
+		Convey("The status of the bind errors should be documented once set", func() {
+			engine.SetBindErrorStatus(http.StatusBadRequest)
+			engine.Get("/pages", func(c *fiber.Ctx) error { return nil }).SetInput(&input{}).OK()
+			So(engine.OpenAPI().Paths.Find("/items").Get.Responses.Status(http.StatusBadRequest), ShouldBeNil)
+			So(engine.OpenAPI().Paths.Find("/pages").Get.Responses.Status(http.StatusBadRequest), ShouldNotBeNil)
+
+			req := httptest.NewRequest("GET", "/pages?page=first", nil)
+			resp, _ := engine.App().Test(req)
+			So(resp.StatusCode, ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("Bind errors should be left to the error handler of the app", func() {
+			var cause error
+			engine := soda.NewWith(fiber.New(fiber.Config{ErrorHandler: func(c *fiber.Ctx, err error) error {
+				var httpErr *soda.HTTPError
+				if errors.As(err, &httpErr) && httpErr.Code == soda.MsgInvalidQuery {
+					cause = errors.Unwrap(httpErr)
+					return c.Status(http.StatusUnprocessableEntity).SendString(httpErr.Message)
+				}
+				return fiber.DefaultErrorHandler(c, err)
+			}}))
+			engine.Get("/items", func(c *fiber.Ctx) error { return nil }).SetInput(&input{}).OK()
+
+			resp, _ := engine.App().Test(httptest.NewRequest("GET", "/items?page=first", nil))
+			So(resp.StatusCode, ShouldEqual, http.StatusUnprocessableEntity)
+			body, _ := io.ReadAll(resp.Body)
+			So(string(body), ShouldStartWith, "invalid query parameters: ")
+			So(cause, ShouldNotBeNil)
+		})
+
+		Convey("Unknown keys should be returned as is", func() {
+			app := fiber.New()
+			app.Get("/", func(c *fiber.Ctx) error { return c.SendString(soda.Translate(c, "unknown")) })
+			resp, _ := app.Test(httptest.NewRequest("GET", "/", nil))
+			body, _ := io.ReadAll(resp.Body)
+			So(string(body), ShouldEqual, "unknown")
+		})
+	})
+}
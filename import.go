@@ -0,0 +1,158 @@
+package soda
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"gopkg.in/yaml.v3"
+)
+
+// ImportComponents imports the components of the OpenAPI document, or the schemas of the JSON Schema document, of
+// the JSON or YAML file at the path, so that the operations can reference them by name, e.g. with AddSchemaResponse,
+// UseRequestBodySchema, UseResponse or UseRequestBody, when the source of truth of the models is an external schema
+// registry. The root schema of a JSON Schema document is named after its title, or the file name otherwise, and
+// its $defs and definitions after their keys. It returns an error if a component conflicts with an existing one.
+func (e *Engine) ImportComponents(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var raw map[string]any
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("parse %s: %w", path, err)
+	}
+	if _, ok := raw["openapi"]; !ok {
+		if _, ok := raw["components"]; !ok {
+			raw = jsonSchemaDocument(raw, path)
+		}
+	}
+	data, err = json.Marshal(raw)
+	if err != nil {
+		return fmt.Errorf("parse %s: %w", path, err)
+	}
+	doc, err := openapi3.NewLoader().LoadFromData(data)
+	if err != nil {
+		return fmt.Errorf("load %s: %w", path, err)
+	}
+	if doc.Components == nil {
+		return nil
+	}
+	e.invalidateSpec()
+	return mergeComponents(e.gen.doc.Components, doc.Components, path, "the engine")
+}
+
+// jsonSchemaDocument wraps the JSON Schema document of the file in an OpenAPI document whose schema components are
+// its root schema and its definitions, rewriting the references between them.
+func jsonSchemaDocument(schema map[string]any, path string) map[string]any {
+	name, _ := schema["title"].(string)
+	if name == "" {
+		name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	schemas := make(map[string]any)
+	for _, key := range []string{"$defs", "definitions"} {
+		defs, _ := schema[key].(map[string]any)
+		for def, value := range defs {
+			schemas[def] = value
+		}
+		delete(schema, key)
+	}
+	for _, key := range []string{"$schema", "$id", "$comment"} {
+		delete(schema, key)
+	}
+	if len(schema) != 0 {
+		schemas[name] = schema
+	}
+	rewriteJSONSchemaRefs(schemas, "#/components/schemas/"+name)
+	return map[string]any{
+		"openapi":    "3.0.3",
+		"info":       map[string]any{"title": name, "version": "0"},
+		"paths":      map[string]any{},
+		"components": map[string]any{"schemas": schemas},
+	}
+}
+
+// rewriteJSONSchemaRefs rewrites the references of the JSON Schema document to its definitions and its root into
+// references to the schema components.
+func rewriteJSONSchemaRefs(value any, root string) {
+	switch v := value.(type) {
+	case map[string]any:
+		if ref, ok := v["$ref"].(string); ok {
+			switch {
+			case ref == "#":
+				v["$ref"] = root
+			case strings.HasPrefix(ref, "#/$defs/"):
+				v["$ref"] = "#/components/schemas/" + strings.TrimPrefix(ref, "#/$defs/")
+			case strings.HasPrefix(ref, "#/definitions/"):
+				v["$ref"] = "#/components/schemas/" + strings.TrimPrefix(ref, "#/definitions/")
+			}
+		}
+		for _, item := range v {
+			rewriteJSONSchemaRefs(item, root)
+		}
+	case []any:
+		for _, item := range v {
+			rewriteJSONSchemaRefs(item, root)
+		}
+	}
+}
+
+// AddSchemaResponse documents the JSON response with the status as a reference to the schema component, e.g. one
+// imported with ImportComponents. It panics if the component is not registered.
+func (op *OperationBuilder) AddSchemaResponse(code int, name string, description ...string) (self *OperationBuilder) {
+	defer op.recoverMisuse(&self)
+	desc := ""
+	if len(description) > 0 {
+		desc = description[0]
+	}
+	response := op.route.gen.GenerateResponse(code, nil, "", desc)
+	if !isNoContent(code) {
+		schema := op.schemaComponentRef(name)
+		if op.route.gen.envelope {
+			schema = envelopeSchema(code, schema)
+		}
+		response.WithContent(openapi3.NewContentWithJSONSchemaRef(schema))
+	}
+	op.mergeResponse(code, response, desc)
+	return op
+}
+
+// UseRequestBodySchema documents the JSON request body of the operation as a reference to the schema component,
+// e.g. one imported with ImportComponents, replacing the schema generated from the input, which is still used for
+// binding. It must be called after SetInput and panics if the component is not registered.
+func (op *OperationBuilder) UseRequestBodySchema(name string) (self *OperationBuilder) {
+	defer op.recoverMisuse(&self)
+	schema := op.schemaComponentRef(name)
+	for mediaType, content := range op.requestBody().Content {
+		if strings.Contains(mediaType, "json") {
+			content.Schema = schema
+		}
+	}
+	return op
+}
+
+// UseResponse documents the response with the status as a reference to the response component, e.g. one imported
+// with ImportComponents. It panics if the component is not registered.
+func (op *OperationBuilder) UseResponse(code int, name string) (self *OperationBuilder) {
+	defer op.recoverMisuse(&self)
+	response, ok := op.route.gen.doc.Components.Responses[name]
+	if !ok {
+		panic("response component " + name + " is not registered")
+	}
+	op.operation.Responses.Set(strconv.Itoa(code),
+		&openapi3.ResponseRef{Ref: "#/components/responses/" + name, Value: response.Value})
+	return op
+}
+
+// schemaComponentRef returns a reference to the schema component, panicking if it is not registered.
+func (op *OperationBuilder) schemaComponentRef(name string) *openapi3.SchemaRef {
+	schema, ok := op.route.gen.doc.Components.Schemas[name]
+	if !ok {
+		panic("schema component " + name + " is not registered")
+	}
+	return openapi3.NewSchemaRef("#/components/schemas/"+name, schema.Value)
+}
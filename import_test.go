@@ -0,0 +1,128 @@
+package soda_test
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const importedComponents = `
+openapi: 3.0.3
+info: {title: registry, version: "1"}
+paths: {}
+components:
+  schemas:
+    Money:
+      type: object
+      required: [amount, currency]
+      properties:
+        amount: {type: integer}
+        currency: {type: string}
+    Price:
+      type: object
+      properties:
+        net: {$ref: '#/components/schemas/Money'}
+  responses:
+    NotFound:
+      description: The resource was not found.
+`
+
+const importedJSONSchema = `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "title": "Address",
+  "type": "object",
+  "properties": {
+    "country": {"$ref": "#/$defs/Country"},
+    "previous": {"$ref": "#"}
+  },
+  "$defs": {
+    "Country": {"type": "string", "minLength": 2, "maxLength": 2}
+  }
+}`
+
+func TestImportComponents(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	components := write("components.yaml", importedComponents)
+	address := write("address.schema.json", importedJSONSchema)
+
+	Convey("Given an engine importing components", t, func() {
+		engine := soda.New()
+		So(engine.ImportComponents(components), ShouldBeNil)
+		So(engine.ImportComponents(address), ShouldBeNil)
+		doc := engine.OpenAPI()
+
+		Convey("The components of an OpenAPI document should be imported", func() {
+			So(doc.Components.Schemas, ShouldContainKey, "Money")
+			So(doc.Components.Schemas["Price"].Value.Properties["net"].Ref, ShouldEqual, "#/components/schemas/Money")
+			So(doc.Components.Responses, ShouldContainKey, "NotFound")
+		})
+
+		Convey("The schemas of a JSON Schema document should be imported with their references rewritten", func() {
+			So(doc.Components.Schemas, ShouldContainKey, "Country")
+			props := doc.Components.Schemas["Address"].Value.Properties
+			So(props["country"].Ref, ShouldEqual, "#/components/schemas/Country")
+			So(props["previous"].Ref, ShouldEqual, "#/components/schemas/Address")
+		})
+
+		Convey("The operations should reference the imported components by name", func() {
+			engine.Post("/prices", func(c *fiber.Ctx) error { return nil }).
+				SetInput(struct {
+					Body struct {
+						Amount int `json:"amount"`
+					} `body:"json"`
+				}{}).
+				UseRequestBodySchema("Money").
+				AddSchemaResponse(http.StatusOK, "Price", "The price.").
+				UseResponse(http.StatusNotFound, "NotFound").
+				OK()
+			op := doc.Paths.Value("/prices").Post
+			So(op.RequestBody.Value.Content.Get("application/json").Schema.Ref, ShouldEqual, "#/components/schemas/Money")
+			ok := op.Responses.Status(http.StatusOK).Value
+			So(*ok.Description, ShouldEqual, "The price.")
+			So(ok.Content.Get("application/json").Schema.Ref, ShouldEqual, "#/components/schemas/Price")
+			So(op.Responses.Status(http.StatusNotFound).Ref, ShouldEqual, "#/components/responses/NotFound")
+			So(func() { engine.Get("/taxes", nil).AddSchemaResponse(http.StatusOK, "Tax") }, ShouldPanicWith,
+				"schema component Tax is not registered")
+		})
+
+		Convey("The components conflicting with existing ones should be rejected", func() {
+			conflicting := write("conflicting.yaml", `
+openapi: 3.0.3
+info: {title: other, version: "1"}
+paths: {}
+components:
+  schemas:
+    Money: {type: string}
+`)
+			err := engine.ImportComponents(conflicting)
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldEqual, "the schema Money of "+conflicting+" conflicts with the one of the engine")
+		})
+
+		Convey("The missing files should be reported", func() {
+			So(engine.ImportComponents(filepath.Join(dir, "missing.yaml")), ShouldNotBeNil)
+		})
+	})
+
+	Convey("Given the imported components in the spec", t, func() {
+		engine := soda.New()
+		So(engine.ImportComponents(components), ShouldBeNil)
+
+		Convey("The spec should still be valid", func() {
+			So(engine.OpenAPI().Components.Validate(openapi3.NewLoader().Context), ShouldBeNil)
+		})
+	})
+}
@@ -0,0 +1,116 @@
+package soda
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+var (
+	ctxType   = reflect.TypeOf((*fiber.Ctx)(nil))
+	errorType = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// provider provides the values of a type to the injected handlers, either a shared value or a value created
+// per request.
+type provider struct {
+	value   reflect.Value
+	request func(c *fiber.Ctx) (reflect.Value, error)
+}
+
+// Provide registers dependencies injected into the handlers of Inject by type.
+// A dependency is either a value, such as an *OrderService shared by every request, or a function of the form
+// func(*fiber.Ctx) (T, error) providing a T per request, such as the current user.
+// A provider replaces the one previously registered for the same type.
+func (e *Engine) Provide(dependencies ...any) *Engine {
+	if e.providers == nil {
+		e.providers = make(map[reflect.Type]provider)
+	}
+	for _, dependency := range dependencies {
+		v := reflect.ValueOf(dependency)
+		t := v.Type()
+		if t.Kind() == reflect.Func && t.NumIn() == 1 && t.In(0) == ctxType && t.NumOut() == 2 && t.Out(1) == errorType {
+			e.providers[t.Out(0)] = provider{request: func(c *fiber.Ctx) (reflect.Value, error) {
+				out := v.Call([]reflect.Value{reflect.ValueOf(c)})
+				if err, _ := out[1].Interface().(error); err != nil {
+					return reflect.Value{}, err
+				}
+				return out[0], nil
+			}}
+			continue
+		}
+		e.providers[t] = provider{value: v}
+	}
+	return e
+}
+
+// Inject appends a handler whose dependencies are resolved at registration, such as
+// func(c *fiber.Ctx, in *In, svc *OrderService) (*Out, error), reducing the global state of the handlers.
+// The first parameter is the context, the others are either the input set by SetInput, which must be called
+// before, or the dependencies registered with Provide. The handler returns an error, optionally preceded by the
+// output, which is sent as JSON and documented as the 200 response unless a 2xx response is already documented.
+// It panics if the handler has another form or a dependency has no provider.
+func (op *OperationBuilder) Inject(handler any) (self *OperationBuilder) {
+	defer op.recoverMisuse(&self)
+	fn := reflect.ValueOf(handler)
+	t := fn.Type()
+	if t.Kind() != reflect.Func || t.NumIn() == 0 || t.In(0) != ctxType ||
+		t.NumOut() == 0 || t.NumOut() > 2 || t.Out(t.NumOut()-1) != errorType {
+		panic(fmt.Sprintf("the injected handler must be of the form func(*fiber.Ctx, ...) ([Out,] error), got %s", t))
+	}
+	args := make([]func(c *fiber.Ctx) (reflect.Value, error), t.NumIn())
+	args[0] = func(c *fiber.Ctx) (reflect.Value, error) { return reflect.ValueOf(c), nil }
+	for i := 1; i < t.NumIn(); i++ {
+		args[i] = op.resolve(t.In(i))
+	}
+	if t.NumOut() == 2 && !op.hasSuccessResponse() {
+		op.AddJSONResponse(http.StatusOK, reflect.Zero(t.Out(0)).Interface())
+	}
+
+	op.handlers = append(op.handlers, func(c *fiber.Ctx) error {
+		in := make([]reflect.Value, len(args))
+		for i, arg := range args {
+			v, err := arg(c)
+			if err != nil {
+				return err
+			}
+			in[i] = v
+		}
+		out := fn.Call(in)
+		if err, _ := out[len(out)-1].Interface().(error); err != nil {
+			return err
+		}
+		if len(out) == 1 {
+			return nil
+		}
+		return c.JSON(out[0].Interface())
+	})
+	return op
+}
+
+// resolve returns the function resolving the values of a parameter of an injected handler.
+func (op *OperationBuilder) resolve(t reflect.Type) func(c *fiber.Ctx) (reflect.Value, error) {
+	if op.input != nil && t == reflect.PointerTo(op.input) {
+		return func(c *fiber.Ctx) (reflect.Value, error) { return reflect.ValueOf(c.Locals(KeyInput)), nil }
+	}
+	p, ok := op.route.engine.providers[t]
+	if !ok {
+		panic(fmt.Sprintf("no provider for the dependency %s of the injected handler of %s %s", t, op.method, op.patternFull))
+	}
+	if p.request != nil {
+		return p.request
+	}
+	return func(*fiber.Ctx) (reflect.Value, error) { return p.value, nil }
+}
+
+// hasSuccessResponse reports whether a 2xx response of the operation is documented.
+func (op *OperationBuilder) hasSuccessResponse() bool {
+	for code := range op.operation.Responses.Map() {
+		if len(code) == 3 && code[0] == '2' {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,94 @@
+package soda_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type orderService struct {
+	prefix string
+}
+
+type injectedUser struct {
+	Name string
+}
+
+type injectedInput struct {
+	ID int `path:"id"`
+}
+
+type injectedOrder struct {
+	ID    string `json:"id"`
+	Owner string `json:"owner"`
+}
+
+func TestInject(t *testing.T) {
+	Convey("Given handlers with injected dependencies", t, func() {
+		engine := soda.New().Provide(
+			&orderService{prefix: "order-"},
+			func(c *fiber.Ctx) (*injectedUser, error) {
+				if c.Get("X-User") == "" {
+					return nil, fiber.ErrUnauthorized
+				}
+				return &injectedUser{Name: c.Get("X-User")}, nil
+			},
+		)
+		engine.Get("/orders/:id").
+			SetInput(injectedInput{}).
+			Inject(func(c *fiber.Ctx, in *injectedInput, svc *orderService, user *injectedUser) (*injectedOrder, error) {
+				if in.ID == 0 {
+					return nil, errors.New("no order")
+				}
+				return &injectedOrder{ID: svc.prefix + c.Params("id"), Owner: user.Name}, nil
+			}).
+			OK()
+		engine.Delete("/orders/:id").
+			Inject(func(c *fiber.Ctx, svc *orderService) error { return c.SendStatus(http.StatusNoContent) }).
+			OK()
+
+		call := func(method, target, user string) (int, string) {
+			req := httptest.NewRequest(method, target, nil)
+			req.Header.Set("X-User", user)
+			resp, err := engine.App().Test(req)
+			So(err, ShouldBeNil)
+			body, _ := io.ReadAll(resp.Body)
+			return resp.StatusCode, string(body)
+		}
+
+		Convey("The dependencies should be injected", func() {
+			status, body := call("GET", "/orders/7", "ada")
+			So(status, ShouldEqual, http.StatusOK)
+			So(body, ShouldEqual, `{"id":"order-7","owner":"ada"}`)
+
+			status, _ = call("DELETE", "/orders/7", "")
+			So(status, ShouldEqual, http.StatusNoContent)
+		})
+
+		Convey("The errors of the providers and the handlers should be returned", func() {
+			status, _ := call("GET", "/orders/7", "")
+			So(status, ShouldEqual, http.StatusUnauthorized)
+			status, _ = call("GET", "/orders/0", "ada")
+			So(status, ShouldEqual, http.StatusInternalServerError)
+		})
+
+		Convey("The output should be documented", func() {
+			response := engine.OpenAPI().Paths.Value("/orders/:id").Get.Responses.Status(http.StatusOK)
+			So(response, ShouldNotBeNil)
+			So(response.Value.Content.Get("application/json").Schema.Ref, ShouldEqual, "#/components/schemas/soda_test.injectedOrder")
+		})
+
+		Convey("Invalid handlers should panic", func() {
+			So(func() { engine.Put("/orders/:id").Inject(func(svc *orderService) error { return nil }) }, ShouldPanic)
+			So(func() { engine.Put("/orders/:id").Inject(func(c *fiber.Ctx) string { return "" }) }, ShouldPanic)
+			So(func() { engine.Put("/orders/:id").Inject(func(c *fiber.Ctx, in *injectedInput) error { return nil }) },
+				ShouldPanicWith, "no provider for the dependency *soda_test.injectedInput of the injected handler of PUT /orders/:id")
+		})
+	})
+}
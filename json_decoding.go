@@ -0,0 +1,100 @@
+package soda
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// JSONDecoding is the set of options of the decoding of JSON request bodies, which otherwise relies on the JSON
+// decoder of the fiber app.
+type JSONDecoding struct {
+	// UseNumber decodes the numbers into interface values as json.Number rather than float64,
+	// so that large integers don't lose precision.
+	UseNumber bool
+	// DisallowUnknownFields rejects the bodies with fields unknown to the body type.
+	DisallowUnknownFields bool
+	// MaxDepth rejects the bodies whose objects and arrays are nested deeper, if positive.
+	MaxDepth int
+}
+
+// SetJSONDecoding sets the options of the decoding of the JSON request bodies of the operations,
+// unless overridden by the operation.
+func (e *Engine) SetJSONDecoding(decoding JSONDecoding) *Engine {
+	e.jsonDecoding = &decoding
+	return e
+}
+
+// SetJSONDecoding sets the options of the decoding of the JSON request body of the operation.
+func (op *OperationBuilder) SetJSONDecoding(decoding JSONDecoding) *OperationBuilder {
+	op.jsonDecoding = &decoding
+	return op
+}
+
+// jsonDecodingOf returns the options of the decoding of the JSON request body of the operation, if any.
+func (op *OperationBuilder) jsonDecodingOf() *JSONDecoding {
+	if !isJSONMediaType(requestMediaType(op.inputBodyMediaType)) {
+		return nil
+	}
+	if op.jsonDecoding != nil {
+		return op.jsonDecoding
+	}
+	if op.route.engine.jsonDecoding == nil && op.route.gen.naming != nil {
+		// The JSON decoder of the app doesn't expect the properties named with the strategy.
+		return &JSONDecoding{}
+	}
+	return op.route.engine.jsonDecoding
+}
+
+// isJSONMediaType reports whether the media type is JSON, including the structured syntax suffix, e.g.
+// application/merge-patch+json.
+func isJSONMediaType(mediaType string) bool {
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// decodeJSON decodes the JSON data into the value with the options, expecting the properties of the fields without
+// a json name to be named with the strategy.
+func decodeJSON(data []byte, v any, decoding *JSONDecoding, naming NamingStrategy) error {
+	if decoding.MaxDepth > 0 {
+		if depth := jsonDepth(data); depth > decoding.MaxDepth {
+			return fmt.Errorf("json: nesting depth %d exceeds the maximum of %d", depth, decoding.MaxDepth)
+		}
+	}
+	return naming.decode(data, v, decoding)
+}
+
+// unmarshal decodes the JSON data into the value with the options.
+func (decoding *JSONDecoding) unmarshal(data []byte, v any) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if decoding.UseNumber {
+		decoder.UseNumber()
+	}
+	if decoding.DisallowUnknownFields {
+		decoder.DisallowUnknownFields()
+	}
+	return decoder.Decode(v)
+}
+
+// jsonDepth returns the maximum nesting depth of the objects and arrays of the JSON data.
+func jsonDepth(data []byte) int {
+	depth, maxDepth := 0, 0
+	inString, escaped := false, false
+	for _, b := range data {
+		switch {
+		case escaped:
+			escaped = false
+		case inString && b == '\\':
+			escaped = true
+		case b == '"':
+			inString = !inString
+		case inString:
+		case b == '{' || b == '[':
+			depth++
+			maxDepth = max(maxDepth, depth)
+		case b == '}' || b == ']':
+			depth--
+		}
+	}
+	return maxDepth
+}
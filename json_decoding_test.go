@@ -0,0 +1,70 @@
+package soda_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestJSONDecoding(t *testing.T) {
+	type input struct {
+		Body struct {
+			Name  string `json:"name"`
+			Extra any    `json:"extra"`
+		} `body:"json"`
+	}
+	handler := func(c *fiber.Ctx) error {
+		extra := soda.GetInput[input](c).Body.Extra
+		if n, ok := extra.(json.Number); ok {
+			return c.SendString("number:" + n.String())
+		}
+		return c.JSON(extra)
+	}
+	post := func(engine *soda.Engine, target, body string) (int, string) {
+		req, _ := http.NewRequest("POST", target, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := engine.App().Test(req)
+		So(err, ShouldBeNil)
+		data, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, string(data)
+	}
+
+	Convey("Given the JSON decoding options of the engine", t, func() {
+		engine := soda.New().SetBindErrorStatus(http.StatusBadRequest).SetJSONDecoding(soda.JSONDecoding{UseNumber: true, DisallowUnknownFields: true, MaxDepth: 3})
+		engine.Post("/strict", handler).SetInput(input{}).OK()
+		engine.Post("/lenient", handler).SetInput(input{}).SetJSONDecoding(soda.JSONDecoding{}).OK()
+
+		Convey("Numbers should keep their precision", func() {
+			status, body := post(engine, "/strict", `{"name":"a","extra":9007199254740993}`)
+			So(status, ShouldEqual, http.StatusOK)
+			So(body, ShouldEqual, "number:9007199254740993")
+		})
+
+		Convey("Unknown fields should be rejected", func() {
+			status, body := post(engine, "/strict", `{"name":"a","unknown":1}`)
+			So(status, ShouldEqual, http.StatusBadRequest)
+			So(body, ShouldContainSubstring, "unknown field")
+		})
+
+		Convey("Deeply nested bodies should be rejected", func() {
+			status, _ := post(engine, "/strict", `{"name":"a","extra":[[1]]}`)
+			So(status, ShouldEqual, http.StatusOK)
+			status, body := post(engine, "/strict", `{"name":"a","extra":[[{"a":"]]]"}]]}`)
+			So(status, ShouldEqual, http.StatusBadRequest)
+			So(body, ShouldContainSubstring, "depth 4")
+		})
+
+		Convey("The options of the operation should override those of the engine", func() {
+			status, _ := post(engine, "/lenient", `{"name":"a","unknown":1}`)
+			So(status, ShouldEqual, http.StatusOK)
+			_, body := post(engine, "/lenient", `{"name":"a","extra":1}`)
+			So(body, ShouldEqual, "1")
+		})
+	})
+}
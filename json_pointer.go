@@ -0,0 +1,50 @@
+package soda
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+)
+
+// jsonTypeMismatch returns the JSON pointer of the value of the JSON document which doesn't match the type of the
+// field it is decoded into, with the JSON types expected and received, if the error is such a mismatch.
+func jsonTypeMismatch(err error) (pointer, expected, actual string, ok bool) {
+	var typeErr *json.UnmarshalTypeError
+	if !errors.As(err, &typeErr) {
+		return "", "", "", false
+	}
+	// The path of the field is dotted, with its tokens escaped as in JSON pointers by the recent versions of Go.
+	if typeErr.Field != "" {
+		pointer = "/" + strings.ReplaceAll(typeErr.Field, ".", "/")
+	}
+	return pointer, jsonTypeName(typeErr.Type), typeErr.Value, true
+}
+
+// jsonTypeName returns the name of the JSON type values of the Go type are decoded from.
+func jsonTypeName(t reflect.Type) string {
+	if t == nil {
+		return "value"
+	}
+	t = derefType(t)
+	switch {
+	case t.Kind() == reflect.Bool:
+		return "boolean"
+	case t.Kind() >= reflect.Int && t.Kind() <= reflect.Uintptr:
+		return "integer"
+	case t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64:
+		return "number"
+	case t.Kind() == reflect.String:
+		return "string"
+	case t.Kind() == reflect.Slice || t.Kind() == reflect.Array:
+		return "array"
+	case t.Kind() == reflect.Map || t.Kind() == reflect.Struct:
+		return "object"
+	}
+	return "value"
+}
+
+// escapePointer escapes a reference token of a JSON pointer, see RFC 6901.
+func escapePointer(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
@@ -0,0 +1,65 @@
+package soda_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestJSONPointerErrors(t *testing.T) {
+	Convey("Given a JSON body with nested fields", t, func() {
+		type orderItem struct {
+			Count int `json:"count"`
+		}
+		type input struct {
+			Body struct {
+				Name   string          `json:"name"`
+				Items  []orderItem     `json:"items"`
+				Labels map[string]bool `json:"labels"`
+			} `body:"json"`
+		}
+		engine := soda.New().SetBindErrorStatus(http.StatusBadRequest)
+		engine.Post("/orders", func(c *fiber.Ctx) error { return nil }).SetInput(input{}).OK()
+
+		post := func(body string) (int, soda.HTTPError) {
+			req, _ := http.NewRequest("POST", "/orders", strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := engine.App().Test(req)
+			So(err, ShouldBeNil)
+			data, _ := io.ReadAll(resp.Body)
+			var httpErr soda.HTTPError
+			So(json.Unmarshal(data, &httpErr), ShouldBeNil)
+			return resp.StatusCode, httpErr
+		}
+
+		Convey("Type mismatches should be located by JSON pointers", func() {
+			status, httpErr := post(`{"name":"a","items":[{"count":1},{"count":"two"}]}`)
+			So(status, ShouldEqual, http.StatusBadRequest)
+			So(httpErr.Code, ShouldEqual, soda.MsgInvalidBodyType)
+			So(httpErr.Pointer, ShouldEqual, "/items/1/count")
+			So(httpErr.Expected, ShouldEqual, "integer")
+			So(httpErr.Message, ShouldEqual, "invalid request body: /items/1/count must be integer, not string")
+
+			_, httpErr = post(`{"name":1}`)
+			So(httpErr.Pointer, ShouldEqual, "/name")
+			So(httpErr.Expected, ShouldEqual, "string")
+
+			_, httpErr = post(`{"labels":{"urgent":"yes"}}`)
+			So(httpErr.Pointer, ShouldEqual, "/labels/urgent")
+			So(httpErr.Expected, ShouldEqual, "boolean")
+		})
+
+		Convey("Other errors should be reported as before", func() {
+			status, httpErr := post(`{"name":`)
+			So(status, ShouldEqual, http.StatusBadRequest)
+			So(httpErr.Code, ShouldEqual, soda.MsgInvalidBody)
+			So(httpErr.Pointer, ShouldBeEmpty)
+		})
+	})
+}
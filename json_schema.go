@@ -0,0 +1,160 @@
+package soda
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+)
+
+// jsonSchemaDialect is the dialect of the standalone JSON Schema documents.
+const jsonSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// SchemaFor returns the schema of the model as a standalone JSON Schema document, e.g. to validate messages or
+// generate forms outside HTTP. The schemas it refers to are embedded under $defs, and the OpenAPI 3.0 keywords
+// are converted to their JSON Schema equivalents.
+func (e *Engine) SchemaFor(model any) ([]byte, error) {
+	gen := NewGenerator()
+	root := gen.generateSchemaRef(nil, reflect.TypeOf(model), "json")
+	return standaloneSchema(root, gen.doc.Components.Schemas)
+}
+
+// ServeSchemas serves the component schemas of the spec as standalone JSON Schema documents under the prefix,
+// e.g. /schemas/User.json.
+func (e *Engine) ServeSchemas(prefix string) *Engine {
+	e.serveDoc(strings.TrimSuffix(prefix, "/")+"/*", func(c *fiber.Ctx) error {
+		name, ok := strings.CutSuffix(c.Params("*"), ".json")
+		if !ok {
+			return fiber.ErrNotFound
+		}
+		e.specMu.Lock()
+		schema, ok := e.gen.doc.Components.Schemas[name]
+		var data []byte
+		var err error
+		if ok {
+			data, err = standaloneSchema(openapi3.NewSchemaRef(componentSchemaRef+name, schema.Value), e.gen.doc.Components.Schemas)
+		}
+		e.specMu.Unlock()
+		if !ok {
+			return fiber.ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+		c.Context().SetContentType("application/schema+json")
+		return c.Send(data)
+	})
+	return e
+}
+
+// componentSchemaRef is the prefix of the references to the component schemas.
+const componentSchemaRef = "#/components/schemas/"
+
+// standaloneSchema marshals the schema as a JSON Schema document embedding the component schemas it refers to.
+func standaloneSchema(root *openapi3.SchemaRef, schemas openapi3.Schemas) ([]byte, error) {
+	rootName := strings.TrimPrefix(root.Ref, componentSchemaRef)
+	doc, err := toJSONValue(root.Value)
+	if err != nil {
+		return nil, err
+	}
+	defs := make(map[string]any)
+	var queue []any
+	queue = append(queue, doc)
+	for len(queue) != 0 {
+		v := queue[0]
+		queue = queue[1:]
+		var refs []string
+		convertSchema(v, &refs)
+		for _, name := range refs {
+			if _, ok := defs[name]; ok {
+				continue
+			}
+			schema, ok := schemas[name]
+			if !ok || schema.Value == nil {
+				continue
+			}
+			def, err := toJSONValue(schema.Value)
+			if err != nil {
+				return nil, err
+			}
+			defs[name] = def
+			queue = append(queue, def)
+		}
+	}
+	out := doc.(map[string]any)
+	out["$schema"] = jsonSchemaDialect
+	if _, ok := out["title"]; !ok && rootName != "" {
+		out["title"] = rootName
+	}
+	if len(defs) != 0 {
+		out["$defs"] = defs
+	}
+	return json.Marshal(out)
+}
+
+// toJSONValue decodes the schema marshaled as JSON into generic values.
+func toJSONValue(schema *openapi3.Schema) (any, error) {
+	data, err := json.Marshal(schema)
+	if err != nil {
+		return nil, err
+	}
+	var v any
+	err = json.Unmarshal(data, &v)
+	return v, err
+}
+
+// convertSchema converts the OpenAPI 3.0 keywords of the decoded schema to JSON Schema in place: the references
+// point to $defs, nullable becomes a null type, the boolean exclusive bounds become numbers and example becomes
+// examples. The names of the component schemas referred to are appended to refs.
+func convertSchema(v any, refs *[]string) {
+	schema, ok := v.(map[string]any)
+	if !ok {
+		return
+	}
+	if ref, ok := schema["$ref"].(string); ok && strings.HasPrefix(ref, componentSchemaRef) {
+		name := strings.TrimPrefix(ref, componentSchemaRef)
+		schema["$ref"] = "#/$defs/" + name
+		*refs = append(*refs, name)
+	}
+	if nullable, _ := schema["nullable"].(bool); nullable {
+		if typ, ok := schema["type"].(string); ok {
+			schema["type"] = []any{typ, "null"}
+		}
+	}
+	delete(schema, "nullable")
+	convertExclusiveBound(schema, "exclusiveMinimum", "minimum")
+	convertExclusiveBound(schema, "exclusiveMaximum", "maximum")
+	if example, ok := schema["example"]; ok {
+		schema["examples"] = []any{example}
+		delete(schema, "example")
+	}
+	for _, key := range []string{"items", "additionalProperties", "not"} {
+		convertSchema(schema[key], refs)
+	}
+	for _, key := range []string{"allOf", "anyOf", "oneOf"} {
+		items, _ := schema[key].([]any)
+		for _, item := range items {
+			convertSchema(item, refs)
+		}
+	}
+	properties, _ := schema["properties"].(map[string]any)
+	for _, name := range sortedKeys(properties) {
+		convertSchema(properties[name], refs)
+	}
+}
+
+// convertExclusiveBound converts the boolean exclusive bound of OpenAPI 3.0 to the numeric one of JSON Schema.
+func convertExclusiveBound(schema map[string]any, exclusive, bound string) {
+	flag, ok := schema[exclusive].(bool)
+	if !ok {
+		return
+	}
+	if flag && schema[bound] != nil {
+		schema[exclusive] = schema[bound]
+		delete(schema, bound)
+		return
+	}
+	delete(schema, exclusive)
+}
@@ -0,0 +1,71 @@
+package soda_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type schemaTag struct {
+	Label string `json:"label"`
+}
+
+type schemaArticle struct {
+	Title    string      `json:"title"    oai:"example=Hello"`
+	Subtitle *string     `json:"subtitle" oai:"nullable"`
+	Rating   float64     `json:"rating"   oai:"minimum=0;exclusiveMinimum"`
+	Tags     []schemaTag `json:"tags"`
+}
+
+func TestJSONSchemaExport(t *testing.T) {
+	Convey("Given a model", t, func() {
+		engine := soda.New()
+
+		Convey("SchemaFor should emit a standalone JSON Schema document", func() {
+			data, err := engine.SchemaFor(schemaArticle{})
+			So(err, ShouldBeNil)
+			var doc map[string]any
+			So(json.Unmarshal(data, &doc), ShouldBeNil)
+			So(doc["$schema"], ShouldEqual, "https://json-schema.org/draft/2020-12/schema")
+			So(doc["title"], ShouldEqual, "soda_test.schemaArticle")
+
+			properties := doc["properties"].(map[string]any)
+			title := properties["title"].(map[string]any)
+			So(title["examples"], ShouldResemble, []any{"Hello"})
+			So(title, ShouldNotContainKey, "example")
+			subtitle := properties["subtitle"].(map[string]any)
+			So(subtitle["type"], ShouldResemble, []any{"string", "null"})
+			So(subtitle, ShouldNotContainKey, "nullable")
+			rating := properties["rating"].(map[string]any)
+			So(rating["exclusiveMinimum"], ShouldEqual, 0)
+			So(rating, ShouldNotContainKey, "minimum")
+
+			items := properties["tags"].(map[string]any)["items"].(map[string]any)
+			So(items["$ref"], ShouldEqual, "#/$defs/soda_test.schemaTag")
+			So(doc["$defs"], ShouldContainKey, "soda_test.schemaTag")
+		})
+
+		Convey("The component schemas should be served", func() {
+			engine.Get("/articles", func(c *fiber.Ctx) error { return nil }).
+				AddJSONResponse(http.StatusOK, schemaArticle{}).OK()
+			engine.ServeSchemas("/schemas")
+
+			resp, err := engine.App().Test(httptest.NewRequest("GET", "/schemas/soda_test.schemaArticle.json", nil))
+			So(err, ShouldBeNil)
+			So(resp.StatusCode, ShouldEqual, http.StatusOK)
+			So(resp.Header.Get("Content-Type"), ShouldEqual, "application/schema+json")
+			data, _ := io.ReadAll(resp.Body)
+			So(string(data), ShouldContainSubstring, `"$ref":"#/$defs/soda_test.schemaTag"`)
+
+			resp, err = engine.App().Test(httptest.NewRequest("GET", "/schemas/Unknown.json", nil))
+			So(err, ShouldBeNil)
+			So(resp.StatusCode, ShouldEqual, http.StatusNotFound)
+		})
+	})
+}
@@ -0,0 +1,69 @@
+package soda
+
+import (
+	"context"
+	"errors"
+	"net"
+)
+
+// LifecycleHook is run when the engine starts or shuts down, e.g. to flush the audit logs, close the idempotency
+// store or persist the spec.
+type LifecycleHook func(ctx context.Context) error
+
+// OnStart adds hooks run in order by Listen and Serve before serving, which aborts the start on the first error.
+func (e *Engine) OnStart(hooks ...LifecycleHook) *Engine {
+	e.startHooks = append(e.startHooks, hooks...)
+	return e
+}
+
+// OnShutdown adds hooks run by Shutdown once the app stopped serving, in the reverse order of their addition,
+// so that the resources are released in the reverse order of their acquisition.
+func (e *Engine) OnShutdown(hooks ...LifecycleHook) *Engine {
+	e.shutdownHooks = append(e.shutdownHooks, hooks...)
+	return e
+}
+
+// Listen runs the start hooks, then serves the app on the address until Shutdown.
+func (e *Engine) Listen(addr string) error {
+	if err := e.start(); err != nil {
+		return err
+	}
+	return e.app.Listen(addr)
+}
+
+// Serve runs the start hooks, then serves the app on the listener until Shutdown.
+func (e *Engine) Serve(ln net.Listener) error {
+	if err := e.start(); err != nil {
+		return err
+	}
+	return e.app.Listener(ln)
+}
+
+// start lints the spec if enabled and not frozen yet, then runs the start hooks.
+func (e *Engine) start() error {
+	if err := e.lintSpec(); err != nil {
+		return err
+	}
+	ctx := context.Background()
+	for _, hook := range e.startHooks {
+		if err := hook(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Shutdown gracefully stops serving, waiting for the requests in flight until the context is done, then runs the
+// shutdown hooks with the context, even if the app didn't stop in time. The hooks only run once, and all of them
+// run even if some fail, their errors being joined to that of the app.
+func (e *Engine) Shutdown(ctx context.Context) error {
+	err := e.app.ShutdownWithContext(ctx)
+	e.shutdownOnce.Do(func() {
+		errs := []error{err}
+		for i := len(e.shutdownHooks) - 1; i >= 0; i-- {
+			errs = append(errs, e.shutdownHooks[i](ctx))
+		}
+		err = errors.Join(errs...)
+	})
+	return err
+}
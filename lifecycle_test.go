@@ -0,0 +1,67 @@
+package soda_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestLifecycle(t *testing.T) {
+	Convey("Given an engine with lifecycle hooks", t, func() {
+		engine := soda.New()
+		engine.Get("/ping", func(c *fiber.Ctx) error { return c.SendString("pong") }).OK()
+		var events []string
+		hook := func(event string, err error) soda.LifecycleHook {
+			return func(context.Context) error {
+				events = append(events, event)
+				return err
+			}
+		}
+
+		Convey("The hooks should run around serving", func() {
+			engine.OnStart(hook("open store", nil), hook("load spec", nil))
+			engine.OnShutdown(hook("close store", nil), hook("flush audit", errors.New("audit sink down")))
+			ln, err := net.Listen("tcp", "127.0.0.1:0")
+			So(err, ShouldBeNil)
+			served := make(chan error, 1)
+			go func() { served <- engine.Serve(ln) }()
+
+			var resp *http.Response
+			for i := 0; i < 50; i++ {
+				if resp, err = http.Get("http://" + ln.Addr().String() + "/ping"); err == nil {
+					break
+				}
+				time.Sleep(10 * time.Millisecond)
+			}
+			So(err, ShouldBeNil)
+			body, _ := io.ReadAll(resp.Body)
+			_ = resp.Body.Close()
+			So(string(body), ShouldEqual, "pong")
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			err = engine.Shutdown(ctx)
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldEqual, "audit sink down")
+			So(<-served, ShouldBeNil)
+			So(events, ShouldResemble, []string{"open store", "load spec", "flush audit", "close store"})
+
+			So(engine.Shutdown(ctx), ShouldBeNil)
+			So(events, ShouldHaveLength, 4)
+		})
+
+		Convey("A failing start hook should abort the start", func() {
+			engine.OnStart(hook("migrate", errors.New("migration failed")), hook("never", nil))
+			So(engine.Listen("127.0.0.1:0"), ShouldBeError, "migration failed")
+			So(events, ShouldResemble, []string{"migrate"})
+		})
+	})
+}
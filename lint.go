@@ -0,0 +1,190 @@
+package soda
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// LintRule is a rule the documented operations are checked against by Lint.
+type LintRule struct {
+	Name string
+	// Check returns the messages of the violations of the rule by the operation.
+	Check func(method, path string, op *openapi3.Operation) []string
+}
+
+// LintViolation is a violation of a LintRule by an operation.
+type LintViolation struct {
+	Rule    string
+	Method  string
+	Path    string
+	Message string
+}
+
+func (v LintViolation) String() string {
+	return fmt.Sprintf("%s %s: %s (%s)", v.Method, v.Path, v.Message, v.Rule)
+}
+
+var regexKebabCase = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+var (
+	// LintOperationDescription requires operations to have a description.
+	LintOperationDescription = LintRule{
+		Name: "operation-description",
+		Check: func(_, _ string, op *openapi3.Operation) []string {
+			if op.Description == "" {
+				return []string{"operation has no description"}
+			}
+			return nil
+		},
+	}
+
+	// LintParameterDescription requires parameters to have a description.
+	LintParameterDescription = LintRule{
+		Name: "parameter-description",
+		Check: func(_, _ string, op *openapi3.Operation) []string {
+			var messages []string
+			for _, param := range op.Parameters {
+				if param.Value != nil && param.Value.Description == "" {
+					messages = append(messages, fmt.Sprintf("%s parameter %q has no description", param.Value.In, param.Value.Name))
+				}
+			}
+			return messages
+		},
+	}
+
+	// LintErrorResponse requires operations to document at least one 4xx response.
+	LintErrorResponse = LintRule{
+		Name: "operation-4xx-response",
+		Check: func(_, _ string, op *openapi3.Operation) []string {
+			for code := range op.Responses.Map() {
+				if strings.HasPrefix(code, "4") {
+					return nil
+				}
+			}
+			return []string{"operation has no 4xx response"}
+		},
+	}
+
+	// LintOperationTags requires operations to be tagged.
+	LintOperationTags = LintRule{
+		Name: "operation-tags",
+		Check: func(_, _ string, op *openapi3.Operation) []string {
+			if len(op.Tags) == 0 {
+				return []string{"operation has no tags"}
+			}
+			return nil
+		},
+	}
+
+	// LintKebabCasePath requires the static segments of the paths, unlike the parameters, to be kebab-case.
+	LintKebabCasePath = LintRule{
+		Name: "path-kebab-case",
+		Check: func(_, path string, _ *openapi3.Operation) []string {
+			for _, segment := range strings.Split(strings.Trim(path, "/"), "/") {
+				if segment != "" && !strings.ContainsAny(segment[:1], "{:") && !regexKebabCase.MatchString(segment) {
+					return []string{fmt.Sprintf("path segment %q is not kebab-case", segment)}
+				}
+			}
+			return nil
+		},
+	}
+)
+
+// DefaultLintRules are the rules Lint checks when none are given.
+var DefaultLintRules = []LintRule{
+	LintOperationDescription,
+	LintParameterDescription,
+	LintErrorResponse,
+	LintOperationTags,
+	LintKebabCasePath,
+}
+
+// Lint checks the documented operations against the rules, DefaultLintRules if none are given,
+// and returns the violations sorted by path and method.
+// It is meant to be called once all the operations are registered.
+func (e *Engine) Lint(rules ...LintRule) []LintViolation {
+	if len(rules) == 0 {
+		rules = DefaultLintRules
+	}
+	var violations []LintViolation
+	paths := e.gen.doc.Paths.Map()
+	for _, path := range sortedKeys(paths) {
+		operations := paths[path].Operations()
+		for _, method := range sortedKeys(operations) {
+			for _, rule := range rules {
+				for _, message := range rule.Check(method, path, operations[method]) {
+					violations = append(violations, LintViolation{Rule: rule.Name, Method: method, Path: path, Message: message})
+				}
+			}
+		}
+	}
+	return violations
+}
+
+// MustLint is like Lint but panics listing the violations if any, to fail the startup in strict mode.
+func (e *Engine) MustLint(rules ...LintRule) *Engine {
+	if err := lintError(e.Lint(rules...)); err != nil {
+		panic(err.Error())
+	}
+	return e
+}
+
+// LintConfig configures the lint of the spec when it is finalized.
+type LintConfig struct {
+	// Rules are the rules checked, DefaultLintRules if none.
+	Rules []LintRule
+	// Strict fails the finalization on violations rather than logging them.
+	Strict bool
+	// Logger logs the violations, slog.Default() if nil.
+	Logger *slog.Logger
+}
+
+// EnableLint lints the spec when it is finalized, by Freeze or else when the engine starts, logging a warning for
+// each violation, or failing with them in strict mode: Freeze panics and Listen and Serve return the error.
+func (e *Engine) EnableLint(cfg LintConfig) *Engine {
+	e.lintConfig = &cfg
+	return e
+}
+
+// lintSpec lints the finalized spec once, if enabled, and returns the error listing the violations in strict mode.
+func (e *Engine) lintSpec() error {
+	cfg := e.lintConfig
+	if cfg == nil || e.linted {
+		return nil
+	}
+	e.linted = true
+	violations := e.Lint(cfg.Rules...)
+	if cfg.Strict {
+		return lintError(violations)
+	}
+	logger := cfg.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	for _, v := range violations {
+		logger.Warn("spec lint violation",
+			slog.String("rule", v.Rule),
+			slog.String("method", v.Method),
+			slog.String("path", v.Path),
+			slog.String("message", v.Message),
+		)
+	}
+	return nil
+}
+
+// lintError returns the error listing the violations, if any.
+func lintError(violations []LintViolation) error {
+	if len(violations) == 0 {
+		return nil
+	}
+	messages := make([]string, len(violations))
+	for i, v := range violations {
+		messages[i] = v.String()
+	}
+	return errors.New("spec lint failed:\n" + strings.Join(messages, "\n"))
+}
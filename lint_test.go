@@ -0,0 +1,72 @@
+package soda_test
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestLint(t *testing.T) {
+	Convey("Given an engine with operations", t, func() {
+		engine := soda.New()
+		type input struct {
+			ID string `path:"id" oai:"description=the user id"`
+		}
+		handler := func(c *fiber.Ctx) error { return nil }
+		engine.Get("/users/:id", handler).
+			SetInput(&input{}).
+			SetDescription("Get a user").
+			AddTags("users").
+			AddJSONResponse(404, nil).
+			OK()
+		engine.Get("/userProfiles", handler).OK()
+
+		Convey("Lint should report the violations of the default rules", func() {
+			violations := engine.Lint()
+			So(violations, ShouldHaveLength, 4)
+			rules := make([]string, len(violations))
+			for i, v := range violations {
+				So(v.Path, ShouldEqual, "/userProfiles")
+				rules[i] = v.Rule
+			}
+			So(rules, ShouldResemble, []string{"operation-description", "operation-4xx-response", "operation-tags", "path-kebab-case"})
+			So(violations[3].String(), ShouldEqual, `GET /userProfiles: path segment "userProfiles" is not kebab-case (path-kebab-case)`)
+		})
+
+		Convey("Lint should only check the given rules", func() {
+			So(engine.Lint(soda.LintOperationTags), ShouldHaveLength, 1)
+			So(engine.Lint(soda.LintParameterDescription), ShouldBeEmpty)
+		})
+
+		Convey("MustLint should panic on violations", func() {
+			So(func() { engine.MustLint() }, ShouldPanic)
+			So(func() { engine.MustLint(soda.LintParameterDescription) }, ShouldNotPanic)
+		})
+
+		Convey("The violations should be logged when the spec is finalized", func() {
+			var logs bytes.Buffer
+			engine.EnableLint(soda.LintConfig{
+				Rules:  []soda.LintRule{soda.LintKebabCasePath},
+				Logger: slog.New(slog.NewJSONHandler(&logs, nil)),
+			})
+			engine.Freeze()
+			So(logs.String(), ShouldContainSubstring, `"msg":"spec lint violation","rule":"path-kebab-case","method":"GET","path":"/userProfiles"`)
+			So(engine.Listen("127.0.0.1:-1"), ShouldNotBeNil)
+			So(bytes.Count(logs.Bytes(), []byte("spec lint violation")), ShouldEqual, 1)
+		})
+
+		Convey("The violations should fail the finalization in strict mode", func() {
+			engine.EnableLint(soda.LintConfig{Rules: []soda.LintRule{soda.LintOperationTags}, Strict: true})
+			So(func() { engine.Freeze() }, ShouldPanicWith, "spec lint failed:\nGET /userProfiles: operation has no tags (operation-tags)")
+		})
+
+		Convey("The violations should fail the start in strict mode", func() {
+			engine.EnableLint(soda.LintConfig{Rules: []soda.LintRule{soda.LintOperationTags}, Strict: true})
+			So(engine.Listen("127.0.0.1:0"), ShouldBeError, "spec lint failed:\nGET /userProfiles: operation has no tags (operation-tags)")
+		})
+	})
+}
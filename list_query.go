@@ -0,0 +1,128 @@
+package soda
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Default and maximum page sizes of the ListQuery inputs.
+var (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+// ListQuery is the input component of the list operations, embedded in their input to share the binding and the
+// documentation of the pagination, sort and filter query parameters, e.g.
+// ?page=2&page_size=50&sort=-created_at,name&filter=status:active&filter=age:gte:18.
+// Its helpers translate it for a datastore against the allow-list of the columns the clients may use.
+type ListQuery struct {
+	Page     int      `query:"page"      oai:"description=The page to list, from 1;minimum=1;required=false"`
+	PageSize int      `query:"page_size" oai:"description=The number of items per page;minimum=1;required=false"`
+	Sort     []string `query:"sort"      oai:"description=The fields to sort by, descending when prefixed with -, e.g. -created_at,name;explode=false;required=false"`
+	Filter   []string `query:"filter"    oai:"description=The filters of the fields, as field:value or field:operator:value, e.g. age:gte:18;required=false"`
+}
+
+// Columns allow-lists the fields of a ListQuery, mapping them to the columns of the datastore.
+type Columns map[string]string
+
+// SortColumn is a column of the order of a ListQuery.
+type SortColumn struct {
+	Column string
+	Desc   bool
+}
+
+// FilterOperators are the operators of the filters of a ListQuery.
+var FilterOperators = []string{"eq", "ne", "lt", "lte", "gt", "gte", "like", "in"}
+
+// FilterColumn is a filter of a ListQuery on a column, whose operator is eq unless given. The values of the in
+// operator are separated by |.
+type FilterColumn struct {
+	Column   string
+	Operator string
+	Value    string
+}
+
+// OffsetLimit returns the offset and the limit of the page, the page size defaulting to DefaultPageSize and being
+// capped to MaxPageSize.
+func (q ListQuery) OffsetLimit() (offset, limit int) {
+	page, limit := max(q.Page, 1), q.PageSize
+	if limit <= 0 {
+		limit = DefaultPageSize
+	}
+	limit = min(limit, MaxPageSize)
+	return (page - 1) * limit, limit
+}
+
+// OrderBy returns the columns of the order, responding 400 if a field isn't allow-listed.
+func (q ListQuery) OrderBy(columns Columns) ([]SortColumn, error) {
+	var order []SortColumn
+	for _, sort := range q.Sort {
+		for _, field := range strings.Split(sort, ",") {
+			if field = strings.TrimSpace(field); field == "" {
+				continue
+			}
+			name := strings.TrimPrefix(field, "-")
+			column, ok := columns[name]
+			if !ok {
+				return nil, listQueryError("the field %s can't be sorted by", name)
+			}
+			order = append(order, SortColumn{Column: column, Desc: name != field})
+		}
+	}
+	return order, nil
+}
+
+// Filters returns the filters on the columns, responding 400 if a field isn't allow-listed or an operator unknown.
+func (q ListQuery) Filters(columns Columns) ([]FilterColumn, error) {
+	filters := make([]FilterColumn, 0, len(q.Filter))
+	for _, filter := range q.Filter {
+		name, rest, ok := strings.Cut(filter, ":")
+		if !ok {
+			return nil, listQueryError("the filter %s must be field:value or field:operator:value", filter)
+		}
+		column, ok := columns[name]
+		if !ok {
+			return nil, listQueryError("the field %s can't be filtered by", name)
+		}
+		operator, value := "eq", rest
+		if op, v, ok := strings.Cut(rest, ":"); ok && slices.Contains(FilterOperators, op) {
+			operator, value = op, v
+		}
+		filters = append(filters, FilterColumn{Column: column, Operator: operator, Value: value})
+	}
+	return filters, nil
+}
+
+// listQueryError returns the 400 HTTPError of an invalid ListQuery.
+func listQueryError(format string, args ...any) error {
+	return NewError(http.StatusBadRequest, MsgInvalidQuery, fmt.Sprintf(format, args...))
+}
+
+// SetListColumns documents the fields allow-listed for the sort and the filter query parameters of the ListQuery
+// of the input, which must be set before.
+func (op *OperationBuilder) SetListColumns(sortable, filterable Columns) *OperationBuilder {
+	if param := op.operation.Parameters.GetByInAndName(openapi3.ParameterInQuery, "sort"); param != nil && len(sortable) != 0 {
+		var fields []any
+		for _, field := range sortedKeys(sortable) {
+			fields = append(fields, field, "-"+field)
+		}
+		schema := *param.Schema.Value
+		schema.Items = openapi3.NewStringSchema().WithEnum(fields...).NewRef()
+		param.Schema = schema.NewRef()
+	}
+	if param := op.operation.Parameters.GetByInAndName(openapi3.ParameterInQuery, "filter"); param != nil && len(filterable) != 0 {
+		names := make([]string, 0, len(filterable))
+		for _, field := range sortedKeys(filterable) {
+			names = append(names, regexp.QuoteMeta(field))
+		}
+		schema := *param.Schema.Value
+		schema.Items = openapi3.NewStringSchema().WithPattern("^(" + strings.Join(names, "|") + "):").NewRef()
+		param.Schema = schema.NewRef()
+	}
+	return op
+}
@@ -0,0 +1,82 @@
+package soda_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestListQuery(t *testing.T) {
+	Convey("Given a list operation embedding a ListQuery in its input", t, func() {
+		type input struct {
+			soda.ListQuery
+			Archived bool `query:"archived"`
+		}
+		columns := soda.Columns{"name": "u.name", "created_at": "u.created_at", "age": "u.age"}
+		var got *input
+		engine := soda.New()
+		engine.Get("/users", func(c *fiber.Ctx) error {
+			got = soda.GetInput[input](c)
+			return nil
+		}).SetInput(&input{}).SetListColumns(columns, soda.Columns{"age": "u.age"}).OK()
+		get := func(query string) *input {
+			resp, err := engine.App().Test(httptest.NewRequest("GET", "/users?"+query, nil))
+			So(err, ShouldBeNil)
+			So(resp.StatusCode, ShouldEqual, http.StatusOK)
+			return got
+		}
+
+		Convey("The page should translate into an offset and a limit", func() {
+			offset, limit := get("page=3&page_size=10").OffsetLimit()
+			So(offset, ShouldEqual, 20)
+			So(limit, ShouldEqual, 10)
+
+			offset, limit = get("page_size=1000").OffsetLimit()
+			So(offset, ShouldEqual, 0)
+			So(limit, ShouldEqual, soda.MaxPageSize)
+
+			_, limit = get("").OffsetLimit()
+			So(limit, ShouldEqual, soda.DefaultPageSize)
+		})
+
+		Convey("The sort should translate into allow-listed columns", func() {
+			order, err := get("sort=-created_at,name").OrderBy(columns)
+			So(err, ShouldBeNil)
+			So(order, ShouldResemble, []soda.SortColumn{{Column: "u.created_at", Desc: true}, {Column: "u.name"}})
+
+			_, err = get("sort=password").OrderBy(columns)
+			So(err, ShouldNotBeNil)
+			So(err.(*soda.HTTPError).Status, ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("The filters should translate into allow-listed columns", func() {
+			filters, err := get("filter=age:gte:18&filter=name:a:b").Filters(columns)
+			So(err, ShouldBeNil)
+			So(filters, ShouldResemble, []soda.FilterColumn{
+				{Column: "u.age", Operator: "gte", Value: "18"},
+				{Column: "u.name", Operator: "eq", Value: "a:b"},
+			})
+
+			_, err = get("filter=password:x").Filters(columns)
+			So(err, ShouldNotBeNil)
+			_, err = get("filter=age").Filters(columns)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("The parameters should be documented with the allow-lists", func() {
+			params := engine.OpenAPI().Paths.Value("/users").Get.Parameters
+			for _, name := range []string{"page", "page_size", "sort", "filter"} {
+				So(params.GetByInAndName("query", name).Required, ShouldBeFalse)
+			}
+			So(params.GetByInAndName("query", "archived"), ShouldNotBeNil)
+			sort := params.GetByInAndName("query", "sort").Schema.Value.Items.Value
+			So(sort.Enum, ShouldResemble, []any{"age", "-age", "created_at", "-created_at", "name", "-name"})
+			filter := params.GetByInAndName("query", "filter").Schema.Value.Items.Value
+			So(filter.Pattern, ShouldEqual, "^(age):")
+		})
+	})
+}
@@ -0,0 +1,38 @@
+package soda
+
+import "strings"
+
+// bodyMediaTypes maps the tag of a body field, e.g. `body:"xml"`, to the media type of the request body.
+var bodyMediaTypes = map[string]string{
+	"json":     "application/json",
+	xmlNameTag: "application/xml",
+	"protobuf": mediaTypeProtobuf,
+}
+
+// requestMediaType returns the media type of a request body declared with the given body tag,
+// which is either a short name such as "json" or a media type such as "application/x-protobuf".
+func requestMediaType(bodyTag string) string {
+	if mt, ok := bodyMediaTypes[bodyTag]; ok {
+		return mt
+	}
+	if strings.Contains(bodyTag, "/") {
+		return bodyTag
+	}
+	return "application/json"
+}
+
+// bodyNameTag returns the name tag used to generate the schema of a request body declared with the given body tag.
+func bodyNameTag(bodyTag string) string {
+	if bodyTag == "protobuf" || strings.Contains(bodyTag, "/") {
+		return responseNameTag(requestMediaType(bodyTag))
+	}
+	return bodyTag
+}
+
+// responseNameTag returns the name tag used to generate response schemas of the given media type.
+func responseNameTag(mediaType string) string {
+	if mediaType == "application/xml" || mediaType == "text/xml" {
+		return xmlNameTag
+	}
+	return "json"
+}
@@ -0,0 +1,136 @@
+package soda
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+)
+
+// methodNotAllowedComponent names the response component documenting the 405 responses.
+const methodNotAllowedComponent = "MethodNotAllowed"
+
+// methodNotAllowedSentence starts the sentence describing the 405 responses of a path item.
+const methodNotAllowedSentence = "Other methods respond 405 Method Not Allowed"
+
+// EnableMethodNotAllowed responds 405 Method Not Allowed, with the Allow header listing the methods routed on the
+// path, to the requests whose path is only routed through soda with other methods, even when a catch-all route,
+// e.g. an SPA fallback, would respond 404. The response is the HTTPError model, documented as the MethodNotAllowed
+// response component. With describe, the path items of the operations registered afterwards mention it in their
+// description. It must be enabled before registering the catch-all routes of the app.
+func (e *Engine) EnableMethodNotAllowed(describe ...bool) *Engine {
+	if !e.methodNotAllowed {
+		e.app.Use(e.rejectMethodNotAllowed)
+	}
+	e.methodNotAllowed = true
+	e.describeMethodNotAllowed = len(describe) != 0 && describe[0]
+	resp := e.gen.GenerateResponse(http.StatusMethodNotAllowed, HTTPError{}, "application/json", "")
+	resp.Headers = openapi3.Headers{
+		fiber.HeaderAllow: &openapi3.HeaderRef{Value: &openapi3.Header{Parameter: openapi3.Parameter{
+			Description: "The methods routed on the path.",
+			Schema:      openapi3.NewStringSchema().NewRef(),
+		}}},
+	}
+	e.gen.doc.Components.Responses[methodNotAllowedComponent] = &openapi3.ResponseRef{Value: resp}
+	return e
+}
+
+// rejectMethodNotAllowed turns the 404 and 405 responses to the requests whose path is routed with other methods
+// into 405 responses listing them.
+func (e *Engine) rejectMethodNotAllowed(c *fiber.Ctx) error {
+	err := c.Next()
+	if status := auditStatus(c, err); status != http.StatusNotFound && status != http.StatusMethodNotAllowed {
+		return err
+	}
+	allowed := e.allowedMethods(c.Path())
+	if len(allowed) == 0 || slices.Contains(allowed, c.Method()) {
+		return err
+	}
+	c.Set(fiber.HeaderAllow, strings.Join(allowed, ", "))
+	httpErr := NewError(http.StatusMethodNotAllowed, MsgMethodNotAllowed, Translate(c, MsgMethodNotAllowed, c.Method()))
+	var body any = httpErr
+	if e.gen.envelope {
+		body = wrapEnvelope(c, httpErr.Status, httpErr)
+	}
+	return c.Status(httpErr.Status).JSON(body)
+}
+
+// allowedMethods returns the sorted methods of the routes matching the path.
+func (e *Engine) allowedMethods(path string) []string {
+	var methods []string
+	for _, route := range e.routes {
+		if !slices.Contains(methods, route.Method) && e.routeRegexp(route.Path).MatchString(path) {
+			methods = append(methods, route.Method)
+		}
+	}
+	slices.Sort(methods)
+	return methods
+}
+
+// routeRegexp returns the regular expression matching the paths of the fiber route, cached by route.
+func (e *Engine) routeRegexp(route string) *regexp.Regexp {
+	if re, ok := e.routeRegexps.Load(route); ok {
+		return re.(*regexp.Regexp)
+	}
+	re := compileRoute(route)
+	e.routeRegexps.Store(route, re)
+	return re
+}
+
+// compileRoute compiles the regular expression matching the paths of the fiber route, case-insensitively and with
+// an optional trailing slash as fiber does by default, capturing its parameters. The constraints of the parameters
+// are not enforced.
+func compileRoute(route string) *regexp.Regexp {
+	var b strings.Builder
+	b.WriteString("(?i)^")
+	last := 0
+	for _, loc := range regexFiberParam.FindAllStringSubmatchIndex(route, -1) {
+		literal, param := route[last:loc[0]], route[loc[0]:loc[1]]
+		optional := param == "*" || loc[6] != -1
+		pattern := "([^/]+)"
+		switch {
+		case param == "*":
+			pattern = "(.*)"
+		case param == "+":
+			pattern = "(.+)"
+		}
+		if optional && strings.HasSuffix(literal, "/") {
+			// The slash before an optional parameter is optional too, e.g. /users/:id? matches /users.
+			b.WriteString(regexp.QuoteMeta(strings.TrimSuffix(literal, "/")))
+			b.WriteString("(?:/" + pattern + ")?")
+		} else {
+			b.WriteString(regexp.QuoteMeta(literal))
+			b.WriteString(pattern)
+		}
+		last = loc[1]
+	}
+	b.WriteString(regexp.QuoteMeta(strings.TrimSuffix(route[last:], "/")))
+	b.WriteString("/?$")
+	return regexp.MustCompile(b.String())
+}
+
+// describeAllowedMethods mentions the 405 responses in the description of the path item of the documented path,
+// listing the methods routed on it.
+func (e *Engine) describeAllowedMethods(docPath string) {
+	item := e.gen.doc.Paths.Value(docPath)
+	if item == nil {
+		return
+	}
+	var methods []string
+	for _, route := range e.routes {
+		if route.DocPath == docPath && !slices.Contains(methods, route.Method) {
+			methods = append(methods, route.Method)
+		}
+	}
+	slices.Sort(methods)
+	description, _, _ := strings.Cut(item.Description, methodNotAllowedSentence)
+	if description != "" && !strings.HasSuffix(description, " ") {
+		description += " "
+	}
+	item.Description = fmt.Sprintf("%s%s, with the Allow header listing %s.", description, methodNotAllowedSentence,
+		strings.Join(methods, ", "))
+}
@@ -0,0 +1,62 @@
+package soda_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestMethodNotAllowed(t *testing.T) {
+	Convey("Given an engine responding 405 to the methods not routed on a path", t, func() {
+		engine := soda.New().EnableMethodNotAllowed(true)
+		engine.Get("/users/:id", func(c *fiber.Ctx) error { return nil }).OK()
+		engine.Delete("/users/:id", func(c *fiber.Ctx) error { return nil }).OK()
+		engine.Get("/files/*", func(c *fiber.Ctx) error { return nil }).OK()
+		engine.App().All("/*", func(c *fiber.Ctx) error {
+			return c.Status(http.StatusNotFound).SendString("index.html")
+		})
+
+		Convey("The other methods should be rejected with the Allow header", func() {
+			resp, err := engine.App().Test(httptest.NewRequest("PUT", "/users/1", nil))
+			So(err, ShouldBeNil)
+			So(resp.StatusCode, ShouldEqual, http.StatusMethodNotAllowed)
+			So(resp.Header.Get("Allow"), ShouldEqual, "DELETE, GET")
+			body, _ := io.ReadAll(resp.Body)
+			var httpErr soda.HTTPError
+			So(json.Unmarshal(body, &httpErr), ShouldBeNil)
+			So(httpErr.Code, ShouldEqual, soda.MsgMethodNotAllowed)
+			So(httpErr.Message, ShouldEqual, "method PUT is not allowed")
+
+			resp, err = engine.App().Test(httptest.NewRequest("POST", "/files/a/b.txt", nil))
+			So(err, ShouldBeNil)
+			So(resp.StatusCode, ShouldEqual, http.StatusMethodNotAllowed)
+			So(resp.Header.Get("Allow"), ShouldEqual, "GET")
+		})
+
+		Convey("The routed methods and the unknown paths should be left alone", func() {
+			resp, err := engine.App().Test(httptest.NewRequest("GET", "/users/1", nil))
+			So(err, ShouldBeNil)
+			So(resp.StatusCode, ShouldEqual, http.StatusOK)
+
+			resp, err = engine.App().Test(httptest.NewRequest("PUT", "/unknown", nil))
+			So(err, ShouldBeNil)
+			So(resp.StatusCode, ShouldEqual, http.StatusNotFound)
+			So(resp.Header.Get("Allow"), ShouldBeEmpty)
+		})
+
+		Convey("The 405 response and the path items should be documented", func() {
+			doc := engine.OpenAPI()
+			resp := doc.Components.Responses["MethodNotAllowed"]
+			So(resp, ShouldNotBeNil)
+			So(resp.Value.Headers, ShouldContainKey, "Allow")
+			So(doc.Paths.Value("/users/:id").Description, ShouldEqual,
+				"Other methods respond 405 Method Not Allowed, with the Allow header listing DELETE, GET.")
+		})
+	})
+}
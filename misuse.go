@@ -0,0 +1,61 @@
+package soda
+
+import (
+	"fmt"
+)
+
+// SetStrict sets whether the misuses of the builders, such as a non-struct input or a route conflict, panic, which
+// is the default. In lenient mode, the operation builders record them instead, reported by Errors, and the misused
+// operations aren't registered, so that the code-generated registrations can surface all their problems at once.
+func (e *Engine) SetStrict(strict bool) *Engine {
+	e.lenient = !strict
+	return e
+}
+
+// Errors returns the misuses of the builders recorded in lenient mode, in their order.
+func (e *Engine) Errors() []error {
+	e.misusesMu.Lock()
+	defer e.misusesMu.Unlock()
+	return append([]error(nil), e.misuses...)
+}
+
+// recoverMisuse records the panic of a builder of the operation as an error of the engine in lenient mode,
+// marking the operation as misused and returning it as the result of the builder, if any. It must be deferred.
+func (op *OperationBuilder) recoverMisuse(self **OperationBuilder) {
+	e := op.route.engine
+	if e == nil || !e.lenient {
+		return
+	}
+	if r := recover(); r != nil {
+		op.misused = true
+		if self != nil {
+			*self = op
+		}
+		e.addMisuse(op.method+" "+op.patternFull, r)
+	}
+}
+
+// recoverMisuse records the panic of a builder of the router as an error of the engine in lenient mode.
+// It must be deferred.
+func (r *Router) recoverMisuse(subject string) {
+	e := r.engine
+	if e == nil || !e.lenient {
+		return
+	}
+	if v := recover(); v != nil {
+		e.addMisuse(subject, v)
+	}
+}
+
+// addMisuse records the panic value of a misuse of the subject.
+func (e *Engine) addMisuse(subject string, v any) {
+	var err error
+	if cause, ok := v.(error); ok {
+		err = fmt.Errorf("%s: %w", subject, cause)
+	} else {
+		err = fmt.Errorf("%s: %v", subject, v)
+	}
+	e.misusesMu.Lock()
+	defer e.misusesMu.Unlock()
+	e.misuses = append(e.misuses, err)
+}
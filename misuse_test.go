@@ -0,0 +1,51 @@
+package soda_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestLenientMode(t *testing.T) {
+	handler := func(c *fiber.Ctx) error { return c.SendStatus(http.StatusNoContent) }
+
+	Convey("Given an engine in lenient mode", t, func() {
+		engine := soda.New().SetStrict(false)
+		engine.Get("/users", handler).SetInput(42).OK()
+		engine.Get("/orders", handler).OK()
+		engine.Get("/orders", handler).OK()
+		engine.Post("/reports", handler).Coalesce(nil).OK()
+		engine.Get("/health", handler).OK()
+
+		Convey("The misuses of the builders should be collected rather than panic", func() {
+			errs := engine.Errors()
+			So(errs, ShouldHaveLength, 3)
+			So(errs[0].Error(), ShouldEqual, "GET /users: input must be a struct")
+			So(errs[1].Error(), ShouldStartWith, "GET /orders: ")
+			So(errs[2].Error(), ShouldEqual, "POST /reports: only the GET operations can be coalesced, not POST /reports")
+		})
+
+		Convey("The misused operations should not be registered", func() {
+			So(engine.OpenAPI().Paths.Value("/users"), ShouldBeNil)
+			So(engine.OpenAPI().Paths.Value("/reports"), ShouldBeNil)
+			So(engine.OpenAPI().Paths.Value("/health"), ShouldNotBeNil)
+
+			resp, err := engine.App().Test(httptest.NewRequest("GET", "/users", nil))
+			So(err, ShouldBeNil)
+			So(resp.StatusCode, ShouldEqual, http.StatusNotFound)
+		})
+	})
+
+	Convey("Given an engine in strict mode", t, func() {
+		engine := soda.New()
+
+		Convey("The misuses of the builders should panic", func() {
+			So(func() { engine.Get("/users", handler).SetInput(42) }, ShouldPanicWith, "input must be a struct")
+			So(engine.Errors(), ShouldBeEmpty)
+		})
+	})
+}
@@ -0,0 +1,88 @@
+package soda
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+)
+
+// PaginationInput is the input mixin of the paginated operations, e.g. ?page=2&page_size=50.
+type PaginationInput struct {
+	Page     int `query:"page"      oai:"description=The page to list, from 1;minimum=1;required=false"`
+	PageSize int `query:"page_size" oai:"description=The number of items per page;minimum=1;required=false"`
+}
+
+// OffsetLimit returns the offset and the limit of the page, as ListQuery.OffsetLimit.
+func (in PaginationInput) OffsetLimit() (offset, limit int) {
+	return ListQuery{Page: in.Page, PageSize: in.PageSize}.OffsetLimit()
+}
+
+// TenantHeaderInput is the input mixin of the operations of multi-tenant APIs, scoped by the X-Tenant-ID header.
+type TenantHeaderInput struct {
+	TenantID string `header:"X-Tenant-ID" oai:"description=The tenant the request is scoped to;minLength=1"`
+}
+
+// IfMatchInput is the input mixin of the conditional updates, guarded by the If-Match header against lost updates.
+type IfMatchInput struct {
+	IfMatch string `header:"If-Match" oai:"description=The ETags of the representations the update is conditional on, or *;required=false"`
+}
+
+// Check responds 412 Precondition Failed unless the If-Match header, if any, matches the current ETag of the
+// resource, strongly as per RFC 9110.
+func (in IfMatchInput) Check(c *fiber.Ctx, etag string) error {
+	if in.IfMatch == "" || in.IfMatch == "*" {
+		return nil
+	}
+	for _, candidate := range strings.Split(in.IfMatch, ",") {
+		if candidate = strings.TrimSpace(candidate); !strings.HasPrefix(candidate, "W/") && candidate == etag {
+			return nil
+		}
+	}
+	return NewError(http.StatusPreconditionFailed, MsgPreconditionFailed, Translate(c, MsgPreconditionFailed))
+}
+
+var (
+	inputMixinsMu sync.RWMutex
+	// inputMixins are the embeddable input structs whose parameters are documented as shared components.
+	inputMixins = map[reflect.Type]bool{
+		reflect.TypeOf(PaginationInput{}):   true,
+		reflect.TypeOf(TenantHeaderInput{}): true,
+		reflect.TypeOf(IfMatchInput{}):      true,
+	}
+)
+
+// RegisterInputMixin registers the struct of the mixin as an input mixin, in addition to PaginationInput,
+// TenantHeaderInput and IfMatchInput: the parameters of the inputs embedding it are documented as references to
+// parameter components named after the mixin and the parameters, e.g. PaginationInput.page, so that the
+// conventions they carry are shared across the operations. They are bound as usual.
+func RegisterInputMixin(mixin any) {
+	inputMixinsMu.Lock()
+	defer inputMixinsMu.Unlock()
+	inputMixins[derefType(reflect.TypeOf(mixin))] = true
+}
+
+// isInputMixin reports whether the type is an input mixin.
+func isInputMixin(t reflect.Type) bool {
+	inputMixinsMu.RLock()
+	defer inputMixinsMu.RUnlock()
+	return inputMixins[t]
+}
+
+// generateMixinParameters generates the parameters of the input mixin as references to its parameter components.
+func (g *Generator) generateMixinParameters(parameters *openapi3.Parameters, mixin reflect.Type) {
+	var generated openapi3.Parameters
+	g.generateParameters(&generated, mixin)
+	for _, param := range generated {
+		name := mixin.Name() + "." + param.Value.Name
+		if component, ok := g.doc.Components.Parameters[name]; ok {
+			param = component
+		} else {
+			g.doc.Components.Parameters[name] = param
+		}
+		*parameters = append(*parameters, &openapi3.ParameterRef{Ref: "#/components/parameters/" + name, Value: param.Value})
+	}
+}
@@ -0,0 +1,94 @@
+package soda_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type mixinListInput struct {
+	soda.PaginationInput
+	soda.TenantHeaderInput
+	Archived bool `query:"archived" oai:"required=false"`
+}
+
+type mixinUpdateInput struct {
+	soda.TenantHeaderInput
+	soda.IfMatchInput
+	ID   string `path:"id"`
+	Body struct {
+		Name string `json:"name"`
+	} `body:"json"`
+}
+
+type LocaleInput struct {
+	Locale string `query:"locale" oai:"required=false"`
+}
+
+func TestInputMixins(t *testing.T) {
+	soda.RegisterInputMixin(LocaleInput{})
+
+	Convey("Given operations whose inputs embed mixins", t, func() {
+		engine := soda.New()
+		engine.Get("/documents", func(c *fiber.Ctx) error {
+			in := soda.GetInput[mixinListInput](c)
+			offset, limit := in.OffsetLimit()
+			return c.JSON(fiber.Map{"tenant": in.TenantID, "offset": offset, "limit": limit})
+		}).SetInput(mixinListInput{}).OK()
+		engine.Put("/documents/:id", func(c *fiber.Ctx) error {
+			in := soda.GetInput[mixinUpdateInput](c)
+			if err := in.Check(c, `"v2"`); err != nil {
+				return err
+			}
+			return c.SendStatus(http.StatusNoContent)
+		}).SetInput(mixinUpdateInput{}).OK()
+		engine.Get("/pages", nil).SetInput(struct{ LocaleInput }{}).OK()
+
+		Convey("The parameters of the mixins should be documented as shared components", func() {
+			doc := engine.OpenAPI()
+			So(doc.Components.Parameters, ShouldContainKey, "PaginationInput.page")
+			So(doc.Components.Parameters, ShouldContainKey, "PaginationInput.page_size")
+			So(doc.Components.Parameters, ShouldContainKey, "TenantHeaderInput.X-Tenant-ID")
+			So(doc.Components.Parameters, ShouldContainKey, "IfMatchInput.If-Match")
+			So(doc.Components.Parameters, ShouldContainKey, "LocaleInput.locale")
+
+			list := doc.Paths.Value("/documents").Get.Parameters
+			So(list[0].Ref, ShouldEqual, "#/components/parameters/PaginationInput.page")
+			So(list.GetByInAndName("header", "X-Tenant-ID").Required, ShouldBeTrue)
+			So(list.GetByInAndName("query", "archived"), ShouldNotBeNil)
+			update := doc.Paths.Value("/documents/:id").Put.Parameters
+			So(update[0].Ref, ShouldEqual, "#/components/parameters/TenantHeaderInput.X-Tenant-ID")
+			So(update[0].Value, ShouldEqual, list.GetByInAndName("header", "X-Tenant-ID"))
+		})
+
+		Convey("The parameters of the mixins should be bound", func() {
+			req := httptest.NewRequest("GET", "/documents?page=3&page_size=10", nil)
+			req.Header.Set("X-Tenant-ID", "acme")
+			resp, err := engine.App().Test(req)
+			So(err, ShouldBeNil)
+			body, _ := io.ReadAll(resp.Body)
+			So(string(body), ShouldEqual, `{"limit":10,"offset":20,"tenant":"acme"}`)
+		})
+
+		Convey("The If-Match precondition should be checked", func() {
+			update := func(ifMatch string) int {
+				req := httptest.NewRequest("PUT", "/documents/1", strings.NewReader(`{"name":"a"}`))
+				req.Header.Set("Content-Type", "application/json")
+				req.Header.Set("If-Match", ifMatch)
+				resp, err := engine.App().Test(req)
+				So(err, ShouldBeNil)
+				return resp.StatusCode
+			}
+			So(update(`"v1"`), ShouldEqual, http.StatusPreconditionFailed)
+			So(update(`"v1", "v2"`), ShouldEqual, http.StatusNoContent)
+			So(update(`W/"v2"`), ShouldEqual, http.StatusPreconditionFailed)
+			So(update(`*`), ShouldEqual, http.StatusNoContent)
+		})
+	})
+}
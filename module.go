@@ -0,0 +1,82 @@
+package soda
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// Module is an API module, typically defined in its own package and registered with RegisterModules,
+// which groups its operations under its prefix and tags them.
+type Module struct {
+	// Name identifies the module, e.g. "billing".
+	Name string
+	// Prefix is the path prefix of the operations of the module, "/" followed by the name by default.
+	Prefix string
+	// Tags are added to every operation of the module, the name by default.
+	Tags []string
+	// NamespaceTags prefixes the tags of the operations with the name of the module, such as "billing/invoices",
+	// so that modules don't share tags by accident.
+	NamespaceTags bool
+	// Register registers the operations of the module on the router.
+	Register func(r *Router)
+}
+
+// RegisterModules registers the modules, in order.
+// It panics if a module has the name of a module already registered, or if one of its routes or operation IDs
+// conflicts with the ones of the operations registered before.
+func (e *Engine) RegisterModules(modules ...Module) *Engine {
+	for _, module := range modules {
+		e.registerModule(module)
+	}
+	return e
+}
+
+func (e *Engine) registerModule(module Module) {
+	if module.Name == "" || module.Register == nil {
+		panic("a module must have a name and a register function")
+	}
+	if e.modules[module.Name] {
+		panic("module " + module.Name + " is already registered")
+	}
+	if e.modules == nil {
+		e.modules = make(map[string]bool)
+	}
+	e.modules[module.Name] = true
+
+	prefix := module.Prefix
+	if prefix == "" {
+		prefix = path.Join("/", module.Name)
+	}
+	tags := module.Tags
+	if len(tags) == 0 {
+		tags = []string{module.Name}
+	}
+	router := e.Group(prefix)
+	if module.NamespaceTags {
+		router.tagNamespace = module.Name
+	}
+	router.AddTags(tags...)
+
+	operationIDs := make(map[string]bool, len(e.routes))
+	for _, route := range e.routes {
+		operationIDs[route.OperationID] = true
+	}
+	registered := len(e.routes)
+	module.Register(router)
+	for _, route := range e.routes[registered:] {
+		if operationIDs[route.OperationID] {
+			panic(fmt.Sprintf("the operation ID %s of the module %s is already used", route.OperationID, module.Name))
+		}
+		operationIDs[route.OperationID] = true
+	}
+}
+
+// namespaceTag prefixes the tag with the namespace of the router, if any, unless it is the namespace itself
+// or already prefixed.
+func (r *Router) namespaceTag(tag string) string {
+	if r.tagNamespace == "" || tag == r.tagNamespace || strings.HasPrefix(tag, r.tagNamespace+"/") {
+		return tag
+	}
+	return r.tagNamespace + "/" + tag
+}
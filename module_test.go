@@ -0,0 +1,66 @@
+package soda_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestModules(t *testing.T) {
+	Convey("Given modules registered declaratively", t, func() {
+		handler := func(c *fiber.Ctx) error { return c.SendString(c.Path()) }
+		billing := soda.Module{
+			Name:          "billing",
+			NamespaceTags: true,
+			Register: func(r *soda.Router) {
+				r.Get("/invoices", handler).SetOperationID("list-invoices").AddTags("invoices").OK()
+			},
+		}
+		users := soda.Module{
+			Name:   "users",
+			Prefix: "/accounts",
+			Tags:   []string{"accounts", "people"},
+			Register: func(r *soda.Router) {
+				r.Get("/", handler).SetOperationID("list-users").AddTags("admin").OK()
+			},
+		}
+		engine := soda.New().RegisterModules(billing, users)
+
+		Convey("The operations should be prefixed", func() {
+			for _, target := range []string{"/billing/invoices", "/accounts"} {
+				resp, err := engine.App().Test(httptest.NewRequest("GET", target, nil))
+				So(err, ShouldBeNil)
+				So(resp.StatusCode, ShouldEqual, http.StatusOK)
+			}
+		})
+
+		Convey("The operations should be tagged", func() {
+			doc := engine.OpenAPI()
+			So(doc.Paths.Value("/billing/invoices").Get.Tags, ShouldResemble, []string{"billing", "billing/invoices"})
+			So(doc.Paths.Value("/accounts").Get.Tags, ShouldResemble, []string{"accounts", "people", "admin"})
+			So(doc.Tags.Get("billing/invoices"), ShouldNotBeNil)
+			So(doc.Tags.Get("invoices"), ShouldBeNil)
+		})
+
+		Convey("Conflicting modules should panic", func() {
+			So(func() {
+				engine.RegisterModules(soda.Module{Name: "billing", Prefix: "/v2", Register: func(*soda.Router) {}})
+			},
+				ShouldPanicWith, "module billing is already registered")
+			So(func() {
+				engine.RegisterModules(soda.Module{Name: "invoicing", Register: func(r *soda.Router) {
+					r.Get("/invoices", handler).SetOperationID("list-invoices").OK()
+				}})
+			}, ShouldPanicWith, "the operation ID list-invoices of the module invoicing is already used")
+			So(func() {
+				engine.RegisterModules(soda.Module{Name: "legacy", Prefix: "/billing", Register: func(r *soda.Router) {
+					r.Get("/invoices", handler).SetOperationID("legacy-invoices").OK()
+				}})
+			}, ShouldPanic)
+		})
+	})
+}
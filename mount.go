@@ -0,0 +1,157 @@
+package soda
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"reflect"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Mount mounts the app of the child engine under the prefix and merges its operations into the spec, their paths
+// being prefixed, along with its components, tags and routes, so that modules can be composed into one server.
+// The child must be complete: the operations it registers afterwards are served but not documented by the parent.
+// Its documentation endpoints, if any, are served under the prefix, describing the module alone.
+// The operation IDs generated from the paths of the child, and the request body schemas named after them, are
+// renamed after the prefixed paths, so that several engines can be mounted.
+// It panics if a route, an operation ID or a component of the child conflicts with the ones of the parent.
+func (e *Engine) Mount(prefix string, child *Engine) *Engine {
+	e.invalidateSpec()
+	// The renamed operation IDs, by generated operation ID.
+	renamed := make(map[string]string)
+	for _, route := range child.routes {
+		if route.OperationID == genDefaultOperationID(route.Method, route.Path) {
+			renamed[route.OperationID] = genDefaultOperationID(route.Method, path.Join(prefix, route.Path))
+			route.OperationID = renamed[route.OperationID]
+		}
+		site := child.routeSites[route.Method+" "+regexRouteParam.ReplaceAllString(route.Path, ":")]
+		route.Path = path.Join(prefix, route.Path)
+		key := route.Method + " " + regexRouteParam.ReplaceAllString(route.Path, ":")
+		if previous, ok := e.routeSites[key]; ok {
+			panic(fmt.Sprintf("route %s %s registered at %s conflicts with the route registered at %s",
+				route.Method, route.Path, site, previous))
+		}
+		if e.routeSites == nil {
+			e.routeSites = make(map[string]string)
+		}
+		e.routeSites[key] = site
+		if route.Documented {
+			route.DocPath = path.Join(prefix, route.DocPath)
+		}
+		e.routes = append(e.routes, route)
+	}
+
+	doc, childDoc := e.gen.doc, child.gen.doc
+	operationIDs := make(map[string]bool)
+	for _, item := range doc.Paths.Map() {
+		for _, operation := range item.Operations() {
+			operationIDs[operation.OperationID] = true
+		}
+	}
+	for _, name := range sortedKeys(childDoc.Paths.Map()) {
+		item := childDoc.Paths.Value(name)
+		for _, method := range sortedKeys(item.Operations()) {
+			operation := renameOperation(item.GetOperation(method), renamed)
+			if operation.OperationID != "" && operationIDs[operation.OperationID] {
+				panic(fmt.Sprintf("the operation ID %s of the mounted engine conflicts with the one of the parent",
+					operation.OperationID))
+			}
+			operationIDs[operation.OperationID] = true
+			doc.AddOperation(path.Join(prefix, name), method, operation)
+		}
+	}
+	components := *childDoc.Components
+	components.Schemas = make(openapi3.Schemas, len(childDoc.Components.Schemas))
+	for name, schema := range childDoc.Components.Schemas {
+		if id, ok := renamed[strings.TrimSuffix(name, "-body")]; ok && strings.HasSuffix(name, "-body") {
+			name = id + "-body"
+		}
+		components.Schemas[name] = schema
+	}
+	if err := mergeComponents(doc.Components, &components, "the mounted engine", "the parent"); err != nil {
+		panic(err.Error())
+	}
+	for _, tag := range childDoc.Tags {
+		if doc.Tags.Get(tag.Name) == nil {
+			doc.Tags = append(doc.Tags, tag)
+		}
+	}
+
+	e.app.Mount(prefix, child.app)
+	return e
+}
+
+// renameOperation returns a copy of the operation with its renamed operation ID, if any, and the reference to its
+// request body schema named after the operation ID.
+func renameOperation(operation *openapi3.Operation, renamed map[string]string) *openapi3.Operation {
+	id, ok := renamed[operation.OperationID]
+	if !ok {
+		return operation
+	}
+	clone := *operation
+	clone.OperationID = id
+	if body := operation.RequestBody; body != nil && body.Value != nil {
+		value := *body.Value
+		value.Content = make(openapi3.Content, len(body.Value.Content))
+		for mt, media := range body.Value.Content {
+			media := *media
+			if media.Schema != nil && media.Schema.Ref == "#/components/schemas/"+operation.OperationID+"-body" {
+				media.Schema = openapi3.NewSchemaRef("#/components/schemas/"+id+"-body", media.Schema.Value)
+			}
+			value.Content[mt] = &media
+		}
+		clone.RequestBody = &openapi3.RequestBodyRef{Ref: body.Ref, Value: &value}
+	}
+	return &clone
+}
+
+// mergeComponents copies the components of the source into the ones of the target, returning an error for each
+// component of the same name which differs.
+func mergeComponents(target, source *openapi3.Components, sourceName, targetName string) error {
+	names := [2]string{sourceName, targetName}
+	return errors.Join(
+		mergeComponent("schema", &target.Schemas, source.Schemas, names),
+		mergeComponent("parameter", &target.Parameters, source.Parameters, names),
+		mergeComponent("header", &target.Headers, source.Headers, names),
+		mergeComponent("request body", &target.RequestBodies, source.RequestBodies, names),
+		mergeComponent("response", &target.Responses, source.Responses, names),
+		mergeComponent("security scheme", &target.SecuritySchemes, source.SecuritySchemes, names),
+		mergeComponent("example", &target.Examples, source.Examples, names),
+		mergeComponent("link", &target.Links, source.Links, names),
+		mergeComponent("callback", &target.Callbacks, source.Callbacks, names),
+	)
+}
+
+// mergeComponent copies the components of a kind of the source into the ones of the target, returning an error
+// for each component of the same name which differs.
+func mergeComponent[M ~map[string]V, V any](kind string, target *M, source M, names [2]string) error {
+	var errs []error
+	for _, name := range sortedKeys(source) {
+		component := source[name]
+		if existing, ok := (*target)[name]; ok {
+			if !sameComponent(existing, component) {
+				errs = append(errs, fmt.Errorf("the %s %s of %s conflicts with the one of %s", kind, name, names[0],
+					names[1]))
+			}
+			continue
+		}
+		if *target == nil {
+			*target = make(M)
+		}
+		(*target)[name] = component
+	}
+	return errors.Join(errs...)
+}
+
+// sameComponent reports whether the components have the same JSON representation.
+func sameComponent(a, b any) bool {
+	ja, errA := json.Marshal(a)
+	jb, errB := json.Marshal(b)
+	if errA != nil || errB != nil {
+		return reflect.DeepEqual(a, b)
+	}
+	return string(ja) == string(jb)
+}
@@ -0,0 +1,121 @@
+package soda_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type mountedInvoice struct {
+	ID     int     `json:"id"`
+	Amount float64 `json:"amount"`
+}
+
+func TestMount(t *testing.T) {
+	Convey("Given a module mounted under a prefix", t, func() {
+		billing := soda.New().ServeSpecJSON("/openapi.json")
+		billing.Get("/invoices/:id", func(c *fiber.Ctx) error {
+			return c.JSON(mountedInvoice{ID: 1, Amount: 9.5})
+		}).SetOperationID("get-invoice").AddTags("billing").AddJSONResponse(http.StatusOK, mountedInvoice{}).OK()
+
+		engine := soda.New()
+		engine.Get("/health", func(c *fiber.Ctx) error { return c.SendString("ok") }).OK()
+		engine.Mount("/billing", billing)
+
+		get := func(target string) (int, string) {
+			resp, err := engine.App().Test(httptest.NewRequest("GET", target, nil))
+			So(err, ShouldBeNil)
+			body, _ := io.ReadAll(resp.Body)
+			return resp.StatusCode, string(body)
+		}
+
+		Convey("The operations of the module should be served under the prefix", func() {
+			status, body := get("/billing/invoices/1")
+			So(status, ShouldEqual, http.StatusOK)
+			So(body, ShouldEqual, `{"id":1,"amount":9.5}`)
+		})
+
+		Convey("The spec should merge the operations, components and tags of the module", func() {
+			doc := engine.OpenAPI()
+			So(doc.Paths.Value("/health"), ShouldNotBeNil)
+			operation := doc.Paths.Value("/billing/invoices/:id").Get
+			So(operation.OperationID, ShouldEqual, "get-invoice")
+			So(doc.Components.Schemas, ShouldContainKey, "soda_test.mountedInvoice")
+			So(doc.Tags.Get("billing"), ShouldNotBeNil)
+		})
+
+		Convey("The routes should be prefixed", func() {
+			routes := engine.Routes()
+			So(routes, ShouldHaveLength, 2)
+			So(routes[1].Path, ShouldEqual, "/billing/invoices/:id")
+			So(routes[1].DocPath, ShouldEqual, "/billing/invoices/:id")
+		})
+
+		Convey("The module should serve its own spec under the prefix", func() {
+			status, body := get("/billing/openapi.json")
+			So(status, ShouldEqual, http.StatusOK)
+			So(body, ShouldContainSubstring, `"/invoices/:id"`)
+		})
+
+		Convey("Conflicting routes should panic", func() {
+			other := soda.New()
+			other.Get("/invoices/:number", func(c *fiber.Ctx) error { return nil }).OK()
+			So(func() { engine.Mount("/billing", other) }, ShouldPanic)
+		})
+
+		Convey("Conflicting operation IDs should panic", func() {
+			other := soda.New()
+			other.Get("/refunds", func(c *fiber.Ctx) error { return nil }).SetOperationID("get-invoice").OK()
+			So(func() { engine.Mount("/other", other) }, ShouldPanicWith,
+				"the operation ID get-invoice of the mounted engine conflicts with the one of the parent")
+		})
+
+		Convey("Conflicting components should panic", func() {
+			other := soda.New()
+			other.AddExampleComponent("invoice", openapi3.NewExample(mountedInvoice{ID: 2}))
+			engine.AddExampleComponent("invoice", openapi3.NewExample(mountedInvoice{ID: 1}))
+			So(func() { engine.Mount("/other", other) }, ShouldPanicWith,
+				"the example invoice of the mounted engine conflicts with the one of the parent")
+		})
+	})
+
+	Convey("Given modules registering the same paths", t, func() {
+		newModule := func() *soda.Engine {
+			module := soda.New()
+			module.Get("/", func(c *fiber.Ctx) error { return c.SendString(c.Path()) }).OK()
+			module.Post("/", func(c *fiber.Ctx) error { return c.SendString(c.Path()) }).
+				SetInput(struct {
+					Body struct {
+						Name string `json:"name"`
+					} `body:"json"`
+				}{}).OK()
+			return module
+		}
+		engine := soda.New()
+		engine.Mount("/users", newModule()).Mount("/orders", newModule())
+
+		Convey("Their generated operation IDs should be prefixed", func() {
+			doc := engine.OpenAPI()
+			So(doc.Paths.Value("/users").Get.OperationID, ShouldEqual, "get--users")
+			So(doc.Paths.Value("/orders").Get.OperationID, ShouldEqual, "get--orders")
+			routes := engine.Routes()
+			So(routes[0].OperationID, ShouldEqual, "get--users")
+			So(routes[2].OperationID, ShouldEqual, "get--orders")
+		})
+
+		Convey("Their request body schemas should be named after the prefixed operation IDs", func() {
+			doc := engine.OpenAPI()
+			So(doc.Components.Schemas, ShouldContainKey, "post--users-body")
+			So(doc.Components.Schemas, ShouldContainKey, "post--orders-body")
+			So(doc.Components.Schemas, ShouldNotContainKey, "post---body")
+			body := doc.Paths.Value("/orders").Post.RequestBody.Value.Content.Get("application/json")
+			So(body.Schema.Ref, ShouldEqual, "#/components/schemas/post--orders-body")
+		})
+	})
+}
@@ -0,0 +1,91 @@
+package soda
+
+import (
+	"crypto/x509"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+)
+
+// mutualTLSExtension documents that an operation requires a client certificate in the OpenAPI 3.0 documents,
+// which have no mutualTLS security scheme.
+const mutualTLSExtension = "x-mutualTLS"
+
+// ClientCertVerifier verifies the client certificate of a request, e.g. its subject, returning an error to reject it.
+type ClientCertVerifier func(cert *x509.Certificate) error
+
+// mutualTLS is the client certificate requirement of the operations of a router.
+type mutualTLS struct {
+	verifiers []ClientCertVerifier
+}
+
+// NewMutualTLSSecurityScheme creates a mutualTLS security scheme, which only OpenAPI 3.1 documents support.
+func NewMutualTLSSecurityScheme(description ...string) *openapi3.SecurityScheme {
+	sec := openapi3.NewSecurityScheme().WithType("mutualTLS")
+	if len(description) != 0 {
+		sec = sec.WithDescription(description[0])
+	}
+	return sec
+}
+
+// AddMutualTLS requires the requests of the operation to present a client certificate, accepted by the verifiers
+// if any: the requirement is enforced before binding, responding 401 without certificate and 403 when a verifier
+// rejects it, and a 401 response is documented. It is documented as a mutualTLS security scheme when the version
+// of the document is 3.1, and with the x-mutualTLS extension of the operation otherwise.
+// The TLS config of the server must request the client certificates, and should verify them, e.g. with
+// tls.RequireAndVerifyClientCert.
+func (op *OperationBuilder) AddMutualTLS(securityName string, verifiers ...ClientCertVerifier) *OperationBuilder {
+	if op.route.gen.openAPI31() {
+		op.AddSecurity(securityName, NewMutualTLSSecurityScheme())
+	} else {
+		op.markMutualTLS()
+	}
+	return op.requireMutualTLS(verifiers)
+}
+
+// AddMutualTLS requires the requests of every operation of the router to present a client certificate,
+// see OperationBuilder.AddMutualTLS.
+func (r *Router) AddMutualTLS(securityName string, verifiers ...ClientCertVerifier) *Router {
+	if r.gen.openAPI31() {
+		r.AddSecurity(securityName, NewMutualTLSSecurityScheme())
+	}
+	r.mutualTLS = &mutualTLS{verifiers: verifiers}
+	return r
+}
+
+// openAPI31 reports whether the version of the document is 3.1.
+func (g *Generator) openAPI31() bool {
+	return strings.HasPrefix(g.doc.OpenAPI, "3.1")
+}
+
+// markMutualTLS documents the client certificate requirement of the operation with the x-mutualTLS extension.
+func (op *OperationBuilder) markMutualTLS() {
+	if op.operation.Extensions == nil {
+		op.operation.Extensions = make(map[string]any)
+	}
+	op.operation.Extensions[mutualTLSExtension] = true
+}
+
+// requireMutualTLS enforces the client certificate requirement of the operation and documents its 401 response.
+func (op *OperationBuilder) requireMutualTLS(verifiers []ClientCertVerifier) *OperationBuilder {
+	op.operation.AddResponse(http.StatusUnauthorized, op.route.gen.GenerateResponse(http.StatusUnauthorized, nil, "", ""))
+	return op.OnBeforeBind(requireClientCert(verifiers))
+}
+
+// requireClientCert returns a hook rejecting the requests without a client certificate accepted by the verifiers.
+func requireClientCert(verifiers []ClientCertVerifier) HookBeforeBind {
+	return func(ctx *fiber.Ctx) error {
+		state := ctx.Context().TLSConnectionState()
+		if state == nil || len(state.PeerCertificates) == 0 {
+			return fiber.NewError(http.StatusUnauthorized, "client certificate required")
+		}
+		for _, verify := range verifiers {
+			if err := verify(state.PeerCertificates[0]); err != nil {
+				return fiber.NewError(http.StatusForbidden, "client certificate rejected")
+			}
+		}
+		return nil
+	}
+}
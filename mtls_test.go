@@ -0,0 +1,115 @@
+package soda_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// selfSignedCert creates a self-signed certificate with the common name.
+func selfSignedCert(commonName string) tls.Certificate {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, _ := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+func TestMutualTLS(t *testing.T) {
+	Convey("Given operations requiring client certificates", t, func() {
+		engine := soda.New()
+		ok := func(c *fiber.Ctx) error { return c.SendString("ok") }
+		trusted := func(cert *x509.Certificate) error {
+			if cert.Subject.CommonName != "trusted" {
+				return errors.New("untrusted client")
+			}
+			return nil
+		}
+		engine.Get("/payments", ok).AddMutualTLS("clientCert", trusted).OK()
+		internal := engine.Group("/internal").AddMutualTLS("clientCert")
+		internal.Get("/metrics", ok).OK()
+		engine.Get("/public", ok).OK()
+
+		Convey("The requirement should be documented with an extension in 3.0 documents", func() {
+			payments := engine.OpenAPI().Paths.Value("/payments").Get
+			So(payments.Extensions["x-mutualTLS"], ShouldEqual, true)
+			So(payments.Responses.Status(http.StatusUnauthorized), ShouldNotBeNil)
+			So(engine.OpenAPI().Paths.Value("/internal/metrics").Get.Extensions["x-mutualTLS"], ShouldEqual, true)
+			So(engine.OpenAPI().Paths.Value("/public").Get.Extensions, ShouldNotContainKey, "x-mutualTLS")
+			So(engine.OpenAPI().Components.SecuritySchemes, ShouldNotContainKey, "clientCert")
+		})
+
+		Convey("The requests without certificate should be rejected", func() {
+			resp, err := engine.App().Test(httptest.NewRequest("GET", "/payments", nil))
+			So(err, ShouldBeNil)
+			So(resp.StatusCode, ShouldEqual, http.StatusUnauthorized)
+			resp, err = engine.App().Test(httptest.NewRequest("GET", "/public", nil))
+			So(err, ShouldBeNil)
+			So(resp.StatusCode, ShouldEqual, http.StatusOK)
+		})
+
+		Convey("The client certificates should be verified over TLS", func() {
+			ln, err := net.Listen("tcp", "127.0.0.1:0")
+			So(err, ShouldBeNil)
+			tlsLn := tls.NewListener(ln, &tls.Config{
+				Certificates: []tls.Certificate{selfSignedCert("localhost")},
+				ClientAuth:   tls.RequireAnyClientCert,
+			})
+			go func() { _ = engine.Serve(tlsLn) }()
+			defer func() { _ = engine.Shutdown(context.Background()) }()
+
+			get := func(commonName, target string) int {
+				client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{
+					InsecureSkipVerify: true, //nolint:gosec
+					Certificates:       []tls.Certificate{selfSignedCert(commonName)},
+				}}}
+				var resp *http.Response
+				var err error
+				for i := 0; i < 50; i++ {
+					if resp, err = client.Get("https://" + ln.Addr().String() + target); err == nil {
+						break
+					}
+					time.Sleep(10 * time.Millisecond)
+				}
+				So(err, ShouldBeNil)
+				_ = resp.Body.Close()
+				return resp.StatusCode
+			}
+			So(get("trusted", "/payments"), ShouldEqual, http.StatusOK)
+			So(get("intruder", "/payments"), ShouldEqual, http.StatusForbidden)
+			So(get("intruder", "/internal/metrics"), ShouldEqual, http.StatusOK)
+		})
+
+		Convey("The requirement should be a security scheme in 3.1 documents", func() {
+			engine31 := soda.New()
+			engine31.OpenAPI().OpenAPI = "3.1.0"
+			engine31.Get("/payments", ok).AddMutualTLS("clientCert").OK()
+			So(engine31.OpenAPI().Components.SecuritySchemes["clientCert"].Value.Type, ShouldEqual, "mutualTLS")
+			payments := engine31.OpenAPI().Paths.Value("/payments").Get
+			So(payments.Extensions, ShouldNotContainKey, "x-mutualTLS")
+			So((*payments.Security)[0], ShouldContainKey, "clientCert")
+		})
+	})
+}
@@ -0,0 +1,490 @@
+package soda
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// NamingStrategy derives the property name of a struct field without a json name from its Go name.
+type NamingStrategy func(fieldName string) string
+
+// SetPropertyNaming sets the naming strategy of the fields without a json name, which keep their Go name by
+// default, to the documentation of the operations registered afterwards and to the JSON payloads bound and
+// serialized by soda, such as those of Respond. The JSON sent directly with c.JSON is encoded by the JSON encoder
+// of the app, which should be set to the Marshal method of the strategy, e.g.
+// fiber.Config{JSONEncoder: soda.NamingStrategy(soda.SnakeCase).Marshal}.
+func (e *Engine) SetPropertyNaming(naming NamingStrategy) *Engine {
+	e.gen.naming = naming
+	return e
+}
+
+// propertyName returns the name of the property of the field in the documents of the name tag, naming the
+// fields without a json name with the strategy.
+func (s NamingStrategy) propertyName(field *tagsResolver, nameTag string) string {
+	if s != nil && nameTag == "json" {
+		if name, _, _ := strings.Cut(field.f.Tag.Get("json"), ","); name == "" {
+			return s(field.f.Name)
+		}
+	}
+	return field.name(nameTag)
+}
+
+// namedField is a JSON property of a struct: its name by default, whether it is a json name, its options, and the
+// index and the type of its field.
+type namedField struct {
+	key       string
+	tagged    bool
+	omitEmpty bool
+	quoted    bool
+	index     []int
+	typ       reflect.Type
+}
+
+// name returns the name of the property, with the strategy unless it is a json name.
+func (f namedField) name(s NamingStrategy) string {
+	if f.tagged || s == nil {
+		return f.key
+	}
+	return s(f.key)
+}
+
+var (
+	// namedFieldsCache caches the namedFields of the struct types.
+	namedFieldsCache sync.Map
+	// namesFieldsCache caches whether the values of the types hold fields without a json name.
+	namesFieldsCache sync.Map
+)
+
+// Marshal encodes the value in JSON like json.Marshal, naming the properties of the fields without a json name
+// with the strategy.
+func (s NamingStrategy) Marshal(v any) ([]byte, error) {
+	if s == nil {
+		return json.Marshal(v)
+	}
+	var buf bytes.Buffer
+	if err := s.encode(&buf, reflect.ValueOf(v)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// encode appends the JSON encoding of the value to the buffer, leaving the values without fields to name, such
+// as the scalars and the types encoding themselves, to encoding/json.
+func (s NamingStrategy) encode(buf *bytes.Buffer, v reflect.Value) error {
+	if !v.IsValid() {
+		buf.WriteString("null")
+		return nil
+	}
+	if !namesFields(v.Type()) {
+		data, err := json.Marshal(v.Interface())
+		buf.Write(data)
+		return err
+	}
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			buf.WriteString("null")
+			return nil
+		}
+		return s.encode(buf, v.Elem())
+	case reflect.Struct:
+		buf.WriteByte('{')
+		first := true
+		for _, field := range namedFields(v.Type()) {
+			fv, err := v.FieldByIndexErr(field.index)
+			if err != nil || (field.omitEmpty && isEmptyJSON(fv)) {
+				continue
+			}
+			if !first {
+				buf.WriteByte(',')
+			}
+			first = false
+			key, _ := json.Marshal(field.name(s))
+			buf.Write(key)
+			buf.WriteByte(':')
+			if field.quoted {
+				data, err := json.Marshal(fv.Interface())
+				if err != nil {
+					return err
+				}
+				data, _ = json.Marshal(string(data))
+				buf.Write(data)
+				continue
+			}
+			if err := s.encode(buf, fv); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	case reflect.Map:
+		if v.IsNil() {
+			buf.WriteString("null")
+			return nil
+		}
+		keys := make(map[string]reflect.Value, v.Len())
+		for iter := v.MapRange(); iter.Next(); {
+			key, err := jsonMapKey(iter.Key())
+			if err != nil {
+				return err
+			}
+			keys[key] = iter.Value()
+		}
+		buf.WriteByte('{')
+		for i, key := range sortedKeys(keys) {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			data, _ := json.Marshal(key)
+			buf.Write(data)
+			buf.WriteByte(':')
+			if err := s.encode(buf, keys[key]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			buf.WriteString("null")
+			return nil
+		}
+		buf.WriteByte('[')
+		for i := 0; i < v.Len(); i++ {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := s.encode(buf, v.Index(i)); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	}
+	return nil
+}
+
+// jsonMapKey returns the JSON property of the map key, as encoding/json names it.
+func jsonMapKey(key reflect.Value) (string, error) {
+	if key.Kind() == reflect.String {
+		return key.String(), nil
+	}
+	if marshaler, ok := key.Interface().(encoding.TextMarshaler); ok {
+		text, err := marshaler.MarshalText()
+		return string(text), err
+	}
+	switch key.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(key.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(key.Uint(), 10), nil
+	}
+	return "", &json.UnsupportedTypeError{Type: key.Type()}
+}
+
+// jsonMapKeyOf returns the map key of the type of the JSON property, as encoding/json decodes it.
+func jsonMapKeyOf(key string, t reflect.Type) (reflect.Value, error) {
+	k := reflect.New(t)
+	if unmarshaler, ok := k.Interface().(encoding.TextUnmarshaler); ok {
+		return k.Elem(), unmarshaler.UnmarshalText([]byte(key))
+	}
+	k = k.Elem()
+	switch t.Kind() {
+	case reflect.String:
+		k.SetString(key)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(key, 10, t.Bits())
+		if err != nil {
+			return k, &json.UnmarshalTypeError{Value: "number " + key, Type: t}
+		}
+		k.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(key, 10, t.Bits())
+		if err != nil {
+			return k, &json.UnmarshalTypeError{Value: "number " + key, Type: t}
+		}
+		k.SetUint(n)
+	default:
+		return k, &json.UnsupportedTypeError{Type: t}
+	}
+	return k, nil
+}
+
+// isEmptyJSON reports whether the value is omitted by the omitempty option, as by encoding/json.
+func isEmptyJSON(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Ptr:
+		return v.IsNil()
+	}
+	return false
+}
+
+// Unmarshal decodes the JSON data into the value like json.Unmarshal, expecting the properties of the fields
+// without a json name to be named with the strategy.
+func (s NamingStrategy) Unmarshal(data []byte, v any) error {
+	return s.decode(data, v, &JSONDecoding{})
+}
+
+// decode decodes the JSON data into the value with the options, expecting the properties of the fields without a
+// json name to be named with the strategy.
+func (s NamingStrategy) decode(data []byte, v any, decoding *JSONDecoding) error {
+	rv := reflect.ValueOf(v)
+	if s == nil || rv.Kind() != reflect.Ptr || rv.IsNil() || !namesFields(rv.Type()) {
+		return decoding.unmarshal(data, v)
+	}
+	return s.decodeValue(data, rv.Elem(), decoding)
+}
+
+// decodeValue decodes the JSON data into the settable value, leaving the values without fields to name to
+// encoding/json.
+func (s NamingStrategy) decodeValue(data []byte, v reflect.Value, decoding *JSONDecoding) error {
+	if !namesFields(v.Type()) || v.Kind() == reflect.Interface {
+		return decoding.unmarshal(data, v.Addr().Interface())
+	}
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		switch v.Kind() {
+		case reflect.Ptr, reflect.Map, reflect.Slice:
+			v.SetZero()
+		}
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return s.decodeValue(data, v.Elem(), decoding)
+	case reflect.Struct:
+		var object map[string]json.RawMessage
+		if err := json.Unmarshal(data, &object); err != nil {
+			return jsonTypeError(data, v.Type(), err)
+		}
+		fields := namedFields(v.Type())
+		for key, value := range object {
+			i := slices.IndexFunc(fields, func(f namedField) bool { return f.name(s) == key })
+			if i < 0 {
+				i = slices.IndexFunc(fields, func(f namedField) bool { return strings.EqualFold(f.name(s), key) })
+			}
+			if i < 0 {
+				if decoding.DisallowUnknownFields {
+					return fmt.Errorf("json: unknown field %q", key)
+				}
+				continue
+			}
+			field := fields[i]
+			if field.quoted {
+				var quoted string
+				if err := json.Unmarshal(value, &quoted); err != nil {
+					return prefixJSONError(key, err)
+				}
+				value = json.RawMessage(quoted)
+			}
+			if err := s.decodeValue(value, allocFieldByIndex(v, field.index), decoding); err != nil {
+				return prefixJSONError(key, err)
+			}
+		}
+	case reflect.Map:
+		var object map[string]json.RawMessage
+		if err := json.Unmarshal(data, &object); err != nil {
+			return jsonTypeError(data, v.Type(), err)
+		}
+		if v.IsNil() {
+			v.Set(reflect.MakeMapWithSize(v.Type(), len(object)))
+		}
+		for key, value := range object {
+			k, err := jsonMapKeyOf(key, v.Type().Key())
+			if err != nil {
+				return prefixJSONError(key, err)
+			}
+			elem := reflect.New(v.Type().Elem()).Elem()
+			if err := s.decodeValue(value, elem, decoding); err != nil {
+				return prefixJSONError(key, err)
+			}
+			v.SetMapIndex(k, elem)
+		}
+	case reflect.Slice, reflect.Array:
+		var array []json.RawMessage
+		if err := json.Unmarshal(data, &array); err != nil {
+			return jsonTypeError(data, v.Type(), err)
+		}
+		if v.Kind() == reflect.Slice {
+			v.Set(reflect.MakeSlice(v.Type(), len(array), len(array)))
+		}
+		for i := 0; i < len(array) && i < v.Len(); i++ {
+			if err := s.decodeValue(array[i], v.Index(i), decoding); err != nil {
+				return prefixJSONError(strconv.Itoa(i), err)
+			}
+		}
+	}
+	return nil
+}
+
+// allocFieldByIndex returns the field of the struct at the index, allocating the nil embedded pointers.
+func allocFieldByIndex(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
+
+// jsonTypeError returns the error of the JSON data which can't be decoded into a value of the type.
+func jsonTypeError(data []byte, t reflect.Type, err error) error {
+	var typeErr *json.UnmarshalTypeError
+	if !errors.As(err, &typeErr) {
+		return err
+	}
+	return &json.UnmarshalTypeError{Value: typeErr.Value, Type: t, Offset: typeErr.Offset}
+}
+
+// prefixJSONError prefixes the field of the error of the decoding of the value of the property, if any, with it.
+func prefixJSONError(key string, err error) error {
+	var typeErr *json.UnmarshalTypeError
+	if !errors.As(err, &typeErr) {
+		return err
+	}
+	prefixed := *typeErr
+	prefixed.Field = key
+	if typeErr.Field != "" {
+		prefixed.Field = key + "." + typeErr.Field
+	}
+	return &prefixed
+}
+
+// namesFields reports whether the values of the type may hold struct fields without a json name, which the types
+// encoding themselves, e.g. time.Time, don't.
+func namesFields(t reflect.Type) bool {
+	if cached, ok := namesFieldsCache.Load(t); ok {
+		return cached.(bool)
+	}
+	// The recursive types are assumed to hold such fields while resolved.
+	namesFieldsCache.Store(t, true)
+	names := false
+	if !customJSON(t) {
+		switch t.Kind() {
+		case reflect.Interface:
+			names = true
+		case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+			names = namesFields(t.Elem())
+		case reflect.Struct:
+			names = slices.ContainsFunc(namedFields(t), func(f namedField) bool { return !f.tagged || namesFields(f.typ) })
+		}
+	}
+	namesFieldsCache.Store(t, names)
+	return names
+}
+
+// customJSON reports whether the type encodes or decodes itself, e.g. time.Time.
+func customJSON(t reflect.Type) bool {
+	pt := reflect.PointerTo(t)
+	return t.Implements(jsonMarshalerType) || pt.Implements(jsonMarshalerType) || pt.Implements(jsonUnmarshalerType) ||
+		t.Implements(textMarshalerType) || pt.Implements(textUnmarshalerType)
+}
+
+var (
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// namedFields returns the JSON properties of the struct type, including those of its embedded structs.
+func namedFields(t reflect.Type) []namedField {
+	if cached, ok := namedFieldsCache.Load(t); ok {
+		return cached.([]namedField)
+	}
+	var fields []namedField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" || (!f.IsExported() && !f.Anonymous) {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		ft := f.Type
+		for ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if f.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+			for _, embedded := range namedFields(ft) {
+				embedded.index = append([]int{i}, embedded.index...)
+				fields = append(fields, embedded)
+			}
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		options := strings.Split(opts, ",")
+		field := namedField{key: name, tagged: true, index: []int{i}, typ: f.Type,
+			omitEmpty: slices.Contains(options, "omitempty"), quoted: slices.Contains(options, "string")}
+		if name == "" {
+			field.key, field.tagged = f.Name, false
+		}
+		fields = append(fields, field)
+	}
+	namedFieldsCache.Store(t, fields)
+	return fields
+}
+
+// SnakeCase converts a Go name to snake_case, keeping acronyms together, e.g. "UserID" becomes "user_id".
+func SnakeCase(name string) string {
+	return strings.Join(splitWords(name), "_")
+}
+
+// CamelCase converts a Go name to camelCase, keeping acronyms together, e.g. "HTTPServer" becomes "httpServer".
+func CamelCase(name string) string {
+	words := splitWords(name)
+	for i := 1; i < len(words); i++ {
+		words[i] = strings.ToUpper(words[i][:1]) + words[i][1:]
+	}
+	return strings.Join(words, "")
+}
+
+// splitWords splits a Go name into lower-cased words, e.g. "HTTPServerID" becomes "http", "server", "id".
+func splitWords(name string) []string {
+	runes := []rune(name)
+	words := make([]string, 0)
+	start := 0
+	for i := 1; i < len(runes); i++ {
+		prev, cur := runes[i-1], runes[i]
+		nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+		switch {
+		case cur == '_':
+			words = append(words, string(runes[start:i]))
+			start = i + 1
+		case unicode.IsUpper(cur) && (unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower)):
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+	}
+	words = append(words, string(runes[start:]))
+
+	result := words[:0]
+	for _, word := range words {
+		if word != "" {
+			result = append(result, strings.ToLower(word))
+		}
+	}
+	return result
+}
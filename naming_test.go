@@ -0,0 +1,113 @@
+package soda_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNaming(t *testing.T) {
+	Convey("Given Go names", t, func() {
+		cases := map[string][2]string{
+			"Name":          {"name", "name"},
+			"UserID":        {"user_id", "userId"},
+			"HTTPServer":    {"http_server", "httpServer"},
+			"OAuth2Token":   {"o_auth2_token", "oAuth2Token"},
+			"already_snake": {"already_snake", "alreadySnake"},
+		}
+		for name, expected := range cases {
+			So(soda.SnakeCase(name), ShouldEqual, expected[0])
+			So(soda.CamelCase(name), ShouldEqual, expected[1])
+		}
+	})
+
+	Convey("Given a struct with and without json names", t, func() {
+		type testStruct struct {
+			UserID    string `json:"id"`
+			FirstName string `json:",omitempty"`
+			LastName  string
+			Hyphen    string  `json:"-,"`
+			Ignored   string  `json:"-"`
+			Nickname  *string `json:"nickname"`
+		}
+
+		Convey("The Go names should be used by default", func() {
+			schema := soda.GenerateSchemaRef(testStruct{}, "json")
+			So(schema.Value.Properties, ShouldContainKey, "id")
+			So(schema.Value.Properties, ShouldContainKey, "FirstName")
+			So(schema.Value.Properties, ShouldContainKey, "LastName")
+			So(schema.Value.Properties, ShouldContainKey, "-")
+			So(schema.Value.Properties, ShouldHaveLength, 5)
+			So(schema.Value.Required, ShouldResemble, []string{"id", "LastName", "-"})
+		})
+
+		Convey("The naming strategy should apply to the fields without a json name", func() {
+			engine := soda.New().SetPropertyNaming(soda.SnakeCase)
+			engine.Get("/names", nil).AddJSONResponse(http.StatusOK, testStruct{}).OK()
+
+			schema := engine.OpenAPI().Components.Schemas["soda_test.testStruct"].Value
+			So(schema.Properties, ShouldContainKey, "id")
+			So(schema.Properties, ShouldContainKey, "first_name")
+			So(schema.Properties, ShouldContainKey, "last_name")
+			So(schema.Properties, ShouldContainKey, "nickname")
+			So(soda.New().OpenAPI().Components.Schemas, ShouldBeEmpty)
+		})
+
+		Convey("The strategy should encode and decode the payloads in the field order", func() {
+			naming := soda.NamingStrategy(soda.SnakeCase)
+			data, err := naming.Marshal(testStruct{UserID: "1", LastName: "Doe", Hyphen: "h"})
+			So(err, ShouldBeNil)
+			So(string(data), ShouldEqual, `{"id":"1","last_name":"Doe","-":"h","nickname":null}`)
+
+			var decoded testStruct
+			So(naming.Unmarshal([]byte(`{"id":"1","first_name":"John","last_name":"Doe"}`), &decoded), ShouldBeNil)
+			So(decoded, ShouldResemble, testStruct{UserID: "1", FirstName: "John", LastName: "Doe"})
+		})
+	})
+
+	Convey("Given an engine with a naming strategy", t, func() {
+		type address struct {
+			ZipCode string
+		}
+		type user struct {
+			UserID    string `json:"id"`
+			FirstName string
+			Address   address
+			Previous  []address
+			CreatedAt time.Time
+		}
+		type input struct {
+			Body user `body:"json"`
+		}
+		engine := soda.New().SetPropertyNaming(soda.SnakeCase)
+		engine.Post("/users", func(c *fiber.Ctx) error {
+			in := soda.GetInput[input](c)
+			return soda.Respond(c, http.StatusOK, in.Body)
+		}).SetInput(input{}).AddJSONResponse(http.StatusOK, user{}).OK()
+
+		Convey("The payloads should be encoded and decoded with the documented names", func() {
+			body := `{"id":"1","first_name":"neo","address":{"zip_code":"75001"},"previous":[{"zip_code":"69001"}],` +
+				`"created_at":"2024-01-02T00:00:00Z"}`
+			req := httptest.NewRequest("POST", "/users", strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := engine.App().Test(req)
+			So(err, ShouldBeNil)
+			So(resp.StatusCode, ShouldEqual, http.StatusOK)
+			data, _ := io.ReadAll(resp.Body)
+			So(string(data), ShouldEqual, body)
+
+			schema := engine.OpenAPI().Components.Schemas["soda_test.user"].Value
+			for _, property := range []string{"address", "created_at", "first_name", "id", "previous"} {
+				So(schema.Properties, ShouldContainKey, property)
+			}
+		})
+	})
+}
@@ -0,0 +1,235 @@
+package soda
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"math"
+	"net/http"
+	"slices"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// ResponseEncoder serializes a response body for a media type.
+type ResponseEncoder func(v any) ([]byte, error)
+
+var (
+	responseEncodersMu sync.RWMutex
+	responseEncoders   = map[string]ResponseEncoder{
+		"application/json":    json.Marshal,
+		"application/xml":     xml.Marshal,
+		"application/yaml":    marshalYAML,
+		"application/msgpack": marshalMsgpack,
+	}
+	responseMediaTypes = []string{"application/json", "application/xml", "application/yaml", "application/msgpack"}
+)
+
+// jsonRepresentedMediaTypes are the built-in media types encoded through the JSON representation of the values,
+// whose properties are named with the naming strategy of the engine.
+var jsonRepresentedMediaTypes = []string{"application/json", "application/yaml", "application/msgpack"}
+
+// RegisterResponseEncoder registers an encoder for a response media type, e.g. "application/cbor",
+// making it available to AddResponse and Respond.
+func RegisterResponseEncoder(mediaType string, encoder ResponseEncoder) {
+	responseEncodersMu.Lock()
+	defer responseEncodersMu.Unlock()
+	if _, ok := responseEncoders[mediaType]; !ok {
+		responseMediaTypes = append(responseMediaTypes, mediaType)
+	}
+	responseEncoders[mediaType] = encoder
+}
+
+// responseEncoder returns the encoder registered for the media type.
+func responseEncoder(mediaType string) (ResponseEncoder, bool) {
+	responseEncodersMu.RLock()
+	defer responseEncodersMu.RUnlock()
+	encoder, ok := responseEncoders[mediaType]
+	return encoder, ok
+}
+
+// registeredMediaTypes returns the media types of the registered encoders, in their order of registration.
+func registeredMediaTypes() []string {
+	responseEncodersMu.RLock()
+	defer responseEncodersMu.RUnlock()
+	return slices.Clone(responseMediaTypes)
+}
+
+// marshalYAML marshals the value through its JSON representation, so property names match the documented ones.
+func marshalYAML(v any) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var generic any
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(generic)
+}
+
+// marshalMsgpack marshals the value in MessagePack through its JSON representation, so property names match the
+// documented ones.
+func marshalMsgpack(v any) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var generic any
+	if err := decoder.Decode(&generic); err != nil {
+		return nil, err
+	}
+	return appendMsgpack(nil, generic), nil
+}
+
+// appendMsgpack appends the MessagePack encoding of a decoded JSON value.
+func appendMsgpack(b []byte, v any) []byte {
+	switch v := v.(type) {
+	case nil:
+		return append(b, 0xc0)
+	case bool:
+		if v {
+			return append(b, 0xc3)
+		}
+		return append(b, 0xc2)
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return appendMsgpackInt(b, n)
+		}
+		f, _ := v.Float64()
+		return binary.BigEndian.AppendUint64(append(b, 0xcb), math.Float64bits(f))
+	case string:
+		b = appendMsgpackLength(b, len(v), 0xa0, 32, 0xd9, 0xda)
+		return append(b, v...)
+	case []any:
+		b = appendMsgpackLength(b, len(v), 0x90, 16, 0, 0xdc)
+		for _, item := range v {
+			b = appendMsgpack(b, item)
+		}
+		return b
+	case map[string]any:
+		b = appendMsgpackLength(b, len(v), 0x80, 16, 0, 0xde)
+		for _, key := range sortedKeys(v) {
+			b = appendMsgpack(append(appendMsgpackLength(b, len(key), 0xa0, 32, 0xd9, 0xda), key...), v[key])
+		}
+		return b
+	}
+	panic(fmt.Sprintf("unexpected JSON value %T", v))
+}
+
+// appendMsgpackLength appends the header of a string, an array or a map of the length: its fix format below the
+// limit, then its 8 bits format if any, then its 16 and 32 bits ones.
+func appendMsgpackLength(b []byte, n int, fix byte, fixLimit int, format8, format16 byte) []byte {
+	switch {
+	case n < fixLimit:
+		return append(b, fix|byte(n))
+	case format8 != 0 && n <= math.MaxUint8:
+		return append(b, format8, byte(n))
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(b, format16), uint16(n))
+	default:
+		return binary.BigEndian.AppendUint32(append(b, format16+1), uint32(n))
+	}
+}
+
+// appendMsgpackInt appends an integer in its shortest MessagePack format.
+func appendMsgpackInt(b []byte, n int64) []byte {
+	switch {
+	case n >= 0 && n <= math.MaxInt8:
+		return append(b, byte(n))
+	case n < 0 && n >= -32:
+		return append(b, byte(n))
+	case n >= 0 && n <= math.MaxUint8:
+		return append(b, 0xcc, byte(n))
+	case n >= 0 && n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(b, 0xcd), uint16(n))
+	case n >= 0 && n <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(b, 0xce), uint32(n))
+	case n >= 0:
+		return binary.BigEndian.AppendUint64(append(b, 0xcf), uint64(n))
+	case n >= math.MinInt8:
+		return append(b, 0xd0, byte(n))
+	case n >= math.MinInt16:
+		return binary.BigEndian.AppendUint16(append(b, 0xd1), uint16(n))
+	case n >= math.MinInt32:
+		return binary.BigEndian.AppendUint32(append(b, 0xd2), uint32(n))
+	default:
+		return binary.BigEndian.AppendUint64(append(b, 0xd3), uint64(n))
+	}
+}
+
+// AddResponse adds a response to the operation, documented for each of the media types.
+// Adding a response with the same status and other media types documents a variant of the response,
+// so that each media type can have its own model.
+// When more than one media type is documented, a 406 Not Acceptable response is documented as well, see Respond.
+func (op *OperationBuilder) AddResponse(code int, model any, mediaTypes ...string) (self *OperationBuilder) {
+	defer op.recoverMisuse(&self)
+	if len(mediaTypes) == 0 {
+		mediaTypes = []string{"application/json"}
+	}
+	response := op.route.gen.GenerateResponse(code, model, mediaTypes[0], "")
+	for _, mt := range mediaTypes[1:] {
+		response.Content[mt] = op.route.gen.GenerateResponse(code, model, mt, "").Content[mt]
+	}
+	if op.responseMediaTypes == nil {
+		op.responseMediaTypes = make(map[int][]string)
+	}
+	// The media types documented by other means, e.g. AddJSONResponse, remain acceptable.
+	if existing := op.operation.Responses.Status(code); existing != nil && existing.Value != nil && op.responseMediaTypes[code] == nil {
+		op.responseMediaTypes[code] = sortedKeys(existing.Value.Content)
+	}
+	op.mergeResponse(code, response, "")
+
+	for _, mt := range mediaTypes {
+		if !slices.Contains(op.responseMediaTypes[code], mt) {
+			op.responseMediaTypes[code] = append(op.responseMediaTypes[code], mt)
+		}
+	}
+	if len(op.responseMediaTypes[code]) > 1 && op.operation.Responses.Status(http.StatusNotAcceptable) == nil {
+		op.operation.AddResponse(http.StatusNotAcceptable, op.route.gen.GenerateResponse(http.StatusNotAcceptable, nil, "", ""))
+	}
+	return op
+}
+
+// Respond serializes the value in the media type that best matches the Accept header of the request.
+// The candidates are the media types documented for the status by AddResponse, or every registered encoder.
+// It returns a 406 Not Acceptable error when nothing matches.
+// The value is first transformed by the response transformers of the operation, then wrapped in an Envelope if enabled.
+func Respond(c *fiber.Ctx, status int, v any) error {
+	v, err := transformResponse(c, status, v)
+	if err != nil {
+		return err
+	}
+	op, _ := c.Locals(keyOperationBuilder).(*OperationBuilder)
+	offers := registeredMediaTypes()
+	if op != nil && len(op.responseMediaTypes[status]) > 0 {
+		offers = op.responseMediaTypes[status]
+	}
+	mt := c.Accepts(offers...)
+	if mt == "" {
+		return fiber.ErrNotAcceptable
+	}
+	if op != nil && op.route.gen.envelope && envelopes(mt) {
+		v = wrapEnvelope(c, status, v)
+	}
+	if op != nil && op.route.gen.naming != nil && slices.Contains(jsonRepresentedMediaTypes, mt) {
+		named, err := op.route.gen.naming.Marshal(v)
+		if err != nil {
+			return err
+		}
+		v = json.RawMessage(named)
+	}
+	encoder, _ := responseEncoder(mt)
+	data, err := encoder(v)
+	if err != nil {
+		return err
+	}
+	c.Set(fiber.HeaderContentType, mt)
+	return c.Status(status).Send(data)
+}
@@ -0,0 +1,128 @@
+package soda_test
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type negotiated struct {
+	Name string `json:"name" xml:"name"`
+}
+
+func TestNegotiation(t *testing.T) {
+	Convey("Given an operation documenting several media types", t, func() {
+		engine := soda.New()
+		engine.Get("/item", func(c *fiber.Ctx) error {
+			return soda.Respond(c, http.StatusOK, negotiated{Name: "soda"})
+		}).AddResponse(http.StatusOK, negotiated{}, "application/json", "application/yaml").OK()
+
+		request := func(accept string) (*http.Response, string) {
+			req, _ := http.NewRequest("GET", "/item", nil)
+			if accept != "" {
+				req.Header.Set("Accept", accept)
+			}
+			resp, _ := engine.App().Test(req)
+			body, _ := io.ReadAll(resp.Body)
+			return resp, string(body)
+		}
+
+		Convey("The media types and the 406 response should be documented", func() {
+			operation := engine.OpenAPI().Paths.Find("/item").Get
+			content := operation.Responses.Status(http.StatusOK).Value.Content
+			So(content, ShouldContainKey, "application/json")
+			So(content, ShouldContainKey, "application/yaml")
+			So(operation.Responses.Status(http.StatusNotAcceptable), ShouldNotBeNil)
+		})
+
+		Convey("The first media type should be used by default", func() {
+			resp, body := request("")
+			So(resp.Header.Get("Content-Type"), ShouldEqual, "application/json")
+			So(body, ShouldEqual, `{"name":"soda"}`)
+		})
+
+		Convey("The Accept header should select the media type", func() {
+			resp, body := request("application/yaml")
+			So(resp.Header.Get("Content-Type"), ShouldEqual, "application/yaml")
+			So(body, ShouldEqual, "name: soda\n")
+		})
+
+		Convey("An undocumented media type should not be acceptable", func() {
+			resp, _ := request("application/xml")
+			So(resp.StatusCode, ShouldEqual, http.StatusNotAcceptable)
+		})
+	})
+
+	Convey("Given an operation documenting a msgpack response", t, func() {
+		engine := soda.New()
+		engine.Get("/stats", func(c *fiber.Ctx) error {
+			return soda.Respond(c, http.StatusOK, map[string]any{
+				"count": 300, "neg": -40, "ok": true, "ratio": 1.5, "tags": []string{"a"},
+			})
+		}).AddResponse(http.StatusOK, map[string]any{}, "application/json", "application/msgpack").OK()
+
+		Convey("It should be encoded in MessagePack", func() {
+			req, _ := http.NewRequest("GET", "/stats", nil)
+			req.Header.Set("Accept", "application/msgpack")
+			resp, _ := engine.App().Test(req)
+			body, _ := io.ReadAll(resp.Body)
+			So(resp.Header.Get("Content-Type"), ShouldEqual, "application/msgpack")
+			So(body, ShouldResemble, []byte("\x85"+
+				"\xa5count\xcd\x01\x2c"+
+				"\xa3neg\xd0\xd8"+
+				"\xa2ok\xc3"+
+				"\xa5ratio\xcb\x3f\xf8\x00\x00\x00\x00\x00\x00"+
+				"\xa4tags\x91\xa1a"))
+		})
+	})
+
+	Convey("Given a custom response encoder", t, func() {
+		soda.RegisterResponseEncoder("text/plain", func(v any) ([]byte, error) {
+			return []byte(v.(negotiated).Name), nil
+		})
+		engine := soda.New()
+		engine.Get("/item", func(c *fiber.Ctx) error {
+			return soda.Respond(c, http.StatusOK, negotiated{Name: "soda"})
+		}).OK()
+
+		Convey("It should be selected through the Accept header", func() {
+			req, _ := http.NewRequest("GET", "/item", nil)
+			req.Header.Set("Accept", "text/plain")
+			resp, _ := engine.App().Test(req)
+			body, _ := io.ReadAll(resp.Body)
+			So(string(body), ShouldEqual, "soda")
+		})
+	})
+
+	Convey("Given a status documented with a model per media type", t, func() {
+		type summary struct {
+			Name string `json:"name"`
+		}
+		engine := soda.New()
+		engine.Get("/report", func(c *fiber.Ctx) error { return nil }).
+			AddJSONResponse(http.StatusOK, negotiated{}, "The report").
+			AddResponse(http.StatusOK, summary{}, "application/yaml").
+			SetResponseDescription(http.StatusNotFound, "No report yet").
+			OK()
+		responses := engine.OpenAPI().Paths.Find("/report").Get.Responses
+
+		Convey("Each media type should keep its model", func() {
+			content := responses.Status(http.StatusOK).Value.Content
+			So(content.Get("application/json").Schema.Ref, ShouldEqual, "#/components/schemas/soda_test.negotiated")
+			So(content.Get("application/yaml").Schema.Ref, ShouldEqual, "#/components/schemas/soda_test.summary")
+			So(*responses.Status(http.StatusOK).Value.Description, ShouldEqual, "The report")
+		})
+
+		Convey("Both media types should be acceptable", func() {
+			So(responses.Status(http.StatusNotAcceptable), ShouldNotBeNil)
+		})
+
+		Convey("The descriptions should be customizable", func() {
+			So(*responses.Status(http.StatusNotFound).Value.Description, ShouldEqual, "No report yet")
+		})
+	})
+}
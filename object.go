@@ -0,0 +1,179 @@
+package soda
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+)
+
+func init() {
+	fieldRuleBuilders[propPatternProperties] = propertyNamesRule
+	fieldRuleBuilders[propPropertyNames] = propertyNamesRule
+	fieldRuleBuilders[propMinProperties] = minPropertiesRule
+	fieldRuleBuilders[propMaxProperties] = maxPropertiesRule
+}
+
+// propertyNamesRule builds the rule enforcing the pattern of the keys of a map field tagged with
+// `oai:"propertyNames=^[a-z]{2}$"` or `oai:"patternProperties=^[a-z]{2}$"`, the values of the map
+// all being described by the schema of its elements.
+func propertyNamesRule(_ reflect.Type, f reflect.StructField, value string) fieldRule {
+	if derefType(f.Type).Kind() != reflect.Map {
+		panic(fmt.Sprintf("the property names of the field %s can only be constrained on maps, not %s", f.Name, f.Type))
+	}
+	pattern, err := regexp.Compile(value)
+	if err != nil {
+		panic(fmt.Sprintf("the pattern of the property names of the field %s is invalid: %v", f.Name, err))
+	}
+	return func(_, v reflect.Value) string {
+		for v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return ""
+			}
+			v = v.Elem()
+		}
+		var invalid []string
+		for _, key := range v.MapKeys() {
+			if name := fmt.Sprint(key.Interface()); !pattern.MatchString(name) {
+				invalid = append(invalid, name)
+			}
+		}
+		if len(invalid) == 0 {
+			return ""
+		}
+		slices.Sort(invalid)
+		return fmt.Sprintf("has the property %q not matching %s", invalid[0], value)
+	}
+}
+
+// minPropertiesRule builds the rule enforcing the minimum number of properties of a map field, or of set fields
+// of a struct field, tagged with `oai:"minProperties=1"`.
+func minPropertiesRule(_ reflect.Type, f reflect.StructField, value string) fieldRule {
+	min := propertiesCount(f, propMinProperties, value)
+	return func(_, v reflect.Value) string {
+		return checkPropertiesCount(v, &min, nil)
+	}
+}
+
+// maxPropertiesRule builds the rule enforcing the maximum number of properties of a map field, or of set fields
+// of a struct field, tagged with `oai:"maxProperties=10"`.
+func maxPropertiesRule(_ reflect.Type, f reflect.StructField, value string) fieldRule {
+	max := propertiesCount(f, propMaxProperties, value)
+	return func(_, v reflect.Value) string {
+		return checkPropertiesCount(v, nil, &max)
+	}
+}
+
+// propertiesCount parses the number of properties of the prop, panicking if the field is not an object.
+func propertiesCount(f reflect.StructField, prop, value string) int {
+	if kind := derefType(f.Type).Kind(); kind != reflect.Map && kind != reflect.Struct {
+		panic(fmt.Sprintf("the %s of the field %s can only be set on maps and structs, not %s", prop, f.Name, f.Type))
+	}
+	count, err := strconv.Atoi(value)
+	if err != nil || count < 0 {
+		panic(fmt.Sprintf("the %s of the field %s must be a number, got %q", prop, f.Name, value))
+	}
+	return count
+}
+
+// checkPropertiesCount returns the reason why the number of properties of the object is out of the bounds, if it is.
+// Nil objects are skipped, as they are left unset by the clients.
+func checkPropertiesCount(v reflect.Value, min, max *int) string {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.Map && v.IsNil() {
+		return ""
+	}
+	return propertiesCountReason(countProperties(v), min, max)
+}
+
+// propertiesCountReason returns the reason why the number of properties is out of the bounds, if it is.
+func propertiesCountReason(count int, min, max *int) string {
+	switch {
+	case min != nil && count < *min:
+		return fmt.Sprintf("must have at least %d %s", *min, pluralProperties(*min))
+	case max != nil && count > *max:
+		return fmt.Sprintf("must have at most %d %s", *max, pluralProperties(*max))
+	}
+	return ""
+}
+
+func pluralProperties(count int) string {
+	if count == 1 {
+		return "property"
+	}
+	return "properties"
+}
+
+// countProperties counts the entries of a map, or the set fields of a struct.
+func countProperties(v reflect.Value) int {
+	if v.Kind() == reflect.Map {
+		return v.Len()
+	}
+	count := 0
+	for _, f := range reflect.VisibleFields(v.Type()) {
+		if f.Anonymous || !f.IsExported() || f.Tag.Get("json") == "-" {
+			continue
+		}
+		if !v.FieldByIndex(f.Index).IsZero() {
+			count++
+		}
+	}
+	return count
+}
+
+// checkBodyProperties enforces the minProperties and maxProperties props of the body field of the input, such as
+// `body:"json,partial" oai:"minProperties=1"` requiring a PATCH to set at least one field. The properties of
+// partial bodies are the provided ones, and the set fields of the other bodies.
+func (op *OperationBuilder) checkBodyProperties(c *fiber.Ctx, body reflect.Value) error {
+	if op.bodyMinProperties == nil && op.bodyMaxProperties == nil {
+		return nil
+	}
+	var reason string
+	if op.inputBodyPartial {
+		reason = propertiesCountReason(len(ProvidedFields(c)), op.bodyMinProperties, op.bodyMaxProperties)
+	} else {
+		reason = checkPropertiesCount(body, op.bodyMinProperties, op.bodyMaxProperties)
+	}
+	if reason == "" {
+		return nil
+	}
+	return NewError(op.bindErrorStatus(), MsgInvalidValue, Translate(c, MsgInvalidValue, "body", reason))
+}
+
+// setBodyPropertiesCount reads the minProperties and maxProperties props of the body field of the input and
+// documents them on the schemas of the request body, which wrap the schemas of the body type so that its
+// component is left untouched.
+func (op *OperationBuilder) setBodyPropertiesCount(field reflect.StructField, body *openapi3.RequestBody) {
+	pairs := newTagsResolver(field).pairs
+	if value, ok := pairs[propMinProperties]; ok {
+		min := propertiesCount(field, propMinProperties, value)
+		op.bodyMinProperties = &min
+	}
+	if value, ok := pairs[propMaxProperties]; ok {
+		max := propertiesCount(field, propMaxProperties, value)
+		op.bodyMaxProperties = &max
+	}
+	if op.bodyMinProperties == nil && op.bodyMaxProperties == nil {
+		return
+	}
+	for _, content := range body.Content {
+		schema := openapi3.NewSchema()
+		schema.AllOf = openapi3.SchemaRefs{content.Schema}
+		if op.bodyMinProperties != nil {
+			schema.MinProps = uint64(*op.bodyMinProperties)
+		}
+		if op.bodyMaxProperties != nil {
+			schema.MaxProps = ptr(uint64(*op.bodyMaxProperties))
+		}
+		content.Schema = schema.NewRef()
+	}
+}
@@ -0,0 +1,147 @@
+package soda_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type translatedProduct struct {
+	Titles  map[string]string `json:"titles"  oai:"propertyNames=^[a-z]{2}(-[A-Z]{2})?$"`
+	Labels  map[string]int    `json:"labels"  oai:"patternProperties=^x-"`
+	Aliases *map[string]bool  `json:"aliases" oai:"propertyNames=^[a-z]+$"`
+}
+
+func TestPropertyNames(t *testing.T) {
+	Convey("Given a body with constrained property names", t, func() {
+		type input struct {
+			Body translatedProduct `body:"json"`
+		}
+		engine := soda.New().SetBindErrorStatus(http.StatusBadRequest)
+		engine.Put("/products", func(c *fiber.Ctx) error { return c.SendStatus(http.StatusNoContent) }).SetInput(input{}).OK()
+
+		put := func(body string) (int, string) {
+			req := httptest.NewRequest("PUT", "/products", strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := engine.App().Test(req)
+			So(err, ShouldBeNil)
+			data, _ := io.ReadAll(resp.Body)
+			return resp.StatusCode, string(data)
+		}
+
+		Convey("The property names should be enforced at bind time", func() {
+			status, _ := put(`{"titles":{"en":"Chair","pt-BR":"Cadeira"},"labels":{"x-size":2}}`)
+			So(status, ShouldEqual, http.StatusNoContent)
+
+			status, body := put(`{"titles":{"en":"Chair","french":"Chaise","EN":"Chair"},"labels":{}}`)
+			So(status, ShouldEqual, http.StatusBadRequest)
+			So(body, ShouldContainSubstring, `"pointer":"/titles"`)
+			So(body, ShouldContainSubstring, `has the property \"EN\" not matching ^[a-z]{2}(-[A-Z]{2})?$`)
+
+			_, body = put(`{"titles":{},"labels":{"size":2}}`)
+			So(body, ShouldContainSubstring, `/labels has the property \"size\" not matching ^x-`)
+
+			_, body = put(`{"titles":{},"labels":{},"aliases":{"Seat":true}}`)
+			So(body, ShouldContainSubstring, `/aliases has the property \"Seat\" not matching ^[a-z]+$`)
+		})
+
+		Convey("The constraints should be documented as extensions", func() {
+			schema := engine.OpenAPI().Paths.Value("/products").Put.RequestBody.Value.Content.Get("application/json").Schema.Value
+			titles := schema.Properties["titles"].Value
+			So(titles.Extensions["x-propertyNames"], ShouldNotBeNil)
+			So(titles.Extensions["x-propertyNames"].(*openapi3.Schema).Pattern, ShouldEqual, "^[a-z]{2}(-[A-Z]{2})?$")
+			labels := schema.Properties["labels"].Value
+			So(labels.Extensions["x-patternProperties"], ShouldContainKey, "^x-")
+		})
+
+		Convey("Constraining the property names of other types should panic", func() {
+			type invalid struct {
+				Body struct {
+					Name string `json:"name" oai:"propertyNames=^[a-z]+$"`
+				} `body:"json"`
+			}
+			So(func() {
+				soda.New().Post("/invalid", func(c *fiber.Ctx) error { return nil }).SetInput(invalid{}).OK()
+			}, ShouldPanicWith, "the property names of the field Name can only be constrained on maps, not string")
+		})
+	})
+}
+
+func TestPropertiesCount(t *testing.T) {
+	Convey("Given bodies with bounds on their number of properties", t, func() {
+		type settings struct {
+			Flags map[string]bool `json:"flags" oai:"minProperties=1;maxProperties=2"`
+		}
+		type settingsInput struct {
+			Body settings `body:"json"`
+		}
+		type patchInput struct {
+			ID   string      `path:"id"`
+			Body partialUser `body:"json,partial" oai:"minProperties=1"`
+		}
+		engine := soda.New().SetBindErrorStatus(http.StatusBadRequest)
+		noContent := func(c *fiber.Ctx) error { return c.SendStatus(http.StatusNoContent) }
+		engine.Put("/settings", noContent).SetInput(settingsInput{}).OK()
+		engine.Patch("/users/:id", noContent).SetInput(patchInput{}).OK()
+
+		send := func(method, path, body string) (int, string) {
+			req := httptest.NewRequest(method, path, strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := engine.App().Test(req)
+			So(err, ShouldBeNil)
+			data, _ := io.ReadAll(resp.Body)
+			return resp.StatusCode, string(data)
+		}
+
+		Convey("The bounds of the fields should be enforced at bind time", func() {
+			status, _ := send("PUT", "/settings", `{"flags":{"dark":true}}`)
+			So(status, ShouldEqual, http.StatusNoContent)
+
+			status, body := send("PUT", "/settings", `{"flags":{}}`)
+			So(status, ShouldEqual, http.StatusBadRequest)
+			So(body, ShouldContainSubstring, `/flags must have at least 1 property`)
+
+			_, body = send("PUT", "/settings", `{"flags":{"a":true,"b":true,"c":false}}`)
+			So(body, ShouldContainSubstring, `/flags must have at most 2 properties`)
+		})
+
+		Convey("An empty PATCH should be rejected", func() {
+			status, _ := send("PATCH", "/users/1", `{"age":0}`)
+			So(status, ShouldEqual, http.StatusNoContent)
+
+			status, body := send("PATCH", "/users/1", `{}`)
+			So(status, ShouldEqual, http.StatusBadRequest)
+			So(body, ShouldContainSubstring, `body must have at least 1 property`)
+		})
+
+		Convey("The bounds should be documented", func() {
+			schema := engine.OpenAPI().Paths.Value("/settings").Put.RequestBody.Value.Content.Get("application/json").Schema.Value
+			flags := schema.Properties["flags"].Value
+			So(flags.MinProps, ShouldEqual, 1)
+			So(*flags.MaxProps, ShouldEqual, 2)
+
+			patch := engine.OpenAPI().Paths.Value("/users/:id").Patch.RequestBody.Value.Content.Get("application/json").Schema
+			So(patch.Value.MinProps, ShouldEqual, 1)
+			So(patch.Value.AllOf[0].Ref, ShouldEqual, "#/components/schemas/soda_test.partialUserPatch")
+			So(engine.OpenAPI().Components.Schemas["soda_test.partialUserPatch"].Value.MinProps, ShouldEqual, 0)
+		})
+
+		Convey("Bounding the properties of other types should panic", func() {
+			type invalid struct {
+				Body struct {
+					Tags []string `json:"tags" oai:"minProperties=1"`
+				} `body:"json"`
+			}
+			So(func() {
+				soda.New().Post("/invalid", noContent).SetInput(invalid{}).OK()
+			}, ShouldPanicWith, "the minProperties of the field Tags can only be set on maps and structs, not []string")
+		})
+	})
+}
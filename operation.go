@@ -1,11 +1,14 @@
 package soda
 
 import (
+	"fmt"
+	"maps"
 	"net/http"
 	"reflect"
 	"slices"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/gofiber/fiber/v2"
@@ -28,19 +31,60 @@ type OperationBuilder struct {
 	method      string
 	patternFull string
 	pattern     string
+	docPath     string
+	info        RouteInfo
 
 	input              reflect.Type
 	inputBody          reflect.Type
 	inputBodyField     string
 	inputBodyMediaType string
+	inputBodyPartial   bool
+	inputBodyOptional  bool
+	inputBodyRaw       bool
+	bodyMinProperties  *int
+	bodyMaxProperties  *int
+	jsonDecoding       *JSONDecoding
+	allowBody          bool
+	inputSkipped       [][]int
+	// inputIn holds the locations of the parameters bound to the fields of the input, whose binders are skipped
+	// otherwise.
+	inputIn map[string]bool
+	// queryAliases holds the names of the query parameters by deprecated alias.
+	queryAliases map[string]string
+	// pathConverters holds the converters of the path parameters by parameter.
+	pathConverters map[string]Converter
+	params         map[string]paramMeta
 
 	handlers []fiber.Handler
 
+	responseMediaTypes map[int][]string
+
 	ignoreAPIDoc bool
+	stability    Stability
+
+	featureFlag       string
+	featureFlagStatus int
+	permissions       []string
+	txProvider        TxProvider
+	noCompression     bool
+	sparseFields      bool
+
+	concurrency           chan struct{}
+	concurrencyRetryAfter time.Duration
+	breaker               *breakerMetrics
+	breakerRetryAfter     time.Duration
+	coalescer             *coalescer
+	bindingMetrics        *bindingMetrics
+	maxResponseSize       int
+	advisoryHeaders       map[string]string
+	misused               bool
 
 	// hooks
 	hooksBeforeBind []HookBeforeBind
 	hooksAfterBind  []HookAfterBind
+
+	requestTransformers  []RequestTransformer
+	responseTransformers []ResponseTransformer
 }
 
 // SetOperationID sets the operation ID of the operation.
@@ -65,6 +109,7 @@ func (op *OperationBuilder) SetDescription(desc string) *OperationBuilder {
 func (op *OperationBuilder) AddTags(tags ...string) *OperationBuilder {
 	// op.operation.Tags = append(op.operation.Tags, tags...)
 	for _, tag := range tags {
+		tag = op.route.namespaceTag(tag)
 		if !slices.Contains(op.operation.Tags, tag) {
 			op.operation.Tags = append(op.operation.Tags, tag)
 		}
@@ -81,8 +126,19 @@ func (op *OperationBuilder) SetDeprecated(deprecated bool) *OperationBuilder {
 	return op
 }
 
+// SetServers overrides the servers of the spec for the operation, e.g. for an upload endpoint served by another host.
+func (op *OperationBuilder) SetServers(urls ...string) *OperationBuilder {
+	servers := make(openapi3.Servers, len(urls))
+	for i, url := range urls {
+		servers[i] = &openapi3.Server{URL: url}
+	}
+	op.operation.Servers = &servers
+	return op
+}
+
 // SetInput sets the input type for the operation.
-func (op *OperationBuilder) SetInput(input any) *OperationBuilder {
+func (op *OperationBuilder) SetInput(input any) (self *OperationBuilder) {
+	defer op.recoverMisuse(&self)
 	inputType := reflect.TypeOf(input)
 	// the input type should be a struct or pointer to a struct
 	for inputType.Kind() == reflect.Ptr {
@@ -93,37 +149,108 @@ func (op *OperationBuilder) SetInput(input any) *OperationBuilder {
 	}
 
 	op.input = inputType
+	op.inputSkipped = skippedFields(inputType, nil)
 	op.setInputBody(inputType)
 
 	op.operation.Parameters = op.route.gen.GenerateParameters(inputType)
+	op.inputIn = bindingLocations(inputType, make(map[string]bool))
+	op.queryAliases = queryAliasesOf(op.operation.Parameters)
+	op.pathConverters = pathConvertersOf(op.operation.Parameters)
 	op.setRequestBody()
 	return op
 }
 
+// bindingLocations adds the locations of the parameters bound to the fields of the input type, documented or not,
+// to the locations.
+func bindingLocations(t reflect.Type, locations map[string]bool) map[string]bool {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tagged := false
+		for _, tag := range [...]string{PathTag, HeaderTag, QueryTag, CookieTag} {
+			if f.Tag.Get(tag) != "" {
+				locations[tag], tagged = true, true
+			}
+		}
+		ft := f.Type
+		for ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if !tagged && f.Anonymous && ft.Kind() == reflect.Struct {
+			bindingLocations(ft, locations)
+		}
+	}
+	return locations
+}
+
 // setInputBody sets the input body from the input type.
 func (op *OperationBuilder) setInputBody(inputType reflect.Type) {
 	for i := 0; i < inputType.NumField(); i++ {
 		if body := inputType.Field(i); body.Tag.Get("body") != "" {
+			mediaType, options, _ := strings.Cut(body.Tag.Get("body"), ",")
 			op.inputBody = body.Type
-			op.inputBodyMediaType = body.Tag.Get("body")
+			op.inputBodyMediaType = mediaType
 			op.inputBodyField = body.Name
+			op.inputBodyPartial = slices.Contains(strings.Split(options, ","), bodyOptionPartial)
+			op.inputBodyOptional = slices.Contains(strings.Split(options, ","), bodyOptionOptional)
+			op.inputBodyRaw = isRawBody(mediaType, body.Type)
+			if !op.inputBodyRaw {
+				checkBodyType(requestMediaType(mediaType), body.Type)
+				buildRules(body.Type)
+			}
 			break
 		}
 	}
 }
 
+// skippedFields returns the index paths of the fields tagged with `oai:"-"`, including those of nested structs.
+// Such fields are computed by hooks rather than provided by clients, so they are reset after binding, unless they
+// are bound to a parameter, which is only hidden from the documentation.
+// When all is set, every settable field of the struct is returned.
+func skippedFields(t reflect.Type, prefix []int, all ...bool) [][]int {
+	var skipped [][]int
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		index := append(slices.Clone(prefix), i)
+		skip := len(all) > 0 && all[0] || f.Tag.Get(OpenAPITag) == "-"
+		switch {
+		case f.Anonymous && f.Type.Kind() == reflect.Struct:
+			skipped = append(skipped, skippedFields(f.Type, index, skip)...)
+		case !f.IsExported():
+			continue
+		case skip && !isParameterField(f):
+			skipped = append(skipped, index)
+		case f.Type.Kind() == reflect.Struct:
+			skipped = append(skipped, skippedFields(f.Type, index)...)
+		}
+	}
+	return skipped
+}
+
+// isParameterField reports whether the field is bound to a parameter, which is bound even if undocumented.
+func isParameterField(f reflect.StructField) bool {
+	for _, tag := range [...]string{PathTag, HeaderTag, QueryTag, CookieTag} {
+		if f.Tag.Get(tag) != "" {
+			return true
+		}
+	}
+	return false
+}
+
 // setRequestBody sets the request body.
 func (op *OperationBuilder) setRequestBody() {
 	if op.inputBodyField == "" {
 		return
 	}
-	op.operation.RequestBody = &openapi3.RequestBodyRef{
-		Value: op.route.gen.GenerateRequestBody(
-			op.operation.OperationID,
-			op.inputBodyMediaType,
-			op.inputBody,
-		),
+	var body *openapi3.RequestBody
+	if op.inputBodyRaw {
+		body = generateRawRequestBody(op.inputBodyMediaType)
+	} else if op.inputBodyPartial {
+		body = op.route.gen.GeneratePartialRequestBody(op.operation.OperationID, op.inputBodyMediaType, op.inputBody)
+	} else {
+		body = op.route.gen.GenerateRequestBody(op.operation.OperationID, op.inputBodyMediaType, op.inputBody)
 	}
+	op.injectRequestBodyTags(body)
+	op.operation.RequestBody = &openapi3.RequestBodyRef{Value: body}
 }
 
 // AddSecurity adds a security scheme to the operation.
@@ -135,17 +262,69 @@ func (op *OperationBuilder) AddSecurity(securityName string, scheme *openapi3.Se
 	return op
 }
 
-// AddJSONResponse adds a JSON response to the operation.
-func (op *OperationBuilder) AddJSONResponse(code int, model any, description ...string) *OperationBuilder {
-	desc := http.StatusText(code)
+// AddJSONResponse adds a JSON response to the operation, described by the optional description.
+// The other media types of the response with the same status, if any, are kept.
+func (op *OperationBuilder) AddJSONResponse(code int, model any, description ...string) (self *OperationBuilder) {
+	defer op.recoverMisuse(&self)
+	desc := ""
+	if len(description) > 0 {
+		desc = description[0]
+	}
+	op.mergeResponse(code, op.route.gen.GenerateResponse(code, model, "application/json", desc), desc)
+	return op
+}
+
+// NoContent documents a response without content with the status, 204 No Content by default.
+// The body written by the handlers for a 204 or 304 status is discarded.
+func (op *OperationBuilder) NoContent(code ...int) *OperationBuilder {
+	status := http.StatusNoContent
+	if len(code) > 0 {
+		status = code[0]
+	}
+	op.operation.AddResponse(status, op.route.gen.GenerateResponse(status, nil, "", ""))
+	return op
+}
+
+// SetDefaultResponse documents the JSON model of the default response, which describes the errors returned with
+// any undocumented status and is relied on by many client generators.
+func (op *OperationBuilder) SetDefaultResponse(model any, description ...string) (self *OperationBuilder) {
+	defer op.recoverMisuse(&self)
+	desc := "Unexpected error"
 	if len(description) > 0 {
 		desc = description[0]
 	}
-	ref := op.route.gen.GenerateResponse(code, model, "application/json", desc)
-	op.operation.AddResponse(code, ref)
+	// The default response is generated as an error one, e.g. when wrapped in an envelope.
+	op.operation.AddResponse(0, op.route.gen.GenerateResponse(http.StatusInternalServerError, model, "application/json", desc))
 	return op
 }
 
+// SetResponseDescription sets the description of the response with the status, which defaults to the status text.
+func (op *OperationBuilder) SetResponseDescription(code int, description string) *OperationBuilder {
+	op.mergeResponse(code, openapi3.NewResponse().WithDescription(description), description)
+	return op
+}
+
+// mergeResponse adds the response to the operation, merging its content into the response with the same status,
+// if any, so that each media type can have its own model. The description is only updated when not empty.
+func (op *OperationBuilder) mergeResponse(code int, response *openapi3.Response, description string) {
+	existing := op.operation.Responses.Status(code)
+	if existing == nil || existing.Value == nil {
+		op.operation.AddResponse(code, response)
+		return
+	}
+	// The existing response may be shared with other operations, e.g. a common response of the router.
+	merged := *existing.Value
+	merged.Content = maps.Clone(merged.Content)
+	if merged.Content == nil && len(response.Content) > 0 {
+		merged.Content = make(openapi3.Content)
+	}
+	maps.Copy(merged.Content, response.Content)
+	if description != "" {
+		merged.Description = &description
+	}
+	op.operation.AddResponse(code, &merged)
+}
+
 // SetIgnoreAPIDoc sets whether to ignore the operation when generating the API doc.
 func (op *OperationBuilder) IgnoreAPIDoc(ignore bool) *OperationBuilder {
 	op.ignoreAPIDoc = ignore
@@ -165,12 +344,108 @@ func (op *OperationBuilder) OnAfterBind(hook HookAfterBind) *OperationBuilder {
 }
 
 // OK finalizes the operation building process.
+// It panics if the route conflicts with a previously registered one, or if the body of the input isn't allowed.
+// Operations less stable than the minimum stability of the engine are discarded.
+// It panics if the engine is frozen.
+// In lenient mode, the panics are recorded instead, and the operations misused before aren't registered.
 func (op *OperationBuilder) OK() {
+	defer op.recoverMisuse(nil)
+	if op.method == "" {
+		panic("the operation template must be routed with Route before OK")
+	}
+	if op.misused {
+		return
+	}
+	if !op.stable() {
+		return
+	}
+	op.route.engine.invalidateSpec()
+	op.checkBody()
+	op.docPath = cleanPath(op.patternFull)
+	if op.route.engine.pathTranslation {
+		op.docPath = op.documentPathTemplate(op.docPath)
+	}
+	op.route.engine.addRoute(op, callSite())
+	if op.inputBodyField != "" && op.route.engine.maxDecompressedBodySize > 0 {
+		op.documentContentEncoding()
+	}
+	op.documentBindError()
+	if op.route.engine.traceContext {
+		op.documentTraceContext()
+	}
+	if op.compressed() {
+		op.documentCompression()
+	}
+	if op.operation.Deprecated || len(op.queryAliases) != 0 {
+		op.AddAdvisoryHeader(fiber.HeaderWarning, warningDescription)
+	}
+	if len(op.advisoryHeaders) != 0 {
+		op.documentAdvisoryHeaders()
+	}
+	responseSizeLimit := op.responseSizeLimit()
+	if responseSizeLimit > 0 {
+		op.documentResponseSizeGuard()
+	}
 	if !op.ignoreAPIDoc {
-		path := cleanPath(op.patternFull)
-		op.route.gen.doc.AddOperation(path, op.method, op.operation)
+		if op.method == MethodQuery {
+			op.addQueryOperation(op.docPath)
+		} else {
+			op.route.gen.doc.AddOperation(op.docPath, op.method, op.operation)
+		}
+		if op.route.engine.describeMethodNotAllowed {
+			op.route.engine.describeAllowedMethods(op.docPath)
+		}
+	}
+	op.bindingMetrics = op.route.engine.addBindingMetrics(op)
+	var handlers []fiber.Handler
+	if op.compressed() {
+		// The compression comes first, so that the error responses written by handle are compressed too.
+		handlers = append(handlers, op.route.engine.compressor)
+	}
+	if responseSizeLimit > 0 {
+		// The guard measures the serialized responses, before their compression.
+		handlers = append(handlers, op.route.engine.addResponseSizeGuard(op, responseSizeLimit).guard)
+	}
+	handlers = append(handlers, op.handle)
+	if op.operation.Deprecated {
+		handlers = append(handlers, op.warnDeprecated)
 	}
-	handlers := append([]fiber.Handler{op.bindInput}, op.handlers...)
+	if op.sparseFields {
+		handlers = append(handlers, op.filterFields())
+	}
+	if op.route.gen.envelope {
+		// The responses are wrapped before their fields are filtered.
+		handlers = append(handlers, op.wrapResponse)
+	}
+	if sink := op.route.engine.auditSink; sink != nil {
+		handlers = append(handlers, op.audit(sink))
+	}
+	if op.route.engine.traceContext {
+		handlers = append(handlers, propagateTraceContext)
+	}
+	if headers := op.route.engine.securityHeaders; headers != nil {
+		handlers = append(handlers, securityHeadersHandler(headers))
+	}
+	if op.featureFlag != "" {
+		handlers = append(handlers, op.checkFeatureFlag)
+	}
+	for _, tag := range op.operation.Tags {
+		handlers = append(handlers, op.route.engine.tagMiddlewares[tag]...)
+	}
+	if op.concurrency != nil {
+		handlers = append(handlers, op.limitConcurrency)
+	}
+	handlers = append(handlers, op.bindInput)
+	if op.coalescer != nil {
+		handlers = append(handlers, op.coalescer.coalesce)
+	}
+	if op.breaker != nil {
+		handlers = append(handlers, op.guardCircuit)
+	}
+	if op.txProvider != nil {
+		handlers = append(handlers, op.transact)
+	}
+	handlers = append(handlers, op.handlers...)
 	op.route.Raw.Add(op.method, op.pattern, handlers...).Name(op.operation.OperationID)
 }
 
@@ -183,44 +458,132 @@ func (op *OperationBuilder) bindInput(ctx *fiber.Ctx) error {
 		}
 	}
 
+	if err := op.checkPermissions(ctx); err != nil {
+		return err
+	}
+
 	if op.input == nil {
 		return ctx.Next()
 	}
 
-	// Bind input
-	input := reflect.New(op.input).Interface()
+	input, err := op.bindRequest(ctx)
+	if err != nil {
+		return err
+	}
 
-	// Bind the input
-	binders := []func(any) error{
-		bindPath(ctx),
-		bindHeader(ctx),
-		ctx.QueryParser,
-		ctx.CookieParser,
+	// Execute Hooks: AfterBind
+	for _, hook := range op.hooksAfterBind {
+		if err := hook(ctx, input); err != nil {
+			return err
+		}
 	}
-	for _, binder := range binders {
-		if err := binder(input); err != nil {
+
+	for _, fn := range op.requestTransformers {
+		if err := fn(ctx, input); err != nil {
 			return err
 		}
 	}
 
+	ctx.Locals(KeyInput, input)
+	return ctx.Next()
+}
+
+// bindRequest binds the parameters and the body of the request to a new input, recording the binding metrics of
+// the operation if enabled.
+func (op *OperationBuilder) bindRequest(ctx *fiber.Ctx) (any, error) {
+	sample := op.startBinding()
+	input := reflect.New(op.input).Interface()
+
+	// Bind the input
+	op.renameQueryAliases(ctx)
+	op.coerceParams(ctx)
+	if binder, ok := input.(Binder); ok {
+		if err := op.bindGenerated(ctx, binder); err != nil {
+			return nil, op.recordBinding(ctx, sample, err)
+		}
+	} else if err := op.bindParams(ctx, input); err != nil {
+		return nil, op.recordBinding(ctx, sample, err)
+	}
+
 	// Bind the request body
 	if op.inputBodyField != "" {
-		body := reflect.New(op.inputBody).Interface()
-		if err := ctx.BodyParser(body); err != nil {
-			return err
+		body, err := op.bindBody(ctx)
+		if err != nil {
+			return nil, op.recordBinding(ctx, sample, op.bindError(ctx, MsgInvalidBody, err))
+		}
+		sample.validate()
+		if err := op.validateBody(ctx, body); err != nil {
+			return nil, op.recordBinding(ctx, sample, err)
 		}
-		reflect.ValueOf(input).Elem().FieldByName(op.inputBodyField).Set(reflect.ValueOf(body).Elem())
+		if err := op.checkBodyProperties(ctx, body); err != nil {
+			return nil, op.recordBinding(ctx, sample, err)
+		}
+		reflect.ValueOf(input).Elem().FieldByName(op.inputBodyField).Set(body)
 	}
 
-	// Execute Hooks: AfterBind
-	for _, hook := range op.hooksAfterBind {
-		if err := hook(ctx, input); err != nil {
-			return err
+	// Reset the fields clients are not allowed to set
+	for _, index := range op.inputSkipped {
+		reflect.ValueOf(input).Elem().FieldByIndex(index).SetZero()
+	}
+	return input, op.recordBinding(ctx, sample, nil)
+}
+
+// bindParams binds the parameters of the request to the input by reflection.
+func (op *OperationBuilder) bindParams(ctx *fiber.Ctx, input any) error {
+	binders := [...]struct {
+		in   string
+		bind func(any) error
+		msg  string
+	}{
+		{openapi3.ParameterInPath, bindPath(ctx, op.pathConverters), MsgInvalidPath},
+		{openapi3.ParameterInHeader, bindHeader(ctx), MsgInvalidHeader},
+		{openapi3.ParameterInQuery, ctx.QueryParser, MsgInvalidQuery},
+		{openapi3.ParameterInCookie, ctx.CookieParser, MsgInvalidCookie},
+	}
+	for _, binder := range binders {
+		if !op.inputIn[binder.in] {
+			continue
+		}
+		if err := binder.bind(input); err != nil {
+			return op.bindError(ctx, binder.msg, err)
 		}
 	}
+	return nil
+}
 
-	ctx.Locals(KeyInput, input)
-	return ctx.Next()
+// bindBody decodes the request body into a new value of the body type.
+func (op *OperationBuilder) bindBody(ctx *fiber.Ctx) (reflect.Value, error) {
+	if maxSize := op.route.engine.maxDecompressedBodySize; maxSize > 0 {
+		if err := decompressBody(ctx, maxSize); err != nil {
+			return reflect.Value{}, err
+		}
+	}
+	body := reflect.New(op.inputBody)
+	if op.inputBodyOptional && len(ctx.Body()) == 0 {
+		return body.Elem(), nil
+	}
+	if op.inputBodyRaw {
+		return bindRaw(ctx, op.inputBody), nil
+	}
+	if isProtobuf(requestMediaType(op.inputBodyMediaType)) {
+		return bindProtobuf(ctx, op.inputBody)
+	}
+	if isTextMediaType(requestMediaType(op.inputBodyMediaType)) {
+		return bindText(ctx, op.inputBody)
+	}
+	if decoding := op.jsonDecodingOf(); decoding != nil {
+		if err := decodeJSON(ctx.Body(), body.Interface(), decoding, op.route.gen.naming); err != nil {
+			return reflect.Value{}, err
+		}
+	} else if err := ctx.BodyParser(body.Interface()); err != nil {
+		return reflect.Value{}, err
+	}
+	if op.inputBodyPartial {
+		if err := bindProvidedFields(ctx); err != nil {
+			return reflect.Value{}, err
+		}
+	}
+	return body.Elem(), nil
 }
 
 var decoderPools = map[string]*sync.Pool{
@@ -236,12 +599,20 @@ func buildDecoder(tag string) *schema.Decoder {
 	return decoder
 }
 
-func bindPath(c *fiber.Ctx) func(any) error {
+func bindPath(c *fiber.Ctx, converters map[string]Converter) func(any) error {
 	return func(out any) error {
 		params := c.Route().Params
 		data := make(map[string][]string, len(params))
 		for _, param := range params {
-			data[param] = append(data[param], c.Params(param))
+			value := c.Params(param)
+			if converter, ok := converters[param]; ok {
+				converted, err := converter.Convert(value)
+				if err != nil {
+					return fmt.Errorf("%s: %w", param, err)
+				}
+				value = converted
+			}
+			data[param] = append(data[param], value)
 		}
 
 		pathDecoder := decoderPools[PathTag].Get().(*schema.Decoder)
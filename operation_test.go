@@ -268,6 +268,114 @@ func TestOperations(t *testing.T) {
 			})
 		})
 
+		Convey("When the input has fields skipped with the oai tag", func() {
+			type embedded struct {
+				Tenant string `query:"tenant"`
+			}
+			type testInput struct {
+				embedded `oai:"-"`
+				Page     int    `query:"page"`
+				UserID   string `query:"user_id" oai:"-"`
+				Body     struct {
+					Name     string `json:"name"`
+					Computed string `json:"computed" oai:"-"`
+				} `body:"json"`
+			}
+			engine := soda.New()
+			engine.
+				Post("/action", func(c *fiber.Ctx) error {
+					in := soda.GetInput[testInput](c)
+					return c.JSON(map[string]any{"tenant": in.Tenant, "user": in.UserID, "computed": in.Body.Computed, "name": in.Body.Name})
+				}).
+				SetInput(testInput{}).
+				OnAfterBind(func(c *fiber.Ctx, input any) error {
+					input.(*testInput).UserID = "from-hook"
+					return nil
+				}).
+				OK()
+
+			Convey("Then the skipped fields should not be documented", func() {
+				operation := engine.OpenAPI().Paths.Find("/action").Post
+				So(operation.Parameters, ShouldHaveLength, 1)
+				So(operation.Parameters[0].Value.Name, ShouldEqual, "page")
+				body := operation.RequestBody.Value.Content["application/json"].Schema.Value
+				So(body.Properties, ShouldNotContainKey, "computed")
+			})
+
+			Convey("And clients should not be able to set the body fields, unlike the hidden parameters", func() {
+				request, _ := http.NewRequest("POST", "/action?tenant=t&user_id=u&page=1", strings.NewReader(`{"name": "n", "computed": "c"}`))
+				request.Header.Add("Content-Type", "application/json")
+				response, _ := engine.App().Test(request)
+				body, _ := io.ReadAll(response.Body)
+				So(string(body), ShouldEqual, `{"computed":"","name":"n","tenant":"t","user":"from-hook"}`)
+			})
+
+			Convey("And the hidden parameters should be bound", func() {
+				type debugInput struct {
+					Debug string `query:"debug" oai:"-"`
+				}
+				engine.Get("/debug", func(c *fiber.Ctx) error {
+					return c.SendString(soda.GetInput[debugInput](c).Debug)
+				}).SetInput(debugInput{}).OK()
+				So(engine.OpenAPI().Paths.Find("/debug").Get.Parameters, ShouldBeEmpty)
+				request, _ := http.NewRequest("GET", "/debug?debug=yes", nil)
+				response, _ := engine.App().Test(request)
+				body, _ := io.ReadAll(response.Body)
+				So(string(body), ShouldEqual, "yes")
+			})
+		})
+
+		Convey("When the operation has its own servers", func() {
+			engine := soda.New()
+			engine.Post("/upload", func(c *fiber.Ctx) error { return nil }).
+				SetServers("https://upload.example.com", "https://cdn.example.com").
+				OK()
+
+			Convey("Then they should be documented", func() {
+				servers := *engine.OpenAPI().Paths.Find("/upload").Post.Servers
+				So(servers, ShouldHaveLength, 2)
+				So(servers[0].URL, ShouldEqual, "https://upload.example.com")
+				So(servers[1].URL, ShouldEqual, "https://cdn.example.com")
+			})
+		})
+
+		Convey("When the operation has a default response", func() {
+			engine := soda.New()
+			engine.Get("/action", func(c *fiber.Ctx) error { return nil }).SetDefaultResponse(soda.HTTPError{}).OK()
+
+			Convey("Then it should be documented as the default response", func() {
+				response := engine.OpenAPI().Paths.Find("/action").Get.Responses.Default().Value
+				So(*response.Description, ShouldEqual, "Unexpected error")
+				So(response.Content.Get("application/json").Schema.Ref, ShouldEqual, "#/components/schemas/soda.HTTPError")
+			})
+		})
+
+		Convey("When the operation has no content", func() {
+			engine := soda.New()
+			engine.
+				Delete("/action", func(c *fiber.Ctx) error {
+					return c.Status(http.StatusNoContent).JSON(map[string]string{"ignored": "body"})
+				}).
+				NoContent().
+				AddJSONResponse(http.StatusNotModified, map[string]string{}).
+				OK()
+
+			Convey("Then the responses should be documented without content", func() {
+				responses := engine.OpenAPI().Paths.Find("/action").Delete.Responses
+				So(responses.Status(http.StatusNoContent).Value.Content, ShouldBeEmpty)
+				So(responses.Status(http.StatusNotModified).Value.Content, ShouldBeEmpty)
+			})
+
+			Convey("And the body written by the handler should be discarded", func() {
+				request, _ := http.NewRequest("DELETE", "/action", nil)
+				response, _ := engine.App().Test(request)
+				So(response.StatusCode, ShouldEqual, http.StatusNoContent)
+				body, _ := io.ReadAll(response.Body)
+				So(body, ShouldBeEmpty)
+				So(response.Header.Get("Content-Type"), ShouldBeEmpty)
+			})
+		})
+
 		Convey("When bind error occurs", func() {
 			type testInput struct {
 				A int `query:"a"`
@@ -368,6 +476,36 @@ func TestOperations(t *testing.T) {
 			So(string(body), ShouldEqual, string(expect))
 		})
 
+		Convey("Bind nested query parameters", func() {
+			type address struct {
+				City string `query:"city" json:"city"`
+				Zip  *int   `query:"zip"  json:"zip,omitempty"`
+			}
+			type filter struct {
+				Field string `query:"field" json:"field"`
+				Op    string `query:"op"    json:"op"`
+			}
+			type nested struct {
+				Address address  `query:"address" json:"address"`
+				Billing *address `query:"billing" json:"billing,omitempty"`
+				Filters []filter `query:"filters" json:"filters"`
+			}
+			engine.Get("/test", func(c *fiber.Ctx) error {
+				return c.JSON(soda.GetInput[nested](c))
+			}).SetInput(nested{}).OK()
+
+			request, _ := http.NewRequest("GET", "/test?address.city=Paris&address.zip=75001&filters[0][field]=name&filters[0][op]=eq&filters.1.field=age", nil)
+			response, _ := engine.App().Test(request)
+			So(response.StatusCode, ShouldEqual, http.StatusOK)
+			body, _ := io.ReadAll(response.Body)
+			zip := 75001
+			expect, _ := json.Marshal(nested{
+				Address: address{City: "Paris", Zip: &zip},
+				Filters: []filter{{Field: "name", Op: "eq"}, {Field: "age"}},
+			})
+			So(string(body), ShouldEqual, string(expect))
+		})
+
 		Convey("Bind Path", func() {
 			engine.Get("/test/:path", func(c *fiber.Ctx) error {
 				in := soda.GetInput[schema](c)
@@ -0,0 +1,137 @@
+package soda
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+)
+
+// paramMeta is the metadata of a parameter read through Params.
+type paramMeta struct {
+	in  string
+	def string
+}
+
+// Params gives typed access to the parameters of a request without materializing the input struct, for hot
+// operations whose parameters are set with SetParams. Their values are read on demand, without allocating.
+type Params struct {
+	c    *fiber.Ctx
+	meta map[string]paramMeta
+}
+
+// SetParams documents the parameters of the input struct, like SetInput, but doesn't bind them: the handlers read
+// them through GetParams instead, trading ergonomics for performance. The input must not have a body.
+func (op *OperationBuilder) SetParams(input any) (self *OperationBuilder) {
+	defer op.recoverMisuse(&self)
+	inputType := derefType(reflect.TypeOf(input))
+	if inputType.Kind() != reflect.Struct {
+		panic("input must be a struct")
+	}
+	op.setInputBody(inputType)
+	if op.inputBodyField != "" {
+		panic("the input of SetParams must not have a body")
+	}
+	op.operation.Parameters = op.route.gen.GenerateParameters(inputType)
+	op.params = make(map[string]paramMeta, len(op.operation.Parameters))
+	for _, param := range op.operation.Parameters {
+		meta := paramMeta{in: param.Value.In}
+		if schema := param.Value.Schema; schema != nil && schema.Value != nil && schema.Value.Default != nil {
+			meta.def = fmt.Sprint(schema.Value.Default)
+		}
+		op.params[param.Value.Name] = meta
+	}
+	return op
+}
+
+// GetParams gets the parameters of the request to an operation whose parameters are set with SetParams.
+func GetParams(c *fiber.Ctx) Params {
+	op, _ := c.Locals(keyOperationBuilder).(*OperationBuilder)
+	if op == nil || op.params == nil {
+		panic("the parameters of the operation are not set with SetParams")
+	}
+	return Params{c: c, meta: op.params}
+}
+
+// GetString returns the value of the parameter, or its documented default if absent.
+// It panics if the parameter is not documented, catching typos early.
+func (p Params) GetString(name string) string {
+	meta, ok := p.meta[name]
+	if !ok {
+		panic("undocumented parameter " + name)
+	}
+	var value string
+	switch meta.in {
+	case openapi3.ParameterInPath:
+		value = p.c.Params(name)
+	case openapi3.ParameterInQuery:
+		value = p.c.Query(name)
+	case openapi3.ParameterInHeader:
+		value = p.c.Get(name)
+	case openapi3.ParameterInCookie:
+		value = p.c.Cookies(name)
+	}
+	if value == "" {
+		return meta.def
+	}
+	return value
+}
+
+// GetInt returns the value of the parameter as an int, zero if absent without a default.
+// The error is a 400 HTTPError which can be returned as is by the handlers.
+func (p Params) GetInt(name string) (int, error) {
+	value := p.GetString(name)
+	if value == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, p.error(name, err)
+	}
+	return n, nil
+}
+
+// GetFloat returns the value of the parameter as a float64, zero if absent without a default.
+// The error is a 400 HTTPError which can be returned as is by the handlers.
+func (p Params) GetFloat(name string) (float64, error) {
+	value := p.GetString(name)
+	if value == "" {
+		return 0, nil
+	}
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, p.error(name, err)
+	}
+	return n, nil
+}
+
+// GetBool returns the value of the parameter as a bool, false if absent without a default.
+// The error is a 400 HTTPError which can be returned as is by the handlers.
+func (p Params) GetBool(name string) (bool, error) {
+	value := p.GetString(name)
+	if value == "" {
+		return false, nil
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, p.error(name, err)
+	}
+	return b, nil
+}
+
+// paramMessages maps the locations of the parameters to the keys of the messages of their errors.
+var paramMessages = map[string]string{
+	openapi3.ParameterInPath:   MsgInvalidPath,
+	openapi3.ParameterInQuery:  MsgInvalidQuery,
+	openapi3.ParameterInHeader: MsgInvalidHeader,
+	openapi3.ParameterInCookie: MsgInvalidCookie,
+}
+
+// error returns the error of the invalid value of the parameter.
+func (p Params) error(name string, err error) error {
+	key := paramMessages[p.meta[name].in]
+	return NewError(http.StatusBadRequest, key, Translate(p.c, key, fmt.Errorf("%s: %w", name, err)))
+}
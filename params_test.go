@@ -0,0 +1,95 @@
+package soda_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestParams(t *testing.T) {
+	Convey("Given an operation reading its parameters through the accessor", t, func() {
+		type params struct {
+			ID     int     `path:"id"`
+			Limit  *int    `query:"limit"  oai:"default=20"`
+			Ratio  float64 `query:"ratio"`
+			Active bool    `query:"active"`
+			Region string  `header:"X-Region"`
+		}
+		engine := soda.New()
+		engine.Get("/users/:id", func(c *fiber.Ctx) error {
+			p := soda.GetParams(c)
+			id, err := p.GetInt("id")
+			if err != nil {
+				return err
+			}
+			limit, err := p.GetInt("limit")
+			if err != nil {
+				return err
+			}
+			ratio, err := p.GetFloat("ratio")
+			if err != nil {
+				return err
+			}
+			active, err := p.GetBool("active")
+			if err != nil {
+				return err
+			}
+			return c.JSON(map[string]any{"id": id, "limit": limit, "ratio": ratio, "active": active, "region": p.GetString("X-Region")})
+		}).SetParams(params{}).OK()
+		var typo any
+		engine.Get("/typo", func(c *fiber.Ctx) error {
+			defer func() { typo = recover() }()
+			soda.GetParams(c).GetString("limt")
+			return nil
+		}).SetParams(params{}).OK()
+
+		get := func(target string) (int, map[string]any) {
+			req := httptest.NewRequest("GET", target, nil)
+			req.Header.Set("X-Region", "eu")
+			resp, err := engine.App().Test(req)
+			So(err, ShouldBeNil)
+			data, _ := io.ReadAll(resp.Body)
+			var body map[string]any
+			_ = json.Unmarshal(data, &body)
+			return resp.StatusCode, body
+		}
+
+		Convey("The parameters should be documented", func() {
+			operation := engine.OpenAPI().Paths.Find("/users/:id").Get
+			So(operation.Parameters, ShouldHaveLength, 5)
+			So(operation.Parameters.GetByInAndName("query", "limit").Schema.Value.Default, ShouldNotBeNil)
+		})
+
+		Convey("The parameters should be read with their types and defaults", func() {
+			status, body := get("/users/7?ratio=0.5&active=true")
+			So(status, ShouldEqual, http.StatusOK)
+			So(body, ShouldResemble, map[string]any{"id": 7.0, "limit": 20.0, "ratio": 0.5, "active": true, "region": "eu"})
+			_, body = get("/users/7?limit=5")
+			So(body["limit"], ShouldEqual, 5)
+		})
+
+		Convey("Invalid values should be reported as bad requests", func() {
+			status, body := get("/users/7?limit=many")
+			So(status, ShouldEqual, http.StatusBadRequest)
+			So(body["code"], ShouldEqual, soda.MsgInvalidQuery)
+		})
+
+		Convey("Undocumented parameters should panic", func() {
+			get("/typo")
+			So(typo, ShouldEqual, "undocumented parameter limt")
+		})
+
+		Convey("Inputs with a body should be rejected", func() {
+			type withBody struct {
+				Body struct{} `body:"json"`
+			}
+			So(func() { engine.Post("/bodies", nil).SetParams(withBody{}) }, ShouldPanic)
+		})
+	})
+}
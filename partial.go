@@ -0,0 +1,116 @@
+package soda
+
+import (
+	"encoding/json"
+	"path"
+	"reflect"
+	"slices"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+)
+
+// bodyOptionPartial marks a request body as a partial update, e.g. `body:"json,partial"`.
+const bodyOptionPartial = "partial"
+
+// GeneratePartialRequestBody generates a request body whose schema is an all-optional variant of the model,
+// e.g. `UserPatch` derived from `User`, suitable for PATCH operations.
+func (g *Generator) GeneratePartialRequestBody(operationID, nameTag string, model reflect.Type) *openapi3.RequestBody {
+	schema := g.generatePartialSchemaRef(model, bodyNameTag(nameTag), operationID+"-body")
+	return openapi3.
+		NewRequestBody().
+		WithRequired(true).
+		WithContent(openapi3.NewContentWithSchemaRef(schema, []string{requestMediaType(nameTag)}))
+}
+
+// generatePartialSchemaRef generates the schema of the model and registers a copy of it without any required
+// properties under the name of the model suffixed with "Patch", along with the copies of its nested schemas.
+func (g *Generator) generatePartialSchemaRef(t reflect.Type, nameTag string, fallbackName string) *openapi3.SchemaRef {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		panic("partial body must be a struct")
+	}
+	name := fallbackName
+	if t.Name() != "" {
+		name = g.generateSchemaName(t)
+	}
+	full := g.generateSchemaRef(nil, t, nameTag, name)
+	if full.Ref == "" {
+		full = openapi3.NewSchemaRef("#/components/schemas/"+name, full.Value)
+	}
+	return g.partialSchemaRef(full)
+}
+
+// partialSchemaRef returns the all-optional variant of the schema, registering a copy of a component under its
+// name suffixed with "Patch" rather than modifying it.
+func (g *Generator) partialSchemaRef(ref *openapi3.SchemaRef) *openapi3.SchemaRef {
+	if ref == nil || isExternalRef(ref) {
+		return ref
+	}
+	if ref.Ref == "" {
+		partial := *ref.Value
+		g.partialize(&partial)
+		return partial.NewRef()
+	}
+	name := path.Base(ref.Ref) + "Patch"
+	if existing, ok := g.doc.Components.Schemas[name]; ok {
+		return openapi3.NewSchemaRef("#/components/schemas/"+name, existing.Value)
+	}
+	partial := *derefSchema(g.doc, ref)
+	// Registered before its properties, so that the recursive schemas reference it.
+	g.doc.Components.Schemas[name] = partial.NewRef()
+	g.partialize(&partial)
+	return openapi3.NewSchemaRef("#/components/schemas/"+name, &partial)
+}
+
+// partialize makes the copy of a schema optional, down to its nested properties and items.
+func (g *Generator) partialize(schema *openapi3.Schema) {
+	schema.Required = nil
+	if len(schema.Properties) != 0 {
+		properties := make(openapi3.Schemas, len(schema.Properties))
+		for name, property := range schema.Properties {
+			properties[name] = g.partialSchemaRef(property)
+		}
+		schema.Properties = properties
+	}
+	if len(schema.AllOf) != 0 {
+		allOf := make(openapi3.SchemaRefs, len(schema.AllOf))
+		for i, s := range schema.AllOf {
+			allOf[i] = g.partialSchemaRef(s)
+		}
+		schema.AllOf = allOf
+	}
+	schema.Items = g.partialSchemaRef(schema.Items)
+}
+
+// bindProvidedFields records the top-level properties present in a partial JSON request body.
+func bindProvidedFields(ctx *fiber.Ctx) error {
+	provided := make(map[string]json.RawMessage)
+	if ctx.Is("json") && len(ctx.Body()) != 0 {
+		if err := json.Unmarshal(ctx.Body(), &provided); err != nil {
+			return err
+		}
+	}
+	fields := make([]string, 0, len(provided))
+	for k := range provided {
+		fields = append(fields, k)
+	}
+	slices.Sort(fields)
+	ctx.Locals(KeyProvidedFields, fields)
+	return nil
+}
+
+// ProvidedFields returns the sorted top-level properties present in a partial request body.
+// Explicit nulls count as provided, so handlers can tell "clear this field" from "leave it untouched".
+func ProvidedFields(c *fiber.Ctx) []string {
+	fields, _ := c.Locals(KeyProvidedFields).([]string)
+	return fields
+}
+
+// Provided reports whether the property was present in a partial request body.
+func Provided(c *fiber.Ctx, property string) bool {
+	_, found := slices.BinarySearch(ProvidedFields(c), property)
+	return found
+}
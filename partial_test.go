@@ -0,0 +1,94 @@
+package soda_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type partialStreet struct {
+	Name string `json:"name"`
+}
+
+type partialAddress struct {
+	City   string        `json:"city"`
+	Street partialStreet `json:"street"`
+}
+
+type partialUser struct {
+	Name     string           `json:"name"`
+	Email    *string          `json:"email"`
+	Age      int              `json:"age"`
+	Address  *partialAddress  `json:"address"`
+	Previous []partialAddress `json:"previous"`
+}
+
+func TestPartialBody(t *testing.T) {
+	Convey("Given an operation with a partial request body", t, func() {
+		type input struct {
+			ID   string      `path:"id"`
+			Body partialUser `body:"json,partial"`
+		}
+		var provided []string
+		var emailProvided, ageProvided bool
+		engine := soda.New()
+		engine.Patch("/users/:id", func(c *fiber.Ctx) error {
+			provided = soda.ProvidedFields(c)
+			emailProvided = soda.Provided(c, "email")
+			ageProvided = soda.Provided(c, "age")
+			return c.SendStatus(http.StatusNoContent)
+		}).SetInput(input{}).OK()
+
+		Convey("The request body should reference an all-optional variant", func() {
+			body := engine.OpenAPI().Paths.Find("/users/:id").Patch.RequestBody.Value
+			schema := body.Content["application/json"].Schema
+			So(schema.Ref, ShouldEqual, "#/components/schemas/soda_test.partialUserPatch")
+			So(schema.Value.Required, ShouldBeEmpty)
+			So(schema.Value.Properties, ShouldContainKey, "name")
+
+			original := engine.OpenAPI().Components.Schemas["soda_test.partialUser"]
+			So(original.Value.Required, ShouldResemble, []string{"name", "age", "previous"})
+		})
+
+		Convey("The nested objects should be optional as well, without altering their components", func() {
+			schema := engine.OpenAPI().Paths.Find("/users/:id").Patch.RequestBody.Value.Content["application/json"].Schema.Value
+			address := schema.Properties["address"]
+			So(address.Ref, ShouldEqual, "#/components/schemas/soda_test.partialAddressPatch")
+			So(address.Value.Required, ShouldBeEmpty)
+			So(address.Value.Properties["street"].Value.Required, ShouldBeEmpty)
+			So(schema.Properties["previous"].Value.Items.Ref, ShouldEqual, "#/components/schemas/soda_test.partialAddressPatch")
+
+			original := engine.OpenAPI().Components.Schemas["soda_test.partialAddress"].Value
+			So(original.Required, ShouldResemble, []string{"city", "street"})
+			So(engine.OpenAPI().Components.Schemas["soda_test.partialStreet"].Value.Required, ShouldResemble, []string{"name"})
+		})
+
+		Convey("The handler should see which fields were provided", func() {
+			request, _ := http.NewRequest("PATCH", "/users/1", strings.NewReader(`{"name": "soda", "email": null}`))
+			request.Header.Add("Content-Type", "application/json")
+			response, err := engine.App().Test(request)
+			So(err, ShouldBeNil)
+			So(response.StatusCode, ShouldEqual, http.StatusNoContent)
+			So(provided, ShouldResemble, []string{"email", "name"})
+			So(emailProvided, ShouldBeTrue)
+			So(ageProvided, ShouldBeFalse)
+		})
+	})
+
+	Convey("Given a partial request body that is not a struct", t, func() {
+		type input struct {
+			Body []string `body:"json,partial"`
+		}
+		engine := soda.New()
+
+		Convey("It should panic", func() {
+			So(func() {
+				engine.Patch("/items", func(c *fiber.Ctx) error { return nil }).SetInput(input{}).OK()
+			}, ShouldPanic)
+		})
+	})
+}
@@ -0,0 +1,113 @@
+package soda
+
+import (
+	"maps"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Extensions documenting the features of the fiber routes which OpenAPI path templates can't express.
+const (
+	// optionalParamExtension marks the path parameters which may be absent, e.g. :id?.
+	optionalParamExtension = "x-optional"
+	// constraintParamExtension holds the fiber constraint of a path parameter, e.g. int or regex(\d+).
+	constraintParamExtension = "x-constraint"
+	// wildcardParamExtension marks the path parameters matching the rest of the path, * or + if it can't be empty.
+	wildcardParamExtension = "x-wildcard"
+)
+
+// regexFiberParam matches the parameters of fiber routes: named ones with their optional constraint and
+// optional marker, and wildcards.
+var regexFiberParam = regexp.MustCompile(`:(\w+)(<[^>]*>)?(\?)?|[*+]`)
+
+// regexConstraintPattern matches the regular expression of a regex constraint.
+var regexConstraintPattern = regexp.MustCompile(`^regex\((.*)\)$`)
+
+// pathTemplateParam is a parameter of a fiber route translated into an OpenAPI path template.
+type pathTemplateParam struct {
+	name       string // name in the path template
+	fiberName  string // name in the route, as read with fiber.Ctx.Params
+	optional   bool
+	constraint string
+	wildcard   string
+}
+
+// SetPathTranslation sets whether the fiber paths of the operations finalized afterwards are documented as OpenAPI
+// path templates, e.g. /users/{id} for /users/:id. Optional parameters, constraints and wildcards are translated
+// into path parameters documented with the x-optional, x-constraint and x-wildcard extensions.
+// The paths are documented as is by default.
+func (e *Engine) SetPathTranslation(enabled bool) *Engine {
+	e.pathTranslation = enabled
+	return e
+}
+
+// translatePath translates the fiber path into an OpenAPI path template and its parameters.
+func translatePath(path string) (string, []pathTemplateParam) {
+	var params []pathTemplateParam
+	wildcards := 0
+	template := regexFiberParam.ReplaceAllStringFunc(path, func(s string) string {
+		m := regexFiberParam.FindStringSubmatch(s)
+		param := pathTemplateParam{name: m[1], fiberName: m[1], optional: m[3] != "", constraint: strings.Trim(m[2], "<>")}
+		if m[1] == "" {
+			wildcards++
+			param.name, param.fiberName, param.wildcard = "wildcard", s, s
+			if wildcards > 1 {
+				param.name += strconv.Itoa(wildcards)
+				param.fiberName += strconv.Itoa(wildcards)
+			}
+		}
+		params = append(params, param)
+		return "{" + param.name + "}"
+	})
+	return template, params
+}
+
+// documentPathTemplate translates the path of the operation into an OpenAPI path template, documenting its
+// parameters, and returns it.
+func (op *OperationBuilder) documentPathTemplate(path string) string {
+	template, params := translatePath(path)
+	for _, tp := range params {
+		param := op.pathParameter(tp)
+		param.Name = tp.name
+		param.Required = true
+		extensions := maps.Clone(param.Extensions)
+		if extensions == nil {
+			extensions = make(map[string]any)
+		}
+		if tp.optional {
+			extensions[optionalParamExtension] = true
+		}
+		if tp.constraint != "" {
+			extensions[constraintParamExtension] = tp.constraint
+			if m := regexConstraintPattern.FindStringSubmatch(tp.constraint); m != nil && param.Schema.Value != nil {
+				// The schema may be shared with other operations.
+				schema := *param.Schema.Value
+				schema.Pattern = m[1]
+				param.Schema = schema.NewRef()
+			}
+		}
+		if tp.wildcard != "" {
+			extensions[wildcardParamExtension] = tp.wildcard
+		}
+		if len(extensions) > 0 {
+			param.Extensions = extensions
+		}
+	}
+	return template
+}
+
+// pathParameter returns the documented path parameter of the route, adding a string one if there is none.
+func (op *OperationBuilder) pathParameter(tp pathTemplateParam) *openapi3.Parameter {
+	for _, ref := range op.operation.Parameters {
+		if ref.Value != nil && ref.Value.In == openapi3.ParameterInPath &&
+			(ref.Value.Name == tp.fiberName || tp.wildcard != "" && ref.Value.Name == tp.wildcard) {
+			return ref.Value
+		}
+	}
+	param := openapi3.NewPathParameter(tp.name).WithSchema(openapi3.NewStringSchema())
+	op.operation.AddParameter(param)
+	return param
+}
@@ -0,0 +1,65 @@
+package soda_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestPathTranslation(t *testing.T) {
+	Convey("Given an engine translating the fiber paths", t, func() {
+		handler := func(c *fiber.Ctx) error { return c.SendString(c.Params("*")) }
+		engine := soda.New().SetPathTranslation(true)
+		type userInput struct {
+			ID int `path:"id"`
+		}
+		engine.Get("/users/:id", handler).SetInput(userInput{}).OK()
+		engine.Get("/orders/:id?", handler).OK()
+		engine.Get(`/items/:id<regex(\d+)>`, handler).OK()
+		engine.Get("/files/*", handler).OK()
+		engine.Get("/mirror/+/to/*", handler).OK()
+
+		param := func(path, name string) map[string]any {
+			operation := engine.OpenAPI().Paths.Value(path).Get
+			p := operation.Parameters.GetByInAndName("path", name)
+			So(p, ShouldNotBeNil)
+			So(p.Required, ShouldBeTrue)
+			return p.Extensions
+		}
+
+		Convey("Named parameters should become templates", func() {
+			So(engine.OpenAPI().Paths.Value("/users/:id"), ShouldBeNil)
+			param("/users/{id}", "id")
+			schema := engine.OpenAPI().Paths.Value("/users/{id}").Get.Parameters.GetByInAndName("path", "id").Schema.Value
+			So(schema.Type.Is("integer"), ShouldBeTrue)
+		})
+
+		Convey("Optional parameters should be marked", func() {
+			So(param("/orders/{id}", "id")["x-optional"], ShouldEqual, true)
+		})
+
+		Convey("Constraints should be documented", func() {
+			p := engine.OpenAPI().Paths.Value("/items/{id}").Get.Parameters.GetByInAndName("path", "id")
+			So(p.Extensions["x-constraint"], ShouldEqual, `regex(\d+)`)
+			So(p.Schema.Value.Pattern, ShouldEqual, `\d+`)
+		})
+
+		Convey("Wildcards should be named", func() {
+			So(param("/files/{wildcard}", "wildcard")["x-wildcard"], ShouldEqual, "*")
+			So(param("/mirror/{wildcard}/to/{wildcard2}", "wildcard")["x-wildcard"], ShouldEqual, "+")
+			So(param("/mirror/{wildcard}/to/{wildcard2}", "wildcard2")["x-wildcard"], ShouldEqual, "*")
+		})
+
+		Convey("The routes should be left untouched", func() {
+			resp, err := engine.App().Test(httptest.NewRequest("GET", "/files/a/b.txt", nil))
+			So(err, ShouldBeNil)
+			So(resp.StatusCode, ShouldEqual, http.StatusOK)
+			So(engine.Routes()[3].Path, ShouldEqual, "/files/*")
+			So(engine.Routes()[3].DocPath, ShouldEqual, "/files/{wildcard}")
+		})
+	})
+}
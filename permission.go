@@ -0,0 +1,55 @@
+package soda
+
+import (
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// PolicyEvaluator reports whether the request is granted the permissions, e.g. from the roles of the
+// authenticated user. A returned error is sent as is.
+type PolicyEvaluator func(c *fiber.Ctx, permissions []string) (bool, error)
+
+// CodeForbidden is the code of the HTTPError returned when the permissions of an operation are not granted.
+const CodeForbidden = "forbidden"
+
+// permissionsExtension documents the permissions required by an operation.
+const permissionsExtension = "x-permissions"
+
+// SetPolicyEvaluator sets the evaluator of the permissions required by the operations.
+// Without an evaluator, the permissions are never granted.
+func (e *Engine) SetPolicyEvaluator(evaluator PolicyEvaluator) *Engine {
+	e.policyEvaluator = evaluator
+	return e
+}
+
+// RequirePermission requires the permissions, e.g. "orders:write", to call the operation. They are evaluated after
+// the authentication by the BeforeBind hooks and before the input is bound, and 403 Forbidden is sent if they are
+// not granted. They are documented with the x-permissions extension.
+func (op *OperationBuilder) RequirePermission(permissions ...string) *OperationBuilder {
+	op.permissions = append(op.permissions, permissions...)
+	if op.operation.Extensions == nil {
+		op.operation.Extensions = make(map[string]any)
+	}
+	op.operation.Extensions[permissionsExtension] = op.permissions
+	return op.AddErrorResponses(http.StatusForbidden)
+}
+
+// checkPermissions returns a 403 HTTPError unless the permissions of the operation are granted.
+func (op *OperationBuilder) checkPermissions(ctx *fiber.Ctx) error {
+	if len(op.permissions) == 0 {
+		return nil
+	}
+	evaluator := op.route.engine.policyEvaluator
+	if evaluator == nil {
+		return NewError(http.StatusForbidden, CodeForbidden, http.StatusText(http.StatusForbidden))
+	}
+	granted, err := evaluator(ctx, op.permissions)
+	if err != nil {
+		return err
+	}
+	if !granted {
+		return NewError(http.StatusForbidden, CodeForbidden, http.StatusText(http.StatusForbidden))
+	}
+	return nil
+}
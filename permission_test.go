@@ -0,0 +1,71 @@
+package soda_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRequirePermission(t *testing.T) {
+	Convey("Given operations requiring permissions", t, func() {
+		handler := func(c *fiber.Ctx) error { return c.SendString("ok") }
+		authenticate := func(c *fiber.Ctx) error {
+			if c.Get("X-User") == "" {
+				return fiber.ErrUnauthorized
+			}
+			c.Locals("perms", strings.Split(c.Get("X-Perms"), ","))
+			return nil
+		}
+		var evaluated []string
+		engine := soda.New().SetPolicyEvaluator(func(c *fiber.Ctx, permissions []string) (bool, error) {
+			evaluated = permissions
+			granted, _ := c.Locals("perms").([]string)
+			for _, p := range permissions {
+				if !slices.Contains(granted, p) {
+					return false, nil
+				}
+			}
+			return true, nil
+		})
+		engine.Post("/orders", handler).OnBeforeBind(authenticate).RequirePermission("orders:write").OK()
+		engine.Delete("/orders", handler).OnBeforeBind(authenticate).RequirePermission("orders:write", "orders:delete").OK()
+
+		call := func(method, user, perms string) int {
+			req := httptest.NewRequest(method, "/orders", nil)
+			req.Header.Set("X-User", user)
+			req.Header.Set("X-Perms", perms)
+			resp, err := engine.App().Test(req)
+			So(err, ShouldBeNil)
+			return resp.StatusCode
+		}
+
+		Convey("The permissions should be documented", func() {
+			operation := engine.OpenAPI().Paths.Find("/orders").Delete
+			So(operation.Extensions["x-permissions"], ShouldResemble, []string{"orders:write", "orders:delete"})
+			So(operation.Responses.Status(http.StatusForbidden), ShouldNotBeNil)
+		})
+
+		Convey("The permissions should be evaluated after the authentication", func() {
+			So(call("POST", "", ""), ShouldEqual, http.StatusUnauthorized)
+			So(evaluated, ShouldBeNil)
+			So(call("POST", "ada", "orders:read"), ShouldEqual, http.StatusForbidden)
+			So(call("POST", "ada", "orders:write"), ShouldEqual, http.StatusOK)
+			So(call("DELETE", "ada", "orders:write"), ShouldEqual, http.StatusForbidden)
+			So(evaluated, ShouldResemble, []string{"orders:write", "orders:delete"})
+		})
+
+		Convey("Without an evaluator the permissions should be denied", func() {
+			engine := soda.New()
+			engine.Get("/reports", handler).RequirePermission("reports:read").OK()
+			resp, err := engine.App().Test(httptest.NewRequest("GET", "/reports", nil))
+			So(err, ShouldBeNil)
+			So(resp.StatusCode, ShouldEqual, http.StatusForbidden)
+		})
+	})
+}
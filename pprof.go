@@ -0,0 +1,28 @@
+package soda
+
+import (
+	"net/http"
+	"net/http/pprof"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+)
+
+// EnablePprof serves the profiles of net/http/pprof under the prefix, e.g. /debug/pprof/, behind the middlewares,
+// such as an auth middleware. The profiling endpoints are routed on the app directly, so they are left out of the
+// spec and the routes. They should not be exposed in production without authentication.
+func (e *Engine) EnablePprof(prefix string, middlewares ...fiber.Handler) *Engine {
+	prefix = strings.TrimSuffix(prefix, "/")
+	profiles := e.app.Group(prefix, middlewares...)
+	profiles.Get("/", adaptor.HTTPHandlerFunc(pprof.Index))
+	profiles.Get("/cmdline", adaptor.HTTPHandlerFunc(pprof.Cmdline))
+	profiles.Get("/profile", adaptor.HTTPHandlerFunc(pprof.Profile))
+	profiles.Add(http.MethodGet, "/symbol", adaptor.HTTPHandlerFunc(pprof.Symbol))
+	profiles.Add(http.MethodPost, "/symbol", adaptor.HTTPHandlerFunc(pprof.Symbol))
+	profiles.Get("/trace", adaptor.HTTPHandlerFunc(pprof.Trace))
+	profiles.Get("/:name", func(c *fiber.Ctx) error {
+		return adaptor.HTTPHandler(pprof.Handler(c.Params("name")))(c)
+	})
+	return e
+}
@@ -0,0 +1,55 @@
+package soda_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestPprof(t *testing.T) {
+	Convey("Given the profiles served behind an auth middleware", t, func() {
+		engine := soda.New().EnablePprof("/debug/pprof", func(c *fiber.Ctx) error {
+			if c.Get("Authorization") != "secret" {
+				return c.SendStatus(http.StatusUnauthorized)
+			}
+			return c.Next()
+		})
+		get := func(target string, authorized bool) (*http.Response, string) {
+			req := httptest.NewRequest("GET", target, nil)
+			if authorized {
+				req.Header.Set("Authorization", "secret")
+			}
+			resp, err := engine.App().Test(req, -1)
+			So(err, ShouldBeNil)
+			body, _ := io.ReadAll(resp.Body)
+			return resp, string(body)
+		}
+
+		Convey("The profiles should be served to the authorized requests", func() {
+			resp, body := get("/debug/pprof/", true)
+			So(resp.StatusCode, ShouldEqual, http.StatusOK)
+			So(body, ShouldContainSubstring, "goroutine")
+
+			resp, _ = get("/debug/pprof/goroutine?debug=1", true)
+			So(resp.StatusCode, ShouldEqual, http.StatusOK)
+
+			resp, _ = get("/debug/pprof/cmdline", true)
+			So(resp.StatusCode, ShouldEqual, http.StatusOK)
+		})
+
+		Convey("The other requests should be rejected by the middleware", func() {
+			resp, _ := get("/debug/pprof/heap", false)
+			So(resp.StatusCode, ShouldEqual, http.StatusUnauthorized)
+		})
+
+		Convey("The profiles should be left out of the spec and the routes", func() {
+			So(engine.OpenAPI().Paths.Len(), ShouldEqual, 0)
+			So(engine.Routes(), ShouldBeEmpty)
+		})
+	})
+}
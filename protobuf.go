@@ -0,0 +1,87 @@
+package soda
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+	"google.golang.org/protobuf/proto"
+)
+
+const mediaTypeProtobuf = "application/x-protobuf"
+
+var protoMessageType = reflect.TypeOf((*proto.Message)(nil)).Elem()
+
+func init() {
+	RegisterResponseEncoder(mediaTypeProtobuf, marshalProtobuf)
+}
+
+// marshalProtobuf encodes a proto.Message in the protobuf wire format.
+func marshalProtobuf(v any) ([]byte, error) {
+	msg, ok := v.(proto.Message)
+	if !ok {
+		return nil, errors.New("protobuf responses must be a proto.Message")
+	}
+	return proto.Marshal(msg)
+}
+
+// isProtobuf reports whether the media type is the protobuf one.
+func isProtobuf(mediaType string) bool {
+	return mediaType == mediaTypeProtobuf
+}
+
+// injectProtobufMessage documents the full name of the proto message of the model, if it is one,
+// through the x-protobuf-message extension of the media type.
+func injectProtobufMessage(mediaType *openapi3.MediaType, model reflect.Type) {
+	for model.Kind() == reflect.Ptr {
+		model = model.Elem()
+	}
+	if !reflect.PointerTo(model).Implements(protoMessageType) {
+		return
+	}
+	msg := reflect.New(model).Interface().(proto.Message)
+	mediaType.Extensions = map[string]any{
+		"x-protobuf-message": string(msg.ProtoReflect().Descriptor().FullName()),
+	}
+}
+
+// bindProtobuf decodes a protobuf request body into a new value of the body type,
+// which must be a proto message or a pointer to one.
+func bindProtobuf(ctx *fiber.Ctx, bodyType reflect.Type) (reflect.Value, error) {
+	msgType := bodyType
+	if msgType.Kind() == reflect.Ptr {
+		msgType = msgType.Elem()
+	}
+	msg := reflect.New(msgType)
+	if err := proto.Unmarshal(ctx.Body(), msg.Interface().(proto.Message)); err != nil {
+		return reflect.Value{}, err
+	}
+	if bodyType.Kind() == reflect.Ptr {
+		return msg, nil
+	}
+	return msg.Elem(), nil
+}
+
+// AddProtobufResponse adds an application/x-protobuf response to the operation, documented by
+// the JSON-shaped schema of the message and the x-protobuf-message extension.
+func (op *OperationBuilder) AddProtobufResponse(code int, msg proto.Message, description ...string) (self *OperationBuilder) {
+	defer op.recoverMisuse(&self)
+	desc := http.StatusText(code)
+	if len(description) > 0 {
+		desc = description[0]
+	}
+	op.operation.AddResponse(code, op.route.gen.GenerateResponse(code, msg, mediaTypeProtobuf, desc))
+	return op
+}
+
+// SendProtobuf writes the message in the protobuf wire format.
+func SendProtobuf(c *fiber.Ctx, status int, msg proto.Message) error {
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	c.Set(fiber.HeaderContentType, mediaTypeProtobuf)
+	return c.Status(status).Send(data)
+}
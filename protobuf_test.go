@@ -0,0 +1,64 @@
+package soda_test
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestProtobuf(t *testing.T) {
+	Convey("Given an operation consuming and producing protobuf", t, func() {
+		type input struct {
+			Body *wrapperspb.StringValue `body:"application/x-protobuf"`
+		}
+		engine := soda.New()
+		engine.Post("/echo", func(c *fiber.Ctx) error {
+			in := soda.GetInput[input](c)
+			return soda.SendProtobuf(c, http.StatusOK, wrapperspb.String(in.Body.GetValue()+"!"))
+		}).
+			SetInput(input{}).
+			AddProtobufResponse(http.StatusOK, &wrapperspb.StringValue{}).
+			OK()
+
+		Convey("The request and response should be documented", func() {
+			operation := engine.OpenAPI().Paths.Find("/echo").Post
+			body := operation.RequestBody.Value.Content["application/x-protobuf"]
+			So(body, ShouldNotBeNil)
+			So(body.Extensions["x-protobuf-message"], ShouldEqual, "google.protobuf.StringValue")
+			So(body.Schema.Value.Properties, ShouldContainKey, "value")
+			So(body.Schema.Value.Properties, ShouldHaveLength, 1)
+
+			response := operation.Responses.Status(200).Value.Content["application/x-protobuf"]
+			So(response.Extensions["x-protobuf-message"], ShouldEqual, "google.protobuf.StringValue")
+		})
+
+		Convey("A protobuf body should be bound and a protobuf response sent", func() {
+			payload, _ := proto.Marshal(wrapperspb.String("soda"))
+			request, _ := http.NewRequest("POST", "/echo", bytes.NewReader(payload))
+			request.Header.Add("Content-Type", "application/x-protobuf")
+			response, err := engine.App().Test(request)
+			So(err, ShouldBeNil)
+			So(response.StatusCode, ShouldEqual, http.StatusOK)
+			So(response.Header.Get("Content-Type"), ShouldEqual, "application/x-protobuf")
+
+			body, _ := io.ReadAll(response.Body)
+			msg := &wrapperspb.StringValue{}
+			So(proto.Unmarshal(body, msg), ShouldBeNil)
+			So(msg.GetValue(), ShouldEqual, "soda!")
+		})
+
+		Convey("An invalid protobuf body should fail", func() {
+			request, _ := http.NewRequest("POST", "/echo", bytes.NewReader([]byte{0xff}))
+			request.Header.Add("Content-Type", "application/x-protobuf")
+			response, _ := engine.App().Test(request)
+			So(response.StatusCode, ShouldEqual, http.StatusInternalServerError)
+		})
+	})
+}
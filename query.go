@@ -0,0 +1,66 @@
+package soda
+
+import (
+	"net/http"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+)
+
+// MethodQuery is the QUERY method, a safe and idempotent method with a request body describing the query.
+// The fiber app must support it, e.g. with fiber.Config{RequestMethods: append(fiber.DefaultMethods, soda.MethodQuery)}.
+const MethodQuery = "QUERY"
+
+// queryExtension documents QUERY operations in path items, as OpenAPI 3.0 has no field for them.
+const queryExtension = "x-query"
+
+// Query registers a QUERY operation, documented with the x-query extension of the path item.
+func (r *Router) Query(pattern string, handlers ...fiber.Handler) *OperationBuilder {
+	return r.Add(MethodQuery, pattern, handlers...)
+}
+
+// AllowBody opts in to an optional request body for a GET, HEAD, DELETE or OPTIONS operation, whose semantics
+// are undefined by HTTP, an empty one binding the zero value. Without it, the body field of the input of such an
+// operation, as well as that of a TRACE operation, is neither documented nor bound.
+func (op *OperationBuilder) AllowBody() *OperationBuilder {
+	op.allowBody = true
+	return op
+}
+
+// optionalBody reports whether the request body of the method must be allowed by AllowBody, and is then optional.
+func optionalBody(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodDelete, http.MethodOptions, http.MethodTrace:
+		return true
+	}
+	return false
+}
+
+// checkBody ignores the request body of the operation unless allowed by AllowBody, and makes it optional otherwise.
+func (op *OperationBuilder) checkBody() {
+	if op.inputBodyField == "" || !optionalBody(op.method) {
+		return
+	}
+	if !op.allowBody || op.method == http.MethodTrace {
+		op.inputBodyField = ""
+		op.operation.RequestBody = nil
+		return
+	}
+	op.inputBodyOptional = true
+	if op.operation.RequestBody != nil && op.operation.RequestBody.Value != nil {
+		op.operation.RequestBody.Value.Required = false
+	}
+}
+
+// addQueryOperation documents the QUERY operation in the x-query extension of its path item.
+func (op *OperationBuilder) addQueryOperation(path string) {
+	item := op.route.gen.doc.Paths.Value(path)
+	if item == nil {
+		item = &openapi3.PathItem{}
+		op.route.gen.doc.Paths.Set(path, item)
+	}
+	if item.Extensions == nil {
+		item.Extensions = make(map[string]any)
+	}
+	item.Extensions[queryExtension] = op.operation
+}
@@ -0,0 +1,87 @@
+package soda_test
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type searchInput struct {
+	Body struct {
+		Term string `json:"term"`
+	} `body:"json"`
+}
+
+func searchHandler(c *fiber.Ctx) error {
+	return c.SendString("term=" + soda.GetInput[searchInput](c).Body.Term)
+}
+
+func TestBodyOnSafeMethods(t *testing.T) {
+	Convey("Given a GET operation with a body", t, func() {
+		engine := soda.New()
+
+		Convey("It should be ignored without opting in", func() {
+			engine.Get("/search", searchHandler).SetInput(&searchInput{}).OK()
+			So(engine.OpenAPI().Paths.Find("/search").Get.RequestBody, ShouldBeNil)
+
+			req, _ := http.NewRequest("GET", "/search", strings.NewReader(`{"term":"soda"}`))
+			req.Header.Set("Content-Type", "application/json")
+			resp, _ := engine.App().Test(req)
+			body, _ := io.ReadAll(resp.Body)
+			So(string(body), ShouldEqual, "term=")
+		})
+
+		Convey("A DELETE operation should ignore its body without opting in", func() {
+			So(func() { engine.Delete("/search", searchHandler).SetInput(&searchInput{}).OK() }, ShouldNotPanic)
+			So(engine.OpenAPI().Paths.Find("/search").Delete.RequestBody, ShouldBeNil)
+		})
+
+		Convey("When the body is allowed", func() {
+			engine.Get("/search", searchHandler).SetInput(&searchInput{}).AllowBody().OK()
+
+			Convey("It should be documented as optional", func() {
+				body := engine.OpenAPI().Paths.Find("/search").Get.RequestBody.Value
+				So(body.Required, ShouldBeFalse)
+			})
+
+			Convey("It should be bound when present", func() {
+				req, _ := http.NewRequest("GET", "/search", strings.NewReader(`{"term":"soda"}`))
+				req.Header.Set("Content-Type", "application/json")
+				resp, _ := engine.App().Test(req)
+				body, _ := io.ReadAll(resp.Body)
+				So(string(body), ShouldEqual, "term=soda")
+
+				req, _ = http.NewRequest("GET", "/search", nil)
+				resp, _ = engine.App().Test(req)
+				body, _ = io.ReadAll(resp.Body)
+				So(string(body), ShouldEqual, "term=")
+			})
+		})
+	})
+
+	Convey("Given a QUERY operation", t, func() {
+		engine := soda.NewWith(fiber.New(fiber.Config{RequestMethods: append(fiber.DefaultMethods, soda.MethodQuery)}))
+		engine.Query("/search", searchHandler).SetInput(&searchInput{}).OK()
+
+		Convey("It should be documented with the x-query extension", func() {
+			item := engine.OpenAPI().Paths.Find("/search")
+			So(item.Extensions, ShouldContainKey, "x-query")
+			spec, err := engine.OpenAPI().MarshalJSON()
+			So(err, ShouldBeNil)
+			So(string(spec), ShouldContainSubstring, `"x-query":{`)
+		})
+
+		Convey("It should bind the body", func() {
+			req, _ := http.NewRequest(soda.MethodQuery, "/search", strings.NewReader(`{"term":"soda"}`))
+			req.Header.Set("Content-Type", "application/json")
+			resp, _ := engine.App().Test(req)
+			body, _ := io.ReadAll(resp.Body)
+			So(string(body), ShouldEqual, "term=soda")
+		})
+	})
+}
@@ -0,0 +1,7 @@
+//go:build race
+
+package soda_test
+
+func init() {
+	raceEnabled = true
+}
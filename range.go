@@ -0,0 +1,106 @@
+package soda
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+)
+
+// SendRange sends the content, honoring a single byte range requested by the Range header with a 206 Partial Content
+// response, or 416 Range Not Satisfiable if it is out of bounds. Without a Range header, or with several ranges,
+// the whole content is sent. The content type is left to the caller.
+func SendRange(c *fiber.Ctx, content io.ReadSeeker) error {
+	size, err := content.Seek(0, io.SeekEnd)
+	if err != nil {
+		return err
+	}
+	c.Set(fiber.HeaderAcceptRanges, "bytes")
+	start, end, ok := parseRange(c.Get(fiber.HeaderRange), size)
+	switch {
+	case !ok:
+		c.Set(fiber.HeaderContentRange, fmt.Sprintf("bytes */%d", size))
+		return c.SendStatus(http.StatusRequestedRangeNotSatisfiable)
+	case end-start+1 < size:
+		c.Status(http.StatusPartialContent)
+		c.Set(fiber.HeaderContentRange, fmt.Sprintf("bytes %d-%d/%d", start, end, size))
+	}
+	if _, err := content.Seek(start, io.SeekStart); err != nil {
+		return err
+	}
+	length := end - start + 1
+	c.Context().SetBodyStream(io.LimitReader(content, length), int(length))
+	return nil
+}
+
+// parseRange parses the single byte range of the header within the content of the size, returning the whole
+// content if the header is empty or has several ranges, and false if the range is not satisfiable.
+func parseRange(header string, size int64) (start, end int64, ok bool) {
+	spec, found := strings.CutPrefix(header, "bytes=")
+	if !found || strings.Contains(spec, ",") {
+		return 0, size - 1, true
+	}
+	first, last, found := strings.Cut(strings.TrimSpace(spec), "-")
+	if !found {
+		return 0, 0, false
+	}
+	if first == "" {
+		// A suffix range requests the last bytes.
+		n, err := strconv.ParseInt(last, 10, 64)
+		if err != nil || n <= 0 || size == 0 {
+			return 0, 0, false
+		}
+		return max(size-n, 0), size - 1, true
+	}
+	start, err := strconv.ParseInt(first, 10, 64)
+	if err != nil || start < 0 || start >= size {
+		return 0, 0, false
+	}
+	end = size - 1
+	if last != "" {
+		if end, err = strconv.ParseInt(last, 10, 64); err != nil || end < start {
+			return 0, 0, false
+		}
+		end = min(end, size-1)
+	}
+	return start, end, true
+}
+
+// AcceptRanges documents an operation serving content with SendRange: the Range header, and the 200, 206 and 416
+// responses with their headers. The media type of the content defaults to application/octet-stream.
+func (op *OperationBuilder) AcceptRanges(mediaType ...string) *OperationBuilder {
+	mt := "application/octet-stream"
+	if len(mediaType) > 0 {
+		mt = mediaType[0]
+	}
+	op.operation.AddParameter(openapi3.NewHeaderParameter(fiber.HeaderRange).
+		WithDescription("The byte range of the content to send, e.g. bytes=0-1023").
+		WithSchema(openapi3.NewStringSchema()))
+
+	header := func(description string) *openapi3.HeaderRef {
+		return &openapi3.HeaderRef{Value: &openapi3.Header{Parameter: openapi3.Parameter{
+			Description: description,
+			Schema:      openapi3.NewStringSchema().NewRef(),
+		}}}
+	}
+	content := openapi3.NewContentWithSchema(openapi3.NewStringSchema().WithFormat("binary"), []string{mt})
+	acceptRanges := header("The unit of the ranges accepted, bytes")
+	contentRange := header("The range of the content sent, e.g. bytes 0-1023/4096")
+
+	full := op.route.gen.GenerateResponse(http.StatusOK, nil, "", "").WithContent(content)
+	full.Headers = openapi3.Headers{fiber.HeaderAcceptRanges: acceptRanges}
+	op.operation.AddResponse(http.StatusOK, full)
+
+	partial := op.route.gen.GenerateResponse(http.StatusPartialContent, nil, "", "").WithContent(content)
+	partial.Headers = openapi3.Headers{fiber.HeaderAcceptRanges: acceptRanges, fiber.HeaderContentRange: contentRange}
+	op.operation.AddResponse(http.StatusPartialContent, partial)
+
+	unsatisfiable := op.route.gen.GenerateResponse(http.StatusRequestedRangeNotSatisfiable, nil, "", "")
+	unsatisfiable.Headers = openapi3.Headers{fiber.HeaderContentRange: header("The size of the content, e.g. bytes */4096")}
+	op.operation.AddResponse(http.StatusRequestedRangeNotSatisfiable, unsatisfiable)
+	return op
+}
@@ -0,0 +1,75 @@
+package soda_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSendRange(t *testing.T) {
+	Convey("Given an operation serving content with ranges", t, func() {
+		engine := soda.New()
+		engine.Get("/media", func(c *fiber.Ctx) error {
+			return soda.SendRange(c, strings.NewReader("0123456789"))
+		}).AcceptRanges("video/mp4").OK()
+
+		get := func(rng string) (*http.Response, string) {
+			req := httptest.NewRequest("GET", "/media", nil)
+			if rng != "" {
+				req.Header.Set("Range", rng)
+			}
+			resp, err := engine.App().Test(req)
+			So(err, ShouldBeNil)
+			body, _ := io.ReadAll(resp.Body)
+			return resp, string(body)
+		}
+
+		Convey("It should be documented", func() {
+			operation := engine.OpenAPI().Paths.Find("/media").Get
+			So(operation.Parameters.GetByInAndName("header", "Range"), ShouldNotBeNil)
+			partial := operation.Responses.Status(http.StatusPartialContent).Value
+			So(partial.Headers, ShouldContainKey, "Content-Range")
+			So(partial.Content.Get("video/mp4").Schema.Value.Format, ShouldEqual, "binary")
+			So(operation.Responses.Status(http.StatusRequestedRangeNotSatisfiable), ShouldNotBeNil)
+		})
+
+		Convey("Without a range the whole content should be sent", func() {
+			resp, body := get("")
+			So(resp.StatusCode, ShouldEqual, http.StatusOK)
+			So(resp.Header.Get("Accept-Ranges"), ShouldEqual, "bytes")
+			So(body, ShouldEqual, "0123456789")
+		})
+
+		Convey("Ranges should be sent as partial content", func() {
+			for rng, expected := range map[string][2]string{
+				"bytes=2-4":  {"234", "bytes 2-4/10"},
+				"bytes=7-":   {"789", "bytes 7-9/10"},
+				"bytes=-2":   {"89", "bytes 8-9/10"},
+				"bytes=8-20": {"89", "bytes 8-9/10"},
+			} {
+				resp, body := get(rng)
+				So(resp.StatusCode, ShouldEqual, http.StatusPartialContent)
+				So(body, ShouldEqual, expected[0])
+				So(resp.Header.Get("Content-Range"), ShouldEqual, expected[1])
+			}
+		})
+
+		Convey("Unsatisfiable ranges should be rejected", func() {
+			resp, _ := get("bytes=10-")
+			So(resp.StatusCode, ShouldEqual, http.StatusRequestedRangeNotSatisfiable)
+			So(resp.Header.Get("Content-Range"), ShouldEqual, "bytes */10")
+		})
+
+		Convey("Several ranges should send the whole content", func() {
+			resp, body := get("bytes=0-1,4-5")
+			So(resp.StatusCode, ShouldEqual, http.StatusOK)
+			So(body, ShouldEqual, "0123456789")
+		})
+	})
+}
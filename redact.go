@@ -0,0 +1,121 @@
+package soda
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// RedactedValue replaces the values of sensitive fields.
+var RedactedValue = "******"
+
+var jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+
+// sensitive reports whether the field is tagged with `oai:"sensitive"` or `oai:"redact"`.
+func (f tagsResolver) sensitive() bool {
+	for _, prop := range []string{propSensitive, propRedact} {
+		if v, ok := f.pairs[prop]; ok && toBool(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// injectOAISensitive marks the schema of a sensitive field and masks its example.
+func (f tagsResolver) injectOAISensitive(schema *openapi3.Schema) {
+	if !f.sensitive() {
+		return
+	}
+	if schema.Extensions == nil {
+		schema.Extensions = make(map[string]any)
+	}
+	schema.Extensions["x-sensitive"] = true
+	if schema.Example != nil {
+		schema.Example = RedactedValue
+	}
+}
+
+// Redact returns a copy of the value as generic JSON data (maps, slices and scalars) in which the fields
+// tagged as sensitive are replaced by RedactedValue. Soda redacts inputs this way whenever it logs or echoes them.
+func Redact(v any) any {
+	if v == nil {
+		return nil
+	}
+	return redactValue(reflect.ValueOf(v))
+}
+
+func redactValue(v reflect.Value) any {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if v.Type().Implements(jsonMarshalerType) || v.Type() == wnTime {
+		return v.Interface()
+	}
+
+	if (v.Kind() == reflect.Map || v.Kind() == reflect.Slice) && v.IsNil() {
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		result := make(map[string]any)
+		redactStruct(v, result)
+		return result
+	case reflect.Map:
+		result := make(map[string]any, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			result[fmt.Sprint(iter.Key().Interface())] = redactValue(iter.Value())
+		}
+		return result
+	case reflect.Slice, reflect.Array:
+		if v.Type() == wnByteSlice {
+			return v.Interface()
+		}
+		result := make([]any, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			result[i] = redactValue(v.Index(i))
+		}
+		return result
+	default:
+		return v.Interface()
+	}
+}
+
+// redactStruct copies the exported fields of the struct into the result, keyed by their JSON names.
+func redactStruct(v reflect.Value, result map[string]any) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Tag.Get("json") == "-" {
+			continue
+		}
+		if f.Anonymous && f.Tag.Get("json") == "" {
+			embedded := v.Field(i)
+			for embedded.Kind() == reflect.Ptr {
+				if embedded.IsNil() {
+					break
+				}
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				redactStruct(embedded, result)
+			}
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		field := newTagsResolver(f)
+		if field.sensitive() {
+			result[field.name("json")] = RedactedValue
+			continue
+		}
+		result[field.name("json")] = redactValue(v.Field(i))
+	}
+}
@@ -0,0 +1,67 @@
+package soda_test
+
+import (
+	"testing"
+
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRedact(t *testing.T) {
+	type credentials struct {
+		Token string `json:"token" oai:"redact"`
+	}
+	type account struct {
+		credentials
+		Username string            `json:"username"`
+		Password string            `json:"password" oai:"sensitive;example=hunter2"`
+		PIN      *int              `json:"pin"      oai:"sensitive"`
+		Labels   map[string]string `json:"labels"`
+		Friends  []account         `json:"friends"`
+		Internal string            `json:"-"`
+	}
+
+	Convey("Given a model with sensitive fields", t, func() {
+		Convey("The schema should mark them and mask their examples", func() {
+			schema := soda.GenerateSchemaRef(account{}, "json")
+			password := schema.Value.Properties["password"].Value
+			So(password.Extensions["x-sensitive"], ShouldBeTrue)
+			So(password.Example, ShouldEqual, soda.RedactedValue)
+			So(schema.Value.Properties["token"].Value.Extensions["x-sensitive"], ShouldBeTrue)
+			So(schema.Value.Properties["username"].Value.Extensions, ShouldBeNil)
+		})
+
+		Convey("Redact should mask them", func() {
+			pin := 1234
+			redacted := soda.Redact(&account{
+				credentials: credentials{Token: "t0k3n"},
+				Username:    "soda",
+				Password:    "hunter2",
+				PIN:         &pin,
+				Labels:      map[string]string{"a": "b"},
+				Friends:     []account{{Username: "fiber", Password: "secret"}},
+				Internal:    "internal",
+			})
+			So(redacted, ShouldResemble, map[string]any{
+				"token":    soda.RedactedValue,
+				"username": "soda",
+				"password": soda.RedactedValue,
+				"pin":      soda.RedactedValue,
+				"labels":   map[string]any{"a": "b"},
+				"friends": []any{map[string]any{
+					"token":    soda.RedactedValue,
+					"username": "fiber",
+					"password": soda.RedactedValue,
+					"pin":      soda.RedactedValue,
+					"labels":   nil,
+					"friends":  nil,
+				}},
+			})
+		})
+
+		Convey("Redact should keep nil values", func() {
+			So(soda.Redact(nil), ShouldBeNil)
+			So(soda.Redact((*account)(nil)), ShouldBeNil)
+		})
+	})
+}
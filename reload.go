@@ -0,0 +1,74 @@
+package soda
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// defaultReloadInterval is the interval at which the spec reload stream checks the spec for changes.
+const defaultReloadInterval = time.Second
+
+// ServeSpecReload serves a stream of server-sent events notifying the version of the spec when it changes,
+// and makes the doc UI served by ServeDocUI reload the page when the version differs from the displayed one.
+// It is meant for development with hot reload workflows, such as air: the browser reconnects to the stream
+// once the application restarts and reloads the UI only if the contract changed, while the stream is kept
+// alive by a ping every interval, one second by default.
+func (e *Engine) ServeSpecReload(pattern string, interval ...time.Duration) *Engine {
+	every := defaultReloadInterval
+	if len(interval) != 0 {
+		every = interval[0]
+	}
+	e.specReload = pattern
+	e.serveDoc(pattern, func(c *fiber.Ctx) error {
+		c.Set(fiber.HeaderContentType, "text/event-stream")
+		c.Set(fiber.HeaderCacheControl, "no-cache")
+		c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+			version := e.specVersion()
+			fmt.Fprintf(w, "retry: %d\nevent: spec\ndata: %s\n\n", every.Milliseconds(), version)
+			if w.Flush() != nil {
+				return
+			}
+			ticker := time.NewTicker(every)
+			defer ticker.Stop()
+			for range ticker.C {
+				if v := e.specVersion(); v != version {
+					version = v
+					fmt.Fprintf(w, "event: spec\ndata: %s\n\n", version)
+				} else {
+					_, _ = w.WriteString(": ping\n\n")
+				}
+				if w.Flush() != nil {
+					return
+				}
+			}
+		})
+		return nil
+	})
+	return e
+}
+
+// specVersion returns a digest of the spec, which changes with the contract only.
+func (e *Engine) specVersion() string {
+	sum := sha256.Sum256(e.specJSON())
+	return hex.EncodeToString(sum[:8])
+}
+
+// reloadScript returns the script reloading the doc UI when the version of the spec differs from the current one.
+func (e *Engine) reloadScript() string {
+	return `<script>
+    (function () {
+        let version = ` + strconv.Quote(e.specVersion()) + `;
+        new EventSource(` + strconv.Quote(e.specReload) + `).addEventListener("spec", function (event) {
+            if (event.data !== version) {
+                window.location.reload();
+            }
+        });
+    })();
+  </script>`
+}
@@ -0,0 +1,66 @@
+package soda_test
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestServeSpecReload(t *testing.T) {
+	Convey("Given an engine serving the spec reload stream", t, func() {
+		handler := func(c *fiber.Ctx) error { return nil }
+		engine := soda.New().
+			ServeDocUI("/docs", soda.UISwaggerUI).
+			ServeSpecReload("/docs/reload", 10*time.Millisecond)
+		engine.Get("/users", handler).OK()
+
+		Convey("The doc UI should listen to the stream", func() {
+			resp, err := engine.App().Test(httptest.NewRequest("GET", "/docs", nil))
+			So(err, ShouldBeNil)
+			body, _ := io.ReadAll(resp.Body)
+			So(string(body), ShouldContainSubstring, `new EventSource("/docs/reload")`)
+		})
+
+		Convey("The stream should notify the changes of the spec", func() {
+			ln, err := net.Listen("tcp", "127.0.0.1:0")
+			So(err, ShouldBeNil)
+			go func() { _ = engine.App().Listener(ln) }()
+			defer func() { _ = engine.App().ShutdownWithTimeout(time.Second) }()
+
+			resp, err := http.Get("http://" + ln.Addr().String() + "/docs/reload")
+			So(err, ShouldBeNil)
+			defer resp.Body.Close()
+			So(resp.Header.Get("Content-Type"), ShouldEqual, "text/event-stream")
+
+			reader := bufio.NewReader(resp.Body)
+			nextVersion := func() string {
+				for {
+					line, err := reader.ReadString('\n')
+					if err != nil {
+						return ""
+					}
+					if strings.HasPrefix(line, "data: ") {
+						return strings.TrimSpace(strings.TrimPrefix(line, "data: "))
+					}
+				}
+			}
+			first := nextVersion()
+			So(first, ShouldNotBeEmpty)
+
+			engine.UpdateSpec(func(doc *openapi3.T) { doc.Info.Version = "2.0.0" })
+			second := nextVersion()
+			So(second, ShouldNotBeEmpty)
+			So(second, ShouldNotEqual, first)
+		})
+	})
+}
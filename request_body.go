@@ -0,0 +1,182 @@
+package soda
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+)
+
+// bodyOptionOptional marks a request body as optional, e.g. `body:"json,optional"`.
+const bodyOptionOptional = "optional"
+
+// propContentType sets the content type of a property of a multipart or form request body,
+// e.g. `oai:"contentType=image/png"`.
+const propContentType = "contentType"
+
+// SetRequestBodyDescription sets the description of the request body, which can also be set with the
+// description prop of the `oai` tag of the body field. It must be called after SetInput.
+func (op *OperationBuilder) SetRequestBodyDescription(description string) *OperationBuilder {
+	op.requestBody().Description = description
+	return op
+}
+
+// SetRequestBodyRequired sets whether the request body is required, which it is by default unless the
+// body field is tagged with the optional option, e.g. `body:"json,optional"`. It must be called after SetInput.
+func (op *OperationBuilder) SetRequestBodyRequired(required bool) *OperationBuilder {
+	op.requestBody().Required = required
+	op.inputBodyOptional = !required
+	return op
+}
+
+// SetRequestBodyEncoding sets the encoding of a property of the multipart or form request body,
+// such as its content type and headers. It must be called after SetInput.
+func (op *OperationBuilder) SetRequestBodyEncoding(property string, encoding *openapi3.Encoding) *OperationBuilder {
+	for mt, content := range op.requestBody().Content {
+		if isFormMediaType(mt) {
+			content.WithEncoding(property, encoding)
+		}
+	}
+	return op
+}
+
+// requestBody returns the request body of the operation, panicking if it has none.
+func (op *OperationBuilder) requestBody() *openapi3.RequestBody {
+	if op.operation.RequestBody == nil || op.operation.RequestBody.Value == nil {
+		panic("the operation has no request body, the input must be set first")
+	}
+	return op.operation.RequestBody.Value
+}
+
+// injectRequestBodyTags sets the description, the required flag and the encodings of the request body
+// from the tags of the body field and of its properties.
+func (op *OperationBuilder) injectRequestBodyTags(body *openapi3.RequestBody) {
+	field, _ := op.input.FieldByName(op.inputBodyField)
+	body.Description = newTagsResolver(field).pairs[propDescription]
+	body.Required = !op.inputBodyOptional
+	op.setBodyPropertiesCount(field, body)
+
+	t := op.inputBody
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return
+	}
+	for mt, content := range body.Content {
+		if !isFormMediaType(mt) {
+			continue
+		}
+		for i := 0; i < t.NumField(); i++ {
+			f := newTagsResolver(t.Field(i))
+			if contentType := f.pairs[propContentType]; contentType != "" {
+				content.WithEncoding(f.name(bodyNameTag(op.inputBodyMediaType)), &openapi3.Encoding{ContentType: contentType})
+			}
+		}
+	}
+}
+
+// isFormMediaType reports whether the properties of request bodies of the media type can have an encoding.
+func isFormMediaType(mediaType string) bool {
+	return strings.HasPrefix(mediaType, "multipart/") || mediaType == "application/x-www-form-urlencoded"
+}
+
+// checkBodyType panics if the body type can't be bound from the media type: form bodies must be structs and text
+// bodies primitives. JSON bodies can be of any type, e.g. a slice to accept a raw array.
+func checkBodyType(mediaType string, t reflect.Type) {
+	t = derefType(t)
+	switch {
+	case isFormMediaType(mediaType) && t.Kind() != reflect.Struct:
+		panic("the body of media type " + mediaType + " must be a struct")
+	case isTextMediaType(mediaType) && !isPrimitiveKind(t.Kind()):
+		panic("the body of media type " + mediaType + " must be a string, a number or a boolean")
+	}
+}
+
+// isTextMediaType reports whether request bodies of the media type are bound from their raw text.
+func isTextMediaType(mediaType string) bool {
+	return strings.HasPrefix(mediaType, "text/") && responseNameTag(mediaType) != xmlNameTag
+}
+
+// isPrimitiveKind reports whether values of the kind are bound from text.
+func isPrimitiveKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// bindText binds the raw text of the request body to a new value of the primitive type.
+func bindText(ctx *fiber.Ctx, t reflect.Type) (reflect.Value, error) {
+	value := reflect.New(derefType(t)).Elem()
+	text := strings.TrimSpace(string(ctx.Body()))
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(string(ctx.Body()))
+	case reflect.Bool:
+		b, err := strconv.ParseBool(text)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		value.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(text, 10, value.Type().Bits())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		value.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(text, 10, value.Type().Bits())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		value.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(text, value.Type().Bits())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		value.SetFloat(n)
+	}
+	if t.Kind() == reflect.Ptr {
+		return value.Addr(), nil
+	}
+	return value, nil
+}
+
+var readerType = reflect.TypeOf((*io.Reader)(nil)).Elem()
+
+// isRawBody reports whether the body receives the raw payload, unparsed: an io.Reader, or a []byte unless decoded
+// from JSON or XML, where it is base64-encoded.
+func isRawBody(bodyTag string, t reflect.Type) bool {
+	mediaType := requestMediaType(bodyTag)
+	return t == readerType || t == wnByteSlice && !strings.Contains(mediaType, "json") && !strings.Contains(mediaType, "xml")
+}
+
+// generateRawRequestBody generates a request body of binary content of the media type of the body tag.
+func generateRawRequestBody(bodyTag string) *openapi3.RequestBody {
+	mediaType := requestMediaType(bodyTag)
+	schema := openapi3.NewStringSchema().WithFormat("binary")
+	return openapi3.NewRequestBody().
+		WithRequired(true).
+		WithContent(openapi3.NewContentWithSchema(schema, []string{mediaType}))
+}
+
+// bindRaw binds the raw payload of the request to a new value of the raw body type.
+func bindRaw(ctx *fiber.Ctx, t reflect.Type) reflect.Value {
+	// The body is only valid within the handler, so it is copied.
+	payload := slices.Clone(ctx.Body())
+	if t == readerType {
+		return reflect.ValueOf(bytes.NewReader(payload)).Convert(readerType)
+	}
+	return reflect.ValueOf(payload)
+}
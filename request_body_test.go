@@ -0,0 +1,193 @@
+package soda_test
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRequestBodyMetadata(t *testing.T) {
+	Convey("Given an optional request body described by its tag", t, func() {
+		type input struct {
+			Body struct {
+				Name string `json:"name"`
+			} `body:"json,optional" oai:"description=The item to create"`
+		}
+		engine := soda.New()
+		engine.Post("/items", func(c *fiber.Ctx) error {
+			return c.SendString("name=" + soda.GetInput[input](c).Body.Name)
+		}).SetInput(&input{}).OK()
+
+		Convey("It should be documented as optional with its description", func() {
+			body := engine.OpenAPI().Paths.Find("/items").Post.RequestBody.Value
+			So(body.Required, ShouldBeFalse)
+			So(body.Description, ShouldEqual, "The item to create")
+		})
+
+		Convey("An empty body should be accepted", func() {
+			req, _ := http.NewRequest("POST", "/items", nil)
+			resp, _ := engine.App().Test(req)
+			body, _ := io.ReadAll(resp.Body)
+			So(resp.StatusCode, ShouldEqual, http.StatusOK)
+			So(string(body), ShouldEqual, "name=")
+		})
+	})
+
+	Convey("Given a multipart request body", t, func() {
+		type input struct {
+			Body struct {
+				Title  string `json:"title"`
+				Avatar string `json:"avatar" oai:"format=binary;contentType=image/png,image/jpeg"`
+			} `body:"multipart/form-data"`
+		}
+		engine := soda.New()
+		op := engine.Post("/profiles", func(c *fiber.Ctx) error { return nil }).SetInput(&input{})
+
+		Convey("The content types of the properties should be documented", func() {
+			op.SetRequestBodyDescription("The profile").OK()
+			body := engine.OpenAPI().Paths.Find("/profiles").Post.RequestBody.Value
+			So(body.Description, ShouldEqual, "The profile")
+			So(body.Required, ShouldBeTrue)
+			encoding := body.Content.Get("multipart/form-data").Encoding
+			So(encoding["avatar"].ContentType, ShouldEqual, "image/png,image/jpeg")
+			So(encoding, ShouldNotContainKey, "title")
+		})
+
+		Convey("The encodings should be customizable", func() {
+			op.SetRequestBodyEncoding("title", &openapi3.Encoding{
+				Headers: openapi3.Headers{"X-Lang": &openapi3.HeaderRef{Value: &openapi3.Header{Parameter: openapi3.Parameter{
+					Schema: openapi3.NewStringSchema().NewRef(),
+				}}}},
+			}).SetRequestBodyRequired(false).OK()
+			body := engine.OpenAPI().Paths.Find("/profiles").Post.RequestBody.Value
+			So(body.Required, ShouldBeFalse)
+			So(body.Content.Get("multipart/form-data").Encoding["title"].Headers, ShouldContainKey, "X-Lang")
+		})
+	})
+}
+
+func TestNonStructRequestBodies(t *testing.T) {
+	Convey("Given non-struct request bodies", t, func() {
+		type item struct {
+			Name string `json:"name"`
+		}
+		type arrayInput struct {
+			Body []item `body:"json"`
+		}
+		type mapInput struct {
+			Body map[string]int `body:"json"`
+		}
+		type textInput struct {
+			Body int `body:"text/plain"`
+		}
+		engine := soda.New().SetBindErrorStatus(http.StatusBadRequest)
+		engine.Post("/items", func(c *fiber.Ctx) error {
+			return c.JSON(soda.GetInput[arrayInput](c).Body)
+		}).SetInput(arrayInput{}).OK()
+		engine.Post("/counters", func(c *fiber.Ctx) error {
+			return c.JSON(soda.GetInput[mapInput](c).Body)
+		}).SetInput(mapInput{}).OK()
+		engine.Post("/numbers", func(c *fiber.Ctx) error {
+			return c.JSON(soda.GetInput[textInput](c).Body + 1)
+		}).SetInput(textInput{}).OK()
+
+		post := func(target, contentType, body string) (int, string) {
+			req, _ := http.NewRequest("POST", target, strings.NewReader(body))
+			req.Header.Set("Content-Type", contentType)
+			resp, err := engine.App().Test(req)
+			So(err, ShouldBeNil)
+			data, _ := io.ReadAll(resp.Body)
+			return resp.StatusCode, string(data)
+		}
+
+		Convey("Their top-level schemas should be documented", func() {
+			items := engine.OpenAPI().Paths.Find("/items").Post.RequestBody.Value.Content.Get("application/json").Schema.Value
+			So(items.Type.Is(openapi3.TypeArray), ShouldBeTrue)
+			So(items.Items.Ref, ShouldEqual, "#/components/schemas/soda_test.item")
+			counters := engine.OpenAPI().Paths.Find("/counters").Post.RequestBody.Value.Content.Get("application/json").Schema.Value
+			So(counters.Type.Is(openapi3.TypeObject), ShouldBeTrue)
+			numbers := engine.OpenAPI().Paths.Find("/numbers").Post.RequestBody.Value.Content.Get("text/plain").Schema.Value
+			So(numbers.Type.Is(openapi3.TypeInteger), ShouldBeTrue)
+		})
+
+		Convey("They should be bound", func() {
+			status, body := post("/items", "application/json", `[{"name":"a"},{"name":"b"}]`)
+			So(status, ShouldEqual, http.StatusOK)
+			So(body, ShouldEqual, `[{"name":"a"},{"name":"b"}]`)
+			status, body = post("/counters", "application/json", `{"a":1}`)
+			So(status, ShouldEqual, http.StatusOK)
+			So(body, ShouldEqual, `{"a":1}`)
+			status, body = post("/numbers", "text/plain", " 41\n")
+			So(status, ShouldEqual, http.StatusOK)
+			So(body, ShouldEqual, "42")
+		})
+
+		Convey("Invalid text should be rejected", func() {
+			status, _ := post("/numbers", "text/plain", "forty-one")
+			So(status, ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("Bodies which can't be bound from their media type should panic", func() {
+			type formInput struct {
+				Body []string `body:"application/x-www-form-urlencoded"`
+			}
+			type textStructInput struct {
+				Body item `body:"text/plain"`
+			}
+			So(func() { engine.Post("/form", nil).SetInput(formInput{}) }, ShouldPanic)
+			So(func() { engine.Post("/text", nil).SetInput(textStructInput{}) }, ShouldPanic)
+		})
+	})
+}
+
+func TestRawRequestBodies(t *testing.T) {
+	Convey("Given raw request bodies", t, func() {
+		type bytesInput struct {
+			Body []byte `body:"application/octet-stream"`
+		}
+		type readerInput struct {
+			Body io.Reader `body:"image/png"`
+		}
+		engine := soda.New()
+		engine.Post("/blobs", func(c *fiber.Ctx) error {
+			return c.SendString(strconv.Itoa(len(soda.GetInput[bytesInput](c).Body)))
+		}).SetInput(bytesInput{}).OK()
+		engine.Put("/avatar", func(c *fiber.Ctx) error {
+			data, err := io.ReadAll(soda.GetInput[readerInput](c).Body)
+			if err != nil {
+				return err
+			}
+			return c.Send(data)
+		}).SetInput(readerInput{}).OK()
+
+		Convey("They should be documented as binary", func() {
+			blobs := engine.OpenAPI().Paths.Find("/blobs").Post.RequestBody.Value.Content
+			So(blobs.Get("application/octet-stream").Schema.Value.Format, ShouldEqual, "binary")
+			avatar := engine.OpenAPI().Paths.Find("/avatar").Put.RequestBody.Value.Content
+			So(avatar.Get("image/png").Schema.Value.Format, ShouldEqual, "binary")
+		})
+
+		Convey("The handlers should receive the raw payload", func() {
+			req, _ := http.NewRequest("POST", "/blobs", strings.NewReader(`{"not":"parsed"}`))
+			req.Header.Set("Content-Type", "application/octet-stream")
+			resp, _ := engine.App().Test(req)
+			body, _ := io.ReadAll(resp.Body)
+			So(resp.StatusCode, ShouldEqual, http.StatusOK)
+			So(string(body), ShouldEqual, "16")
+
+			req, _ = http.NewRequest("PUT", "/avatar", strings.NewReader("\x89PNG"))
+			req.Header.Set("Content-Type", "image/png")
+			resp, _ = engine.App().Test(req)
+			body, _ = io.ReadAll(resp.Body)
+			So(resp.StatusCode, ShouldEqual, http.StatusOK)
+			So(string(body), ShouldEqual, "\x89PNG")
+		})
+	})
+}
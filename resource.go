@@ -0,0 +1,125 @@
+package soda
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+)
+
+// resourceAction is a CRUD operation of a resource, handled by the method of its controller.
+type resourceAction struct {
+	handler string
+	method  string
+	verb    string
+	// item is set for the operations on an item of the resource, routed with its id.
+	item   bool
+	status int
+}
+
+// resourceActions are the CRUD operations of the resources, in registration order.
+var resourceActions = []resourceAction{
+	{handler: "List", method: http.MethodGet, verb: "list", status: http.StatusOK},
+	{handler: "Create", method: http.MethodPost, verb: "create", status: http.StatusCreated},
+	{handler: "Get", method: http.MethodGet, verb: "get", item: true, status: http.StatusOK},
+	{handler: "Update", method: http.MethodPut, verb: "update", item: true, status: http.StatusOK},
+	{handler: "Delete", method: http.MethodDelete, verb: "delete", item: true, status: http.StatusNoContent},
+}
+
+// resourceIDParam is the path parameter of the items of the resources.
+const resourceIDParam = "id"
+
+// Resource registers the CRUD operations of the resource at the pattern, e.g. /users, handled by the methods of
+// the controller, each of them optional:
+//
+//   - List handles GET /users
+//   - Create handles POST /users, responding 201 Created
+//   - Get handles GET /users/:id
+//   - Update handles PUT /users/:id
+//   - Delete handles DELETE /users/:id, responding 204 No Content
+//
+// The methods are injected handlers, as with Register, e.g. func (c *Users) Get(ctx *fiber.Ctx, in *GetUser)
+// (*User, error). The operations are tagged with the name of the resource, the last segment of the pattern, and
+// identified consistently, e.g. listUsers and getUser. The operations on an item document a 404 response, and
+// the id path parameter unless their input does.
+func (r *Router) Resource(pattern string, controller any) *Router {
+	v := reflect.ValueOf(controller)
+	name := resourceName(pattern)
+	var routes []ControllerRoute
+	for _, action := range resourceActions {
+		method := v.MethodByName(action.handler)
+		if !method.IsValid() {
+			continue
+		}
+		route := ControllerRoute{Method: action.method, Pattern: pattern, Handler: action.handler, Setup: action.setup(name, method.Type())}
+		if action.item {
+			route.Pattern = strings.TrimSuffix(pattern, "/") + "/:" + resourceIDParam
+		}
+		routes = append(routes, route)
+	}
+	return r.registerRoutes(v, routes)
+}
+
+// setup returns the setup of the operation of the action on the resource, handled by a method of the type.
+func (a resourceAction) setup(name string, t reflect.Type) func(op *OperationBuilder) {
+	return func(op *OperationBuilder) {
+		// The collection is listed, e.g. "List users", and the other actions act on one item, e.g. "Get a user".
+		subject, article := name, " "
+		if a.item || a.verb == "create" {
+			subject, article = singular(name), " a "
+		}
+		op.SetOperationID(CamelCase(strings.ReplaceAll(a.verb+"_"+subject, "-", "_")))
+		op.SetSummary(strings.ToUpper(a.verb[:1]) + a.verb[1:] + article + strings.ReplaceAll(subject, "-", " "))
+		op.AddTags(name)
+		if a.item {
+			if op.operation.Parameters.GetByInAndName(openapi3.ParameterInPath, resourceIDParam) == nil {
+				op.operation.AddParameter(openapi3.NewPathParameter(resourceIDParam).WithSchema(openapi3.NewStringSchema()))
+			}
+			op.AddErrorResponses(http.StatusNotFound)
+		}
+		switch a.status {
+		case http.StatusCreated:
+			if t.NumOut() == 2 {
+				op.AddJSONResponse(a.status, reflect.Zero(t.Out(0)).Interface())
+			}
+			op.handlers = append(op.handlers, respondStatus(a.status))
+		case http.StatusNoContent:
+			op.NoContent()
+			op.handlers = append(op.handlers, respondStatus(a.status))
+		}
+	}
+}
+
+// respondStatus returns a handler setting the status of the response of the next handlers, unless they fail.
+func respondStatus(status int) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		c.Status(status)
+		return c.Next()
+	}
+}
+
+// resourceName returns the name of the resource at the pattern, its last segment which isn't a parameter.
+func resourceName(pattern string) string {
+	segments := strings.Split(strings.Trim(pattern, "/"), "/")
+	for i := len(segments) - 1; i >= 0; i-- {
+		if segments[i] != "" && !strings.HasPrefix(segments[i], ":") {
+			return segments[i]
+		}
+	}
+	return "resources"
+}
+
+// singular returns the naive singular of the plural English name of a resource, e.g. user for users.
+func singular(name string) string {
+	switch {
+	case strings.HasSuffix(name, "ies"):
+		return strings.TrimSuffix(name, "ies") + "y"
+	case strings.HasSuffix(name, "sses"), strings.HasSuffix(name, "xes"):
+		return strings.TrimSuffix(name, "es")
+	case strings.HasSuffix(name, "s") && !strings.HasSuffix(name, "ss"):
+		return strings.TrimSuffix(name, "s")
+	}
+	return name
+}
@@ -0,0 +1,104 @@
+package soda_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type resourceUser struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type createUserInput struct {
+	Body struct {
+		Name string `json:"name"`
+	} `body:"json"`
+}
+
+type userIDInput struct {
+	ID string `path:"id" oai:"description=the id of the user"`
+}
+
+// usersResource handles the CRUD operations of the users but their update.
+type usersResource struct{}
+
+func (usersResource) List(c *fiber.Ctx) ([]resourceUser, error) {
+	return []resourceUser{{ID: "1", Name: "ann"}}, nil
+}
+
+func (usersResource) Create(c *fiber.Ctx, in *createUserInput) (*resourceUser, error) {
+	return &resourceUser{ID: "2", Name: in.Body.Name}, nil
+}
+
+func (usersResource) Get(c *fiber.Ctx, in *userIDInput) (*resourceUser, error) {
+	if in.ID != "1" {
+		return nil, soda.NewError(http.StatusNotFound, "not_found", "no such user")
+	}
+	return &resourceUser{ID: in.ID, Name: "ann"}, nil
+}
+
+func (usersResource) Delete(c *fiber.Ctx) error {
+	return nil
+}
+
+func TestResource(t *testing.T) {
+	Convey("Given the CRUD operations of a resource", t, func() {
+		engine := soda.New()
+		engine.Group("/v1").Resource("/users", usersResource{})
+		do := func(method, target, body string) (*http.Response, string) {
+			req := httptest.NewRequest(method, target, strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := engine.App().Test(req)
+			So(err, ShouldBeNil)
+			b, _ := io.ReadAll(resp.Body)
+			return resp, string(b)
+		}
+
+		Convey("The methods of the controller should handle the operations", func() {
+			resp, body := do("GET", "/v1/users", "")
+			So(resp.StatusCode, ShouldEqual, http.StatusOK)
+			So(body, ShouldEqual, `[{"id":"1","name":"ann"}]`)
+
+			resp, body = do("POST", "/v1/users", `{"name":"bob"}`)
+			So(resp.StatusCode, ShouldEqual, http.StatusCreated)
+			So(body, ShouldEqual, `{"id":"2","name":"bob"}`)
+
+			resp, _ = do("GET", "/v1/users/1", "")
+			So(resp.StatusCode, ShouldEqual, http.StatusOK)
+			resp, _ = do("GET", "/v1/users/2", "")
+			So(resp.StatusCode, ShouldEqual, http.StatusNotFound)
+
+			resp, _ = do("DELETE", "/v1/users/1", "")
+			So(resp.StatusCode, ShouldEqual, http.StatusNoContent)
+		})
+
+		Convey("The missing methods should not be routed", func() {
+			So(engine.OpenAPI().Paths.Value("/v1/users/:id").Put, ShouldBeNil)
+		})
+
+		Convey("The operations should be documented consistently", func() {
+			collection, item := engine.OpenAPI().Paths.Value("/v1/users"), engine.OpenAPI().Paths.Value("/v1/users/:id")
+			So(collection.Get.OperationID, ShouldEqual, "listUsers")
+			So(collection.Get.Summary, ShouldEqual, "List users")
+			So(collection.Post.OperationID, ShouldEqual, "createUser")
+			So(collection.Post.Responses.Status(http.StatusCreated), ShouldNotBeNil)
+			So(collection.Post.Responses.Status(http.StatusOK), ShouldBeNil)
+			So(item.Get.OperationID, ShouldEqual, "getUser")
+			So(item.Get.Summary, ShouldEqual, "Get a user")
+			So(item.Get.Tags, ShouldResemble, []string{"users"})
+			So(item.Get.Responses.Status(http.StatusNotFound), ShouldNotBeNil)
+			So(item.Get.Parameters.GetByInAndName("path", "id").Description, ShouldEqual, "the id of the user")
+			So(item.Delete.OperationID, ShouldEqual, "deleteUser")
+			So(item.Delete.Parameters.GetByInAndName("path", "id"), ShouldNotBeNil)
+			So(item.Delete.Responses.Status(http.StatusNoContent), ShouldNotBeNil)
+		})
+	})
+}
@@ -0,0 +1,116 @@
+package soda
+
+import (
+	"net/http"
+	"sort"
+	"sync/atomic"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ResponseSizeStats are the metrics of the response size guard of an operation.
+type ResponseSizeStats struct {
+	OperationID string `json:"operationId"`
+	Method      string `json:"method"`
+	Path        string `json:"path"`
+	// Limit is the maximum size of the responses in bytes, Rejected counts the responses exceeding it and
+	// Largest is the size of the largest of them.
+	Limit    int    `json:"limit"`
+	Rejected uint64 `json:"rejected"`
+	Largest  int64  `json:"largest"`
+}
+
+// responseSizeGuard rejects the responses of an operation exceeding the limit.
+type responseSizeGuard struct {
+	operationID, method, path string
+	limit                     int
+	rejected                  atomic.Uint64
+	largest                   atomic.Int64
+}
+
+// SetMaxResponseSize sets the maximum size in bytes of the serialized responses of the operations registered
+// afterwards, so that accidentally unbounded endpoints, e.g. lists without pagination, are caught: the larger
+// responses are replaced with a documented 500 HTTPError and reported by Engine.ResponseSizeStats.
+// The streamed responses aren't guarded.
+func (e *Engine) SetMaxResponseSize(limit int) *Engine {
+	e.maxResponseSize = limit
+	return e
+}
+
+// SetMaxResponseSize overrides the maximum size in bytes of the serialized responses of the operation set by the
+// engine, a negative one disabling the guard.
+func (op *OperationBuilder) SetMaxResponseSize(limit int) *OperationBuilder {
+	op.maxResponseSize = limit
+	return op
+}
+
+// responseSizeLimit returns the maximum size of the responses of the operation, 0 if unlimited.
+func (op *OperationBuilder) responseSizeLimit() int {
+	limit := op.maxResponseSize
+	if limit == 0 {
+		limit = op.route.engine.maxResponseSize
+	}
+	return max(limit, 0)
+}
+
+// documentResponseSizeGuard documents the 500 responses replacing the responses exceeding the limit.
+func (op *OperationBuilder) documentResponseSizeGuard() {
+	if op.operation.Responses.Status(http.StatusInternalServerError) == nil {
+		op.AddErrorResponses(http.StatusInternalServerError)
+	}
+}
+
+// addResponseSizeGuard returns the response size guard of the operation, reported by the engine.
+func (e *Engine) addResponseSizeGuard(op *OperationBuilder, limit int) *responseSizeGuard {
+	g := &responseSizeGuard{operationID: op.operation.OperationID, method: op.method, path: op.docPath, limit: limit}
+	e.responseSizesMu.Lock()
+	defer e.responseSizesMu.Unlock()
+	e.responseSizes = append(e.responseSizes, g)
+	return g
+}
+
+// guard replaces the response with a 500 HTTPError if it exceeds the limit once serialized.
+func (g *responseSizeGuard) guard(c *fiber.Ctx) error {
+	err := c.Next()
+	if err != nil || c.Response().IsBodyStream() {
+		return err
+	}
+	size := len(c.Response().Body())
+	if size <= g.limit {
+		return nil
+	}
+	g.rejected.Add(1)
+	for {
+		largest := g.largest.Load()
+		if int64(size) <= largest || g.largest.CompareAndSwap(largest, int64(size)) {
+			break
+		}
+	}
+	c.Response().ResetBody()
+	return c.App().ErrorHandler(c, NewError(http.StatusInternalServerError, MsgResponseTooLarge,
+		Translate(c, MsgResponseTooLarge, size, g.limit)))
+}
+
+// ResponseSizeStats reports the metrics of the response size guards of the operations, sorted by path and method.
+func (e *Engine) ResponseSizeStats() []ResponseSizeStats {
+	e.responseSizesMu.Lock()
+	defer e.responseSizesMu.Unlock()
+	stats := make([]ResponseSizeStats, 0, len(e.responseSizes))
+	for _, g := range e.responseSizes {
+		stats = append(stats, ResponseSizeStats{
+			OperationID: g.operationID,
+			Method:      g.method,
+			Path:        g.path,
+			Limit:       g.limit,
+			Rejected:    g.rejected.Load(),
+			Largest:     g.largest.Load(),
+		})
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Path != stats[j].Path {
+			return stats[i].Path < stats[j].Path
+		}
+		return stats[i].Method < stats[j].Method
+	})
+	return stats
+}
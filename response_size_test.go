@@ -0,0 +1,62 @@
+package soda_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestResponseSizeGuard(t *testing.T) {
+	Convey("Given operations whose responses are size-guarded", t, func() {
+		engine := soda.New().SetMaxResponseSize(64)
+		engine.Get("/items", func(c *fiber.Ctx) error {
+			return c.JSON([]string{strings.Repeat("a", c.QueryInt("size"))})
+		}).OK()
+		engine.Get("/exports", func(c *fiber.Ctx) error {
+			return c.SendString(strings.Repeat("a", 128))
+		}).SetMaxResponseSize(-1).OK()
+		get := func(target string) (int, []byte) {
+			resp, err := engine.App().Test(httptest.NewRequest("GET", target, nil))
+			So(err, ShouldBeNil)
+			var body json.RawMessage
+			_ = json.NewDecoder(resp.Body).Decode(&body)
+			return resp.StatusCode, body
+		}
+
+		Convey("The responses within the limit should be sent", func() {
+			status, _ := get("/items?size=8")
+			So(status, ShouldEqual, http.StatusOK)
+		})
+
+		Convey("The responses exceeding the limit should be replaced with an error", func() {
+			status, body := get("/items?size=100")
+			So(status, ShouldEqual, http.StatusInternalServerError)
+			So(string(body), ShouldEqual,
+				`{"code":"response_too_large","message":"the response of 104 bytes exceeds the limit of 64 bytes"}`)
+
+			stats := engine.ResponseSizeStats()
+			So(stats, ShouldHaveLength, 1)
+			So(stats[0], ShouldResemble, soda.ResponseSizeStats{
+				OperationID: stats[0].OperationID, Method: "GET", Path: "/items", Limit: 64, Rejected: 1, Largest: 104,
+			})
+		})
+
+		Convey("The operations opting out should not be guarded", func() {
+			resp, err := engine.App().Test(httptest.NewRequest("GET", "/exports", nil))
+			So(err, ShouldBeNil)
+			So(resp.StatusCode, ShouldEqual, http.StatusOK)
+		})
+
+		Convey("The error response should be documented", func() {
+			responses := engine.OpenAPI().Paths.Value("/items").Get.Responses
+			So(responses.Status(http.StatusInternalServerError), ShouldNotBeNil)
+			So(engine.OpenAPI().Paths.Value("/exports").Get.Responses.Status(http.StatusInternalServerError), ShouldBeNil)
+		})
+	})
+}
@@ -0,0 +1,88 @@
+package soda
+
+import (
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+)
+
+// retryExtension documents how the clients should retry after a response, e.g. for code generators.
+const retryExtension = "x-retry"
+
+// Backoff strategies of RetryHint.
+const (
+	BackoffConstant    = "constant"
+	BackoffExponential = "exponential"
+)
+
+// RetryHint advises the clients on how to retry after a throttled or unavailable response. It is documented
+// with the x-retry extension of the response, the Retry-After header taking precedence when sent.
+type RetryHint struct {
+	// Backoff is the strategy of the delays between the attempts, BackoffExponential by default.
+	Backoff string
+	// InitialDelay is the delay before the first retry.
+	InitialDelay time.Duration
+	// MaxDelay caps the delays between the attempts, unbounded if zero.
+	MaxDelay time.Duration
+	// MaxAttempts is the number of attempts worth making, unbounded if zero.
+	MaxAttempts int
+}
+
+// extension returns the value of the x-retry extension documenting the hint.
+func (h RetryHint) extension() map[string]any {
+	ext := map[string]any{"backoff": h.Backoff}
+	if h.Backoff == "" {
+		ext["backoff"] = BackoffExponential
+	}
+	if h.InitialDelay > 0 {
+		ext["initialDelay"] = retrySeconds(h.InitialDelay)
+	}
+	if h.MaxDelay > 0 {
+		ext["maxDelay"] = retrySeconds(h.MaxDelay)
+	}
+	if h.MaxAttempts > 0 {
+		ext["maxAttempts"] = h.MaxAttempts
+	}
+	return ext
+}
+
+// RetryAfter sets the Retry-After header of the response to the delay, rounded up to the second, and returns
+// the error of the status, such as 429 or 503, to return from the handler.
+func RetryAfter(c *fiber.Ctx, status int, delay time.Duration, message ...string) error {
+	c.Set(fiber.HeaderRetryAfter, strconv.Itoa(retrySeconds(delay)))
+	return fiber.NewError(status, message...)
+}
+
+// AddRetryableResponse documents a response of the status, such as 429 or 503, sent with a Retry-After header,
+// and the retry hint with the x-retry extension, if given.
+func (op *OperationBuilder) AddRetryableResponse(status int, description string, hint ...RetryHint) *OperationBuilder {
+	resp := op.route.gen.GenerateResponse(status, nil, "", description)
+	documentRetryAfter(resp, hint...)
+	op.operation.AddResponse(status, resp)
+	return op
+}
+
+// documentRetryAfter documents the Retry-After header of the response, and the retry hint if given.
+func documentRetryAfter(resp *openapi3.Response, hint ...RetryHint) {
+	if resp.Headers == nil {
+		resp.Headers = make(openapi3.Headers)
+	}
+	resp.Headers[fiber.HeaderRetryAfter] = &openapi3.HeaderRef{Value: &openapi3.Header{Parameter: openapi3.Parameter{
+		Description: "The number of seconds to wait before retrying.",
+		Schema:      openapi3.NewIntegerSchema().WithMin(0).NewRef(),
+	}}}
+	if len(hint) != 0 {
+		if resp.Extensions == nil {
+			resp.Extensions = make(map[string]any)
+		}
+		resp.Extensions[retryExtension] = hint[0].extension()
+	}
+}
+
+// retrySeconds rounds the delay up to the second.
+func retrySeconds(delay time.Duration) int {
+	return int(math.Ceil(delay.Seconds()))
+}
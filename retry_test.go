@@ -0,0 +1,59 @@
+package soda_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRetryAfter(t *testing.T) {
+	Convey("Given an operation throttling its requests", t, func() {
+		engine := soda.New()
+		engine.Get("/quotes", func(c *fiber.Ctx) error {
+			return soda.RetryAfter(c, http.StatusTooManyRequests, 2500*time.Millisecond, "slow down")
+		}).AddRetryableResponse(http.StatusTooManyRequests, "Too many requests", soda.RetryHint{
+			InitialDelay: time.Second,
+			MaxDelay:     time.Minute,
+			MaxAttempts:  5,
+		}).OK()
+
+		Convey("The response should advise the delay in seconds, rounded up", func() {
+			resp, err := engine.App().Test(httptest.NewRequest("GET", "/quotes", nil))
+			So(err, ShouldBeNil)
+			So(resp.StatusCode, ShouldEqual, http.StatusTooManyRequests)
+			So(resp.Header.Get("Retry-After"), ShouldEqual, "3")
+		})
+
+		Convey("The Retry-After header and the retry hint should be documented", func() {
+			resp := engine.OpenAPI().Paths.Value("/quotes").Get.Responses.Status(http.StatusTooManyRequests)
+			So(resp, ShouldNotBeNil)
+			So(resp.Value.Description, ShouldNotBeNil)
+			So(*resp.Value.Description, ShouldEqual, "Too many requests")
+			So(resp.Value.Headers, ShouldContainKey, "Retry-After")
+			So(resp.Value.Extensions["x-retry"], ShouldResemble, map[string]any{
+				"backoff":      soda.BackoffExponential,
+				"initialDelay": 1,
+				"maxDelay":     60,
+				"maxAttempts":  5,
+			})
+		})
+	})
+
+	Convey("Given an operation limited in concurrency", t, func() {
+		engine := soda.New()
+		engine.Get("/reports", nil).SetMaxConcurrency(1, 2*time.Second).OK()
+
+		Convey("Its 503 response should advise a constant backoff", func() {
+			resp := engine.OpenAPI().Paths.Value("/reports").Get.Responses.Status(http.StatusServiceUnavailable)
+			So(resp.Value.Extensions["x-retry"], ShouldResemble, map[string]any{
+				"backoff":      soda.BackoffConstant,
+				"initialDelay": 2,
+			})
+		})
+	})
+}
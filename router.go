@@ -10,8 +10,9 @@ import (
 )
 
 type Router struct {
-	Raw fiber.Router
-	gen *Generator
+	Raw    fiber.Router
+	gen    *Generator
+	engine *Engine
 
 	commonPrefix     string
 	commonTags       []string
@@ -23,6 +24,8 @@ type Router struct {
 	commonHooksAfterBind  []HookAfterBind
 
 	ignoreAPIDoc bool
+	tagNamespace string
+	mutualTLS    *mutualTLS
 }
 
 func (r *Router) createOperationBuilder(method string, pattern, patternFull string, handlers ...fiber.Handler) *OperationBuilder {
@@ -45,6 +48,7 @@ func (r *Router) createOperationBuilder(method string, pattern, patternFull stri
 }
 
 func (r *Router) Add(method string, pattern string, handlers ...fiber.Handler) *OperationBuilder {
+	pattern = r.engine.normalizePattern(pattern)
 	patternFull := path.Join(r.commonPrefix, pattern)
 	builder := r.createOperationBuilder(method, pattern, patternFull, handlers...)
 	for code, resp := range r.commonResponses {
@@ -52,6 +56,12 @@ func (r *Router) Add(method string, pattern string, handlers ...fiber.Handler) *
 	}
 	builder.AddTags(r.commonTags...)
 	builder.SetDeprecated(r.commonDeprecated)
+	if r.mutualTLS != nil {
+		if !r.gen.openAPI31() {
+			builder.markMutualTLS()
+		}
+		builder.requireMutualTLS(r.mutualTLS.verifiers)
+	}
 	return builder
 }
 
@@ -88,9 +98,9 @@ func (r *Router) Trace(pattern string, handlers ...fiber.Handler) *OperationBuil
 }
 
 func (r *Router) AddTags(tags ...string) *Router {
-	r.commonTags = append(r.commonTags, tags...)
-
 	for _, tag := range tags {
+		tag = r.namespaceTag(tag)
+		r.commonTags = append(r.commonTags, tag)
 		r.gen.doc.Tags = append(r.gen.doc.Tags, &openapi3.Tag{
 			Name: tag,
 		})
@@ -149,8 +159,9 @@ func (r *Router) AddJSONResponse(code int, model any, description ...string) *Ro
 func (r *Router) Group(prefix string, handlers ...fiber.Handler) *Router {
 	return &Router{
 		gen:                   r.gen,
-		Raw:                   r.Raw.Group(prefix, handlers...),
-		commonPrefix:          path.Join(r.commonPrefix, prefix),
+		engine:                r.engine,
+		Raw:                   r.Raw.Group(r.engine.normalizePattern(prefix), handlers...),
+		commonPrefix:          path.Join(r.commonPrefix, r.engine.normalizePattern(prefix)),
 		commonTags:            r.commonTags,
 		commonDeprecated:      r.commonDeprecated,
 		commonResponses:       maps.Clone(r.commonResponses),
@@ -158,5 +169,7 @@ func (r *Router) Group(prefix string, handlers ...fiber.Handler) *Router {
 		commonHooksBeforeBind: r.commonHooksBeforeBind,
 		commonHooksAfterBind:  r.commonHooksAfterBind,
 		ignoreAPIDoc:          r.ignoreAPIDoc,
+		tagNamespace:          r.tagNamespace,
+		mutualTLS:             r.mutualTLS,
 	}
 }
@@ -0,0 +1,101 @@
+package soda
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"runtime"
+	"slices"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// RouteInfo describes a route registered through soda.
+type RouteInfo struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	// DocPath is the path of the operation in the spec, which differs from Path when translated.
+	DocPath     string   `json:"docPath,omitempty"`
+	OperationID string   `json:"operationId"`
+	Tags        []string `json:"tags,omitempty"`
+	// Handler is the name of the function handling the route, the last of its handlers.
+	Handler string `json:"handler"`
+	// Documented is false for the routes hidden from the spec.
+	Documented bool `json:"documented"`
+}
+
+// Routes returns the routes registered through soda, in registration order.
+func (e *Engine) Routes() []RouteInfo {
+	return slices.Clone(e.routes)
+}
+
+// ServeRoutes serves the registered routes as JSON, so that operators can inspect what is actually registered
+// versus documented. It is meant for development and should not be exposed in production.
+func (e *Engine) ServeRoutes(pattern string) *Engine {
+	e.serveDoc(pattern, func(c *fiber.Ctx) error {
+		return c.JSON(e.routes)
+	})
+	return e
+}
+
+// regexRouteParam matches the names of the path parameters, which don't distinguish routes.
+var regexRouteParam = regexp.MustCompile(`:\w+`)
+
+// addRoute records the route of the operation registered at the call site.
+// It panics if the route conflicts with a previously registered one, which fiber and the spec would silently shadow.
+func (e *Engine) addRoute(op *OperationBuilder, site string) {
+	key := op.method + " " + regexRouteParam.ReplaceAllString(op.patternFull, ":")
+	if previous, ok := e.routeSites[key]; ok {
+		panic(fmt.Sprintf("route %s %s registered at %s conflicts with the route registered at %s",
+			op.method, op.patternFull, site, previous))
+	}
+	if e.routeSites == nil {
+		e.routeSites = make(map[string]string)
+	}
+	e.routeSites[key] = site
+	info := RouteInfo{
+		Method:      op.method,
+		Path:        op.patternFull,
+		OperationID: op.operation.OperationID,
+		Tags:        op.operation.Tags,
+		Handler:     handlerName(op.handlers),
+		Documented:  !op.ignoreAPIDoc,
+	}
+	if info.Documented {
+		info.DocPath = op.docPath
+	}
+	op.info = info
+	e.routes = append(e.routes, info)
+}
+
+// callSite returns the location of the caller of the function calling it.
+func callSite() string {
+	_, file, line, ok := runtime.Caller(2)
+	if !ok {
+		return "unknown"
+	}
+	return fmt.Sprintf("%s:%d", file, line)
+}
+
+// handlerName returns the name of the last handler.
+func handlerName(handlers []fiber.Handler) string {
+	if len(handlers) == 0 {
+		return ""
+	}
+	fn := runtime.FuncForPC(reflect.ValueOf(handlers[len(handlers)-1]).Pointer())
+	if fn == nil {
+		return ""
+	}
+	return fn.Name()
+}
+
+// OperationFromCtx returns the route of the operation handling the request, so that authorization and auditing
+// layers can key on its operation ID, tags and path template. It is available to the handlers, hooks and tag
+// middlewares of the operation, and to the middlewares of the app once they call Next.
+func OperationFromCtx(c *fiber.Ctx) (RouteInfo, bool) {
+	op, ok := c.Locals(keyOperationBuilder).(*OperationBuilder)
+	if !ok {
+		return RouteInfo{}, false
+	}
+	return op.info, true
+}
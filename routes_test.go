@@ -0,0 +1,104 @@
+package soda_test
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func listUsers(c *fiber.Ctx) error { return c.SendStatus(200) }
+
+func TestRoutes(t *testing.T) {
+	Convey("Given an engine with registered routes", t, func() {
+		engine := soda.New()
+		engine.Get("/users", listUsers).AddTags("users").OK()
+		engine.Group("/internal").SetIgnoreAPIDoc(true).Post("/reload", listUsers).SetOperationID("reload").OK()
+
+		Convey("Routes should list them in registration order", func() {
+			routes := engine.Routes()
+			So(routes, ShouldHaveLength, 2)
+			So(routes[0], ShouldResemble, soda.RouteInfo{
+				Method:      "GET",
+				Path:        "/users",
+				DocPath:     "/users",
+				OperationID: "get--users",
+				Tags:        []string{"users"},
+				Handler:     "github.com/neo-f/soda/v3_test.listUsers",
+				Documented:  true,
+			})
+			So(routes[1].Path, ShouldEqual, "/internal/reload")
+			So(routes[1].Documented, ShouldBeFalse)
+		})
+
+		Convey("ServeRoutes should render them as JSON", func() {
+			engine.ServeRoutes("/debug/routes")
+			resp, err := engine.App().Test(httptest.NewRequest("GET", "/debug/routes", nil))
+			So(err, ShouldBeNil)
+			var routes []soda.RouteInfo
+			So(json.NewDecoder(resp.Body).Decode(&routes), ShouldBeNil)
+			So(routes, ShouldResemble, engine.Routes())
+		})
+	})
+
+	Convey("Given an engine with a registered route", t, func() {
+		engine := soda.New()
+		engine.Get("/users/:id", listUsers).OK()
+
+		Convey("Registering the same route again should panic with both call sites", func() {
+			var msg any
+			func() {
+				defer func() { msg = recover() }()
+				engine.Get("/users/:id", listUsers).OK()
+			}()
+			So(msg, ShouldStartWith, "route GET /users/:id registered at ")
+			So(strings.Count(msg.(string), "routes_test.go:"), ShouldEqual, 2)
+		})
+
+		Convey("Registering a route differing only in parameter names should panic", func() {
+			So(func() { engine.Get("/users/:uid", listUsers).OK() }, ShouldPanic)
+		})
+
+		Convey("Registering the route with another method should not panic", func() {
+			So(func() { engine.Delete("/users/:id", listUsers).OK() }, ShouldNotPanic)
+		})
+	})
+}
+
+func TestOperationFromCtx(t *testing.T) {
+	Convey("Given an operation", t, func() {
+		engine := soda.New().SetPathTranslation(true)
+		var fromMiddleware, fromTag, fromHandler soda.RouteInfo
+		var beforeNext bool
+		engine.App().Use(func(c *fiber.Ctx) error {
+			_, beforeNext = soda.OperationFromCtx(c)
+			err := c.Next()
+			fromMiddleware, _ = soda.OperationFromCtx(c)
+			return err
+		})
+		engine.UseForTag("orders", func(c *fiber.Ctx) error {
+			fromTag, _ = soda.OperationFromCtx(c)
+			return c.Next()
+		})
+		engine.Get("/orders/:id", func(c *fiber.Ctx) error {
+			fromHandler, _ = soda.OperationFromCtx(c)
+			return nil
+		}).SetOperationID("getOrder").AddTags("orders").OK()
+
+		Convey("Its route should be available to the handlers and middlewares", func() {
+			_, err := engine.App().Test(httptest.NewRequest("GET", "/orders/1", nil))
+			So(err, ShouldBeNil)
+			So(beforeNext, ShouldBeFalse)
+			So(fromHandler.OperationID, ShouldEqual, "getOrder")
+			So(fromHandler.Tags, ShouldResemble, []string{"orders"})
+			So(fromHandler.Path, ShouldEqual, "/orders/:id")
+			So(fromHandler.DocPath, ShouldEqual, "/orders/{id}")
+			So(fromTag, ShouldResemble, fromHandler)
+			So(fromMiddleware, ShouldResemble, fromHandler)
+		})
+	})
+}
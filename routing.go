@@ -0,0 +1,117 @@
+package soda
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// TrailingSlash is the handling of the trailing slash of the request paths.
+type TrailingSlash int
+
+const (
+	// TrailingSlashAsIs leaves the trailing slashes to the fiber app, which ignores them unless StrictRouting is set.
+	TrailingSlashAsIs TrailingSlash = iota
+	// TrailingSlashRedirect redirects the requests with a trailing slash to the path without it, with a
+	// 308 Permanent Redirect preserving their method and body.
+	TrailingSlashRedirect
+	// TrailingSlashRewrite strips the trailing slash of the request paths before routing.
+	TrailingSlashRewrite
+)
+
+// Routing is the set of options of the matching of the request paths, applied to the patterns of the operations
+// alike, so that the documented paths and the matched ones agree.
+type Routing struct {
+	// CaseInsensitive matches the literal segments of the paths case-insensitively, even if the fiber app is
+	// case-sensitive, the patterns being registered and documented lowercased. The parameters keep their case.
+	CaseInsensitive bool
+	// TrailingSlash is the handling of the trailing slashes, which are stripped from the patterns unless AsIs.
+	TrailingSlash TrailingSlash
+}
+
+// SetRouting sets the routing options of the operations registered afterwards. It must be set before registering
+// the routes of the app, since the request paths are normalized by a middleware.
+func (e *Engine) SetRouting(routing Routing) *Engine {
+	if e.routing == nil {
+		e.app.Use(e.normalizePath)
+	}
+	e.routing = &routing
+	return e
+}
+
+// normalizePattern normalizes the literal segments and the trailing slash of the pattern per the routing options.
+func (e *Engine) normalizePattern(pattern string) string {
+	if e == nil || e.routing == nil {
+		return pattern
+	}
+	if e.routing.CaseInsensitive {
+		pattern = lowerLiterals(pattern)
+	}
+	if e.routing.TrailingSlash != TrailingSlashAsIs && len(pattern) > 1 {
+		pattern = strings.TrimRight(pattern, "/")
+	}
+	return pattern
+}
+
+// lowerLiterals lowercases the pattern but its parameters.
+func lowerLiterals(pattern string) string {
+	var b strings.Builder
+	last := 0
+	for _, loc := range regexFiberParam.FindAllStringIndex(pattern, -1) {
+		b.WriteString(strings.ToLower(pattern[last:loc[0]]))
+		b.WriteString(pattern[loc[0]:loc[1]])
+		last = loc[1]
+	}
+	b.WriteString(strings.ToLower(pattern[last:]))
+	return b.String()
+}
+
+// normalizePath normalizes the request path per the routing options before routing.
+func (e *Engine) normalizePath(c *fiber.Ctx) error {
+	routing := e.routing
+	p := c.Path()
+	if routing.TrailingSlash != TrailingSlashAsIs && len(p) > 1 && strings.HasSuffix(p, "/") {
+		p = strings.TrimRight(p, "/")
+		if p == "" {
+			p = "/"
+		}
+		if routing.TrailingSlash == TrailingSlashRedirect {
+			location := p
+			if query := c.Request().URI().QueryString(); len(query) != 0 {
+				location += "?" + string(query)
+			}
+			return c.Redirect(location, http.StatusPermanentRedirect)
+		}
+	}
+	if routing.CaseInsensitive && e.app.Config().CaseSensitive {
+		p = e.foldPath(p)
+	}
+	if p != c.Path() {
+		c.Path(p)
+	}
+	return c.Next()
+}
+
+// foldPath lowercases the literal segments of the path matching a route, keeping the case of its parameters.
+func (e *Engine) foldPath(p string) string {
+	for _, route := range e.routes {
+		loc := e.routeRegexp(route.Path).FindStringSubmatchIndex(p)
+		if loc == nil {
+			continue
+		}
+		var b strings.Builder
+		last := 0
+		for i := 2; i+1 < len(loc); i += 2 {
+			if loc[i] < 0 {
+				continue
+			}
+			b.WriteString(strings.ToLower(p[last:loc[i]]))
+			b.WriteString(p[loc[i]:loc[i+1]])
+			last = loc[i+1]
+		}
+		b.WriteString(strings.ToLower(p[last:]))
+		return b.String()
+	}
+	return p
+}
@@ -0,0 +1,57 @@
+package soda_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRouting(t *testing.T) {
+	Convey("Given a strict and case-sensitive app with tolerant routing options", t, func() {
+		newEngine := func(trailingSlash soda.TrailingSlash) *soda.Engine {
+			engine := soda.NewWith(fiber.New(fiber.Config{CaseSensitive: true, StrictRouting: true})).
+				SetRouting(soda.Routing{CaseInsensitive: true, TrailingSlash: trailingSlash})
+			engine.Group("/API").Get("/Users/:name/", func(c *fiber.Ctx) error {
+				return c.SendString(c.Params("name"))
+			}).OK()
+			return engine
+		}
+		get := func(engine *soda.Engine, target string) (*http.Response, string) {
+			resp, err := engine.App().Test(httptest.NewRequest("GET", target, nil))
+			So(err, ShouldBeNil)
+			buf := make([]byte, 64)
+			n, _ := resp.Body.Read(buf)
+			return resp, string(buf[:n])
+		}
+
+		Convey("The patterns should be documented normalized", func() {
+			engine := newEngine(soda.TrailingSlashRedirect)
+			So(engine.OpenAPI().Paths.Value("/api/users/:name"), ShouldNotBeNil)
+		})
+
+		Convey("The paths should match case-insensitively, keeping the case of the parameters", func() {
+			engine := newEngine(soda.TrailingSlashRedirect)
+			resp, body := get(engine, "/Api/USERS/Bob")
+			So(resp.StatusCode, ShouldEqual, http.StatusOK)
+			So(body, ShouldEqual, "Bob")
+		})
+
+		Convey("The trailing slashes should be redirected", func() {
+			engine := newEngine(soda.TrailingSlashRedirect)
+			resp, _ := get(engine, "/api/users/Bob/?verbose=1")
+			So(resp.StatusCode, ShouldEqual, http.StatusPermanentRedirect)
+			So(resp.Header.Get("Location"), ShouldEqual, "/api/users/Bob?verbose=1")
+		})
+
+		Convey("The trailing slashes should be rewritten", func() {
+			engine := newEngine(soda.TrailingSlashRewrite)
+			resp, body := get(engine, "/API/users/Bob/")
+			So(resp.StatusCode, ShouldEqual, http.StatusOK)
+			So(body, ShouldEqual, "Bob")
+		})
+	})
+}
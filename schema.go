@@ -3,6 +3,8 @@ package soda
 import (
 	"context"
 	"encoding/json"
+	"encoding/xml"
+	"maps"
 	"math"
 	"net"
 	"net/http"
@@ -20,6 +22,7 @@ var (
 	wnByteSlice    = reflect.TypeOf([]byte(nil))       // Byte slices will be encoded as base64
 	wnJSON         = reflect.TypeOf(json.RawMessage{}) // Except for json.RawMessage
 	wnMapStringAny = reflect.TypeOf(map[string]any{})  // Except for map[string]any
+	wnXMLName      = reflect.TypeOf(xml.Name{})        // The root element name of XML documents
 )
 
 // Define an interface for JSON schema generation.
@@ -33,6 +36,26 @@ var jsonSchemaFunc = reflect.TypeOf((*jsonSchema)(nil)).Elem()
 // Generator Define the Generator struct.
 type Generator struct {
 	doc *openapi3.T
+
+	envelope bool
+	// naming is the naming strategy of the fields without a json name, if any.
+	naming NamingStrategy
+
+	// The schemas of the structs and the parameters of the inputs already generated,
+	// reused by the operations sharing models.
+	schemas    map[schemaKey]*openapi3.Schema
+	parameters map[reflect.Type]openapi3.Parameters
+
+	// The types of the schema names and the names of the types, checked for duplicates.
+	schemaTypes map[string]reflect.Type
+	typeNames   map[typeNameTag][]string
+}
+
+// schemaKey identifies the schema of a struct generated with a name tag under a schema name.
+type schemaKey struct {
+	t       reflect.Type
+	nameTag string
+	name    string
 }
 
 // NewGenerator Create a new generator.
@@ -59,6 +82,9 @@ func NewGenerator() *Generator {
 
 // Generate TestCase for a given type.
 func (g *Generator) generateParameters(parameters *openapi3.Parameters, t reflect.Type) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
 	if t.Kind() != reflect.Struct {
 		return
 	}
@@ -66,8 +92,13 @@ func (g *Generator) generateParameters(parameters *openapi3.Parameters, t reflec
 	// Loop through the fields of the type and handle each field.
 	for i := 0; i < t.NumField(); i++ {
 		f := t.Field(i)
-		if f.Tag.Get(OpenAPITag) == "-" || f.Anonymous {
-			if f.Anonymous {
+		if f.Tag.Get(OpenAPITag) == "-" {
+			continue
+		}
+		if f.Anonymous {
+			if isInputMixin(derefType(f.Type)) {
+				g.generateMixinParameters(parameters, derefType(f.Type))
+			} else {
 				g.generateParameters(parameters, f.Type)
 			}
 			continue
@@ -77,15 +108,52 @@ func (g *Generator) generateParameters(parameters *openapi3.Parameters, t reflec
 		if in == "" {
 			continue
 		}
+		g.generateParameter(parameters, t, f, in, "", true)
+	}
+}
+
+// generateParameter generates the parameter of the field in the location, named with the prefix of its parents
+// and only required if they are. Nested structs are flattened into a parameter per field, e.g. address.city,
+// and slices of structs are documented as deep objects, e.g. filters[0][field].
+func (g *Generator) generateParameter(parameters *openapi3.Parameters, t reflect.Type, f reflect.StructField, in, prefix string, parentRequired bool) {
+	field := newTagsResolver(f)
+	if nested := derefType(f.Type); in != openapi3.ParameterInPath && isNestedStruct(nested) {
+		g.generateNestedParameters(parameters, nested, in, prefix+field.name(in)+".", parentRequired && field.required())
+		return
+	}
 
-		fieldSchemaRef := g.generateSchemaRef(nil, f.Type, in)
-		field := newTagsResolver(f)
-		schema := derefSchema(g.doc, fieldSchemaRef)
-		field.injectOAITags(schema)
+	fieldSchemaRef := g.generateSchemaRef(nil, f.Type, in)
+	injectComment(fieldSchemaRef, fieldComment(t, f))
+	schema := derefSchema(g.doc, fieldSchemaRef)
+	field.injectOAITags(schema)
+
+	parameter := g.createParameter(field, schema, in, fieldSchemaRef)
+	parameter.Name = prefix + parameter.Name
+	parameter.Required = parameter.Required && parentRequired
+	if elem := derefType(f.Type); elem.Kind() == reflect.Slice && isNestedStruct(derefType(elem.Elem())) {
+		parameter.Style = openapi3.SerializationDeepObject
+		parameter.Explode = ptr(true)
+	}
+	g.setAdditionalProperties(&parameter, field)
+	applyConverter(&parameter, field)
+	*parameters = append(*parameters, &openapi3.ParameterRef{Value: &parameter})
+	*parameters = append(*parameters, aliasParameters(parameter, field, prefix)...)
+}
 
-		parameter := g.createParameter(field, schema, in, fieldSchemaRef)
-		g.setAdditionalProperties(&parameter, field)
-		*parameters = append(*parameters, &openapi3.ParameterRef{Value: &parameter})
+// generateNestedParameters generates the parameters of the fields of a nested struct in the location.
+func (g *Generator) generateNestedParameters(parameters *openapi3.Parameters, t reflect.Type, in, prefix string, required bool) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Tag.Get(OpenAPITag) == "-" || (!f.IsExported() && !f.Anonymous) {
+			continue
+		}
+		if f.Anonymous {
+			if embedded := derefType(f.Type); embedded.Kind() == reflect.Struct {
+				g.generateNestedParameters(parameters, embedded, in, prefix, required)
+			}
+			continue
+		}
+		g.generateParameter(parameters, t, f, in, prefix, required)
 	}
 }
 
@@ -105,6 +173,7 @@ func (g *Generator) createParameter(field *tagsResolver, schema *openapi3.Schema
 		Required:    field.required() || in == "path", // path parameters are always required
 		Description: schema.Description,
 		Deprecated:  schema.Deprecated,
+		Example:     schema.Example,
 		Schema:      schemaRef,
 	}
 }
@@ -116,16 +185,42 @@ func (g *Generator) setAdditionalProperties(parameter *openapi3.Parameter, field
 	if v, ok := field.pairs[propStyle]; ok {
 		parameter.Style = v
 	}
+	if v, ok := field.pairs[propExamples]; ok {
+		// A parameter has either an example or examples, named after their values.
+		parameter.Example = nil
+		parameter.Examples = make(openapi3.Examples)
+		for _, example := range strings.Split(v, SeparatorPropItem) {
+			parameter.Examples[example] = &openapi3.ExampleRef{Value: openapi3.NewExample(example)}
+		}
+	}
 }
 
 // GenerateParameters generates OpenAPI TestCase for a given model.
 func (g *Generator) GenerateParameters(model reflect.Type) openapi3.Parameters {
+	if parameters, ok := g.parameters[model]; ok {
+		return cloneParameters(parameters)
+	}
 	parameters := make(openapi3.Parameters, 0)
 	g.generateParameters(&parameters, model)
 	if err := parameters.Validate(context.Background()); err != nil {
 		panic(err)
 	}
-	return parameters
+	if g.parameters == nil {
+		g.parameters = make(map[reflect.Type]openapi3.Parameters)
+	}
+	g.parameters[model] = parameters
+	return cloneParameters(parameters)
+}
+
+// cloneParameters copies the parameters, which the operations customize, sharing their schemas.
+func cloneParameters(parameters openapi3.Parameters) openapi3.Parameters {
+	clone := make(openapi3.Parameters, len(parameters))
+	for i, param := range parameters {
+		value := *param.Value
+		value.Examples = maps.Clone(value.Examples)
+		clone[i] = &openapi3.ParameterRef{Ref: param.Ref, Value: &value}
+	}
+	return clone
 }
 
 // GenerateRequestBody generates an OpenAPI request body for a given model using the given operation ID and name tag.
@@ -133,11 +228,16 @@ func (g *Generator) GenerateParameters(model reflect.Type) openapi3.Parameters {
 // and the model to generate a request body for.
 // It returns a *spec.RequestBody that represents the generated request body.
 func (g *Generator) GenerateRequestBody(operationID, nameTag string, model reflect.Type) *openapi3.RequestBody {
-	schema := g.generateSchemaRef(nil, model, nameTag, operationID+"-body")
+	schema := g.generateSchemaRef(nil, model, bodyNameTag(nameTag), operationID+"-body")
+	mediaType := requestMediaType(nameTag)
+	content := openapi3.NewContentWithSchemaRef(schema, []string{mediaType})
+	if isProtobuf(mediaType) {
+		injectProtobufMessage(content[mediaType], model)
+	}
 	return openapi3.
 		NewRequestBody().
 		WithRequired(true).
-		WithJSONSchemaRef(schema)
+		WithContent(content)
 }
 
 func (g *Generator) GenerateResponse(code int, model any, mt string, description string) *openapi3.Response {
@@ -146,15 +246,22 @@ func (g *Generator) GenerateResponse(code int, model any, mt string, description
 		desc = description
 	}
 	response := openapi3.NewResponse().WithDescription(desc)
-	if model == nil {
+	if model == nil || isNoContent(code) {
 		return response
 	}
 
-	if mt == "application/json" {
-		schema := g.generateSchemaRef(nil, reflect.TypeOf(model), "json")
-		return response.WithJSONSchemaRef(schema)
+	if _, ok := responseEncoder(mt); !ok {
+		panic("unsupported media type " + mt)
+	}
+	schema := g.generateSchemaRef(nil, reflect.TypeOf(model), responseNameTag(mt))
+	if g.envelope && envelopes(mt) {
+		schema = envelopeSchema(code, schema)
 	}
-	panic("unsupported media type " + mt)
+	content := openapi3.NewContentWithSchemaRef(schema, []string{mt})
+	if isProtobuf(mt) {
+		injectProtobufMessage(content[mt], reflect.TypeOf(model))
+	}
+	return response.WithContent(content)
 }
 
 var primitiveSchemaFunc = map[reflect.Kind]func() *openapi3.Schema{
@@ -206,10 +313,14 @@ func (g *Generator) generateSchemaRef(parents []reflect.Type, t reflect.Type, na
 	// Check for circular references.
 	for _, parent := range parents {
 		if parent == t {
-			schemaName := g.generateSchemaName(t, name...)
+			schemaName := g.generateSchemaNameFor(t, nameTag, name...)
 			return openapi3.NewSchemaRef("#/components/schemas/"+schemaName, nil)
 		}
 	}
+	// Refer to the external schema of the type, if any.
+	if ref, ok := externalRef(t); ok {
+		return ref
+	}
 	// Check if the type implements the jsonSchema interface.
 	if t.Implements(jsonSchemaFunc) {
 		js := reflect.New(t).Interface().(jsonSchema).JSONSchema(g.doc)
@@ -249,56 +360,151 @@ func (g *Generator) generateSchemaRef(parents []reflect.Type, t reflect.Type, na
 	// Handle maps.
 	if t.Kind() == reflect.Map {
 		itemSchemaRef := g.generateSchemaRef(parents, t.Elem(), nameTag)
+		if isExternalRef(itemSchemaRef) {
+			schema := openapi3.NewObjectSchema()
+			schema.AdditionalProperties = openapi3.AdditionalProperties{Schema: itemSchemaRef}
+			return schema.NewRef()
+		}
 		return openapi3.NewObjectSchema().WithAdditionalProperties(itemSchemaRef.Value).NewRef()
 	}
 
 	// Handle structs.
 	if t.Kind() == reflect.Struct {
+		key := schemaKey{t: t, nameTag: nameTag, name: g.generateSchemaNameFor(t, nameTag, name...)}
+		if schema, ok := g.schemas[key]; ok && g.doc.Components.Schemas[key.name] != nil {
+			return openapi3.NewSchemaRef("#/components/schemas/"+key.name, schema)
+		}
 		schema := openapi3.NewObjectSchema()
+		// The declaration order of the properties, which is lost in the properties map.
+		var order []string
+		// The conditions of the conditionally required properties, by property.
+		conditions := make(map[string]string)
+		// The comparisons of the properties to their siblings, by property and prop.
+		comparisons := make(map[string]map[string]string)
 
 		// Iterate over the struct fields.
 		for i := 0; i < t.NumField(); i++ {
 			f := t.Field(i)
 
-			// Check for the OpenAPI tag "-" to skip the field, skip json tag "-" as well
-			if f.Tag.Get(OpenAPITag) == "-" || f.Tag.Get("json") == "-" {
+			// Check for the OpenAPI tag "-" to skip the field, skip json tag "-", xml tag "-" in XML documents and
+			// unexported fields as well
+			if f.Tag.Get(OpenAPITag) == "-" || f.Tag.Get("json") == "-" || (nameTag == xmlNameTag && f.Tag.Get(xmlNameTag) == "-") ||
+				(!f.IsExported() && !f.Anonymous) {
+				continue
+			}
+
+			// The XMLName field names the root element of XML documents.
+			if nameTag == xmlNameTag && f.Type == wnXMLName {
+				if xmlName, _ := xmlField(f); xmlName != f.Name {
+					schema.XML = &openapi3.XML{Name: xmlName}
+				}
 				continue
 			}
 
-			// Handle embedded structs.
+			// Handle embedded structs, and the embedded interfaces documented by a schema provider.
 			if f.Anonymous {
-				embedSchema := derefSchema(g.doc, g.generateSchemaRef(parents, f.Type, nameTag))
+				if derefType(f.Type).Kind() == reflect.Interface {
+					g.embedProvidedSchema(schema, f, &order)
+					continue
+				}
+				embedRef := g.generateSchemaRef(parents, f.Type, nameTag)
+				if isExternalRef(embedRef) {
+					schema.AllOf = append(schema.AllOf, embedRef)
+					continue
+				}
+				embedSchema := derefSchema(g.doc, embedRef)
 				for k, v := range embedSchema.Properties {
 					schema.Properties[k] = v
 				}
+				order = append(order, propertyOrder(embedSchema)...)
 				schema.Required = append(schema.Required, embedSchema.Required...)
 				continue
 			}
 
-			// Generate a schema for the field.
-			fieldSchema := g.generateSchemaRef(parents, f.Type, nameTag)
 			// Create a field resolver to handle OpenAPI tags.
 			field := newTagsResolver(f)
+			// Generate a schema for the field, unless a schema provider documents it.
+			fieldSchema := g.providedSchema(f, field)
+			if fieldSchema == nil {
+				fieldSchema = g.generateSchemaRef(parents, f.Type, nameTag)
+			}
+			injectComment(fieldSchema, fieldComment(t, f))
 			if fieldSchema.Value != nil {
-				field.injectOAITags(derefSchema(g.doc, fieldSchema))
+				fieldSchema = field.injectFieldTags(fieldSchema)
+			}
+
+			propName := g.naming.propertyName(field, nameTag)
+			if nameTag == xmlNameTag {
+				propName = injectXMLTags(f, fieldSchema)
 			}
 
 			// Add the field to the schema properties.
-			schema.Properties[field.name(nameTag)] = fieldSchema
-			if field.required() {
-				schema.Required = append(schema.Required, field.name(nameTag))
+			schema.Properties[propName] = fieldSchema
+			order = append(order, propName)
+			if field.required(nameTag) {
+				schema.Required = append(schema.Required, propName)
+			}
+			if cond, ok := field.pairs[propRequiredIf]; ok {
+				conditions[propName] = cond
 			}
+			for prop := range fieldComparisons {
+				if other, ok := field.pairs[prop]; ok {
+					if comparisons[propName] == nil {
+						comparisons[propName] = make(map[string]string)
+					}
+					comparisons[propName][prop] = other
+				}
+			}
+		}
+		for _, propName := range sortedKeys(conditions) {
+			schema.AllOf = append(schema.AllOf, g.requiredIfSchema(schema, propName, conditions[propName]))
+		}
+
+		schema.Description = typeComment(t)
+		if len(order) != 0 {
+			schema.Extensions = map[string]any{propertyOrderExtension: order}
 		}
+		documentConstraints(schema, t, comparisons)
 
-		// Generate a name for the schema and add it to the OpenAPI components.
-		schemaName := g.generateSchemaName(t, name...)
-		g.doc.Components.Schemas[schemaName] = schema.NewRef()
-		return openapi3.NewSchemaRef("#/components/schemas/"+schemaName, schema)
+		// Add the schema to the OpenAPI components under its name.
+		g.checkSchemaName(key)
+		g.doc.Components.Schemas[key.name] = schema.NewRef()
+		if g.schemas == nil {
+			g.schemas = make(map[schemaKey]*openapi3.Schema)
+		}
+		g.schemas[key] = schema
+		return openapi3.NewSchemaRef("#/components/schemas/"+key.name, schema)
 	}
 
 	panic("unsupported type " + t.String())
 }
 
+// injectFieldTags injects the OAI tags of the field into its schema. The components being shared by all the fields
+// referring to them, the tags of those fields are injected into an allOf wrapper of the reference instead.
+func (f tagsResolver) injectFieldTags(schemaRef *openapi3.SchemaRef) *openapi3.SchemaRef {
+	if schemaRef.Ref == "" {
+		f.injectOAITags(schemaRef.Value)
+		return schemaRef
+	}
+	wrapper := &openapi3.Schema{Type: schemaRef.Value.Type}
+	f.injectOAITags(wrapper)
+	if reflect.DeepEqual(wrapper, &openapi3.Schema{Type: schemaRef.Value.Type}) {
+		return schemaRef
+	}
+	wrapper.AllOf = openapi3.SchemaRefs{schemaRef}
+	return wrapper.NewRef()
+}
+
+// propertyOrderExtension lists the properties of an object schema in the declaration order of the struct fields,
+// since documentation UIs would otherwise show them in the order of the properties map.
+const propertyOrderExtension = "x-propertyOrder"
+
+// propertyOrder returns the declared order of the properties of the schema.
+func propertyOrder(schema *openapi3.Schema) []string {
+	order, _ := schema.Extensions[propertyOrderExtension].([]string)
+	return order
+}
+
 // generateSchemaName generates a name for an OpenAPI schema based on the given type.
 // It takes in the type to generate a name for and an optional name to use instead of generating one.
 // It returns a string representing the generated schema name.
@@ -324,6 +530,16 @@ func (g *Generator) generateSchemaName(t reflect.Type, name ...string) string {
 	panic("cannot generate a name for an anonymous type")
 }
 
+// generateSchemaNameFor generates a name for an OpenAPI schema generated with the given name tag.
+// Schemas generated for XML name their properties differently, so they are registered under a distinct name.
+func (g *Generator) generateSchemaNameFor(t reflect.Type, nameTag string, name ...string) string {
+	schemaName := g.generateSchemaName(t, name...)
+	if nameTag == xmlNameTag && len(name) == 0 {
+		schemaName += "XML"
+	}
+	return schemaName
+}
+
 // GenerateSchemaRef generates an OpenAPI schema for a given model using the given name tag.
 // It takes in the model to generate a schema for and a name tag to use for naming properties.
 // It returns a *spec.Schema that represents the generated schema.
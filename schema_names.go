@@ -0,0 +1,58 @@
+package soda
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// typeNameTag identifies the schemas of a type generated with a name tag.
+type typeNameTag struct {
+	t       reflect.Type
+	nameTag string
+}
+
+// checkSchemaName records the schema name of the type, panicking with the paths of both types if another type
+// already generated a schema under the name, which would otherwise be silently clobbered.
+func (g *Generator) checkSchemaName(key schemaKey) {
+	if other, ok := g.schemaTypes[key.name]; ok && other != key.t {
+		panic(fmt.Sprintf("the schema name %s is generated by both %s and %s, rename one of the types",
+			key.name, typePath(other), typePath(key.t)))
+	}
+	if g.schemaTypes == nil {
+		g.schemaTypes = make(map[string]reflect.Type)
+		g.typeNames = make(map[typeNameTag][]string)
+	}
+	g.schemaTypes[key.name] = key.t
+	tag := typeNameTag{t: key.t, nameTag: key.nameTag}
+	if !slices.Contains(g.typeNames[tag], key.name) {
+		g.typeNames[tag] = append(g.typeNames[tag], key.name)
+	}
+}
+
+// typePath returns the fully qualified name of the type, e.g. "github.com/acme/api/models.User".
+func typePath(t reflect.Type) string {
+	if t.PkgPath() == "" {
+		return t.String()
+	}
+	return t.PkgPath() + "." + t.Name()
+}
+
+// SchemaWarnings lists the types documented under several schema names, such as a request body type shared by
+// several operations, whose duplicated schemas could be merged by naming them consistently.
+// It is meant to be called once all the operations are registered.
+func (e *Engine) SchemaWarnings() []string {
+	var warnings []string
+	for tag, names := range e.gen.typeNames {
+		if len(names) < 2 {
+			continue
+		}
+		names = slices.Clone(names)
+		slices.Sort(names)
+		warnings = append(warnings, fmt.Sprintf("the type %s is documented under the schema names %s",
+			typePath(tag.t), strings.Join(names, ", ")))
+	}
+	slices.Sort(warnings)
+	return warnings
+}
@@ -0,0 +1,60 @@
+package soda_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type sharedAccount struct {
+	Name string `json:"name"`
+}
+
+func firstAccount() any {
+	type account struct {
+		Name string `json:"name"`
+	}
+	return account{}
+}
+
+func secondAccount() any {
+	type account struct {
+		ID int `json:"id"`
+	}
+	return account{}
+}
+
+func TestDuplicateSchemaNames(t *testing.T) {
+	Convey("Given operations documenting models", t, func() {
+		engine := soda.New()
+		ok := func(c *fiber.Ctx) error { return c.SendStatus(http.StatusOK) }
+
+		Convey("Distinct types generating the same schema name should panic with both type paths", func() {
+			engine.Get("/first", ok).AddJSONResponse(http.StatusOK, firstAccount()).OK()
+			So(func() {
+				engine.Get("/second", ok).AddJSONResponse(http.StatusOK, secondAccount()).OK()
+			}, ShouldPanicWith, "the schema name soda_test.account is generated by both "+
+				"github.com/neo-f/soda/v3_test.account and github.com/neo-f/soda/v3_test.account, rename one of the types")
+		})
+
+		Convey("Reusing a type under its schema name should not warn", func() {
+			engine.Get("/accounts", ok).AddJSONResponse(http.StatusOK, sharedAccount{}).OK()
+			engine.Get("/accounts/:id", ok).AddJSONResponse(http.StatusOK, sharedAccount{}).OK()
+			So(engine.SchemaWarnings(), ShouldBeEmpty)
+		})
+
+		Convey("A type documented under several names should be warned about", func() {
+			type input struct {
+				Body sharedAccount `body:"json"`
+			}
+			engine.Post("/accounts", ok).SetInput(input{}).AddJSONResponse(http.StatusOK, sharedAccount{}).OK()
+			So(engine.SchemaWarnings(), ShouldResemble, []string{
+				"the type github.com/neo-f/soda/v3_test.sharedAccount is documented under the schema names " +
+					"post--accounts-body, soda_test.sharedAccount",
+			})
+		})
+	})
+}
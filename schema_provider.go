@@ -0,0 +1,61 @@
+package soda
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// SchemaProvider documents the fields whose static type is an interface, e.g. `oai:"schema=shape"`, whose concrete
+// type is resolved by an UnmarshalJSON method of the struct holding them, which the binding of the request bodies
+// delegates to. It may add the components it refers to, e.g. the oneOf of the concrete types, to the document.
+type SchemaProvider func(doc *openapi3.T) *openapi3.SchemaRef
+
+// schemaProviders are the providers of the schemas of the interface fields, by name.
+var schemaProviders = map[string]SchemaProvider{}
+
+// RegisterSchemaProvider registers the provider under the name the fields refer to with the schema prop.
+func RegisterSchemaProvider(name string, provider SchemaProvider) {
+	schemaProviders[name] = provider
+}
+
+// providedSchema returns the schema of the interface field given by the provider of its schema prop, if any.
+func (g *Generator) providedSchema(f reflect.StructField, field *tagsResolver) *openapi3.SchemaRef {
+	name, ok := field.pairs[propSchema]
+	if !ok {
+		return nil
+	}
+	if derefType(f.Type).Kind() != reflect.Interface {
+		panic(fmt.Sprintf("the schema of the field %s can only be provided for interfaces, not %s", f.Name, f.Type))
+	}
+	provider, ok := schemaProviders[name]
+	if !ok {
+		panic(fmt.Sprintf("the schema provider %s of the field %s is not registered", name, f.Name))
+	}
+	return provider(g.doc)
+}
+
+// embedProvidedSchema documents the embedded interface field with its provided schema, whose properties are
+// merged into those of the struct, as the UnmarshalJSON method of the struct inlines them, or which the struct
+// schema is combined with if it has none, e.g. a oneOf. The embedded interfaces without a provider are skipped.
+func (g *Generator) embedProvidedSchema(schema *openapi3.Schema, f reflect.StructField, order *[]string) {
+	ref := g.providedSchema(f, newTagsResolver(f))
+	if ref == nil {
+		return
+	}
+	embedSchema := derefSchema(g.doc, ref)
+	if len(embedSchema.Properties) == 0 {
+		schema.AllOf = append(schema.AllOf, ref)
+		return
+	}
+	for k, v := range embedSchema.Properties {
+		schema.Properties[k] = v
+	}
+	if embedOrder := propertyOrder(embedSchema); len(embedOrder) != 0 {
+		*order = append(*order, embedOrder...)
+	} else {
+		*order = append(*order, sortedKeys(embedSchema.Properties)...)
+	}
+	schema.Required = append(schema.Required, embedSchema.Required...)
+}
@@ -0,0 +1,152 @@
+package soda_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type Shape interface{ Area() float64 }
+
+type Circle struct {
+	Radius float64 `json:"radius"`
+	Unit   *string `json:"unit" oai:"const=cm"`
+}
+
+func (c Circle) Area() float64 { return 3 * c.Radius * c.Radius }
+
+type Square struct {
+	Side float64 `json:"side"`
+}
+
+func (s Square) Area() float64 { return s.Side * s.Side }
+
+// Figure holds a shape, resolved by its kind.
+type Figure struct {
+	Kind  string `json:"kind" oai:"enum=circle,square"`
+	Shape Shape  `json:"shape" oai:"schema=shape"`
+}
+
+func (f *Figure) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Kind  string          `json:"kind"`
+		Shape json.RawMessage `json:"shape"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	f.Kind = raw.Kind
+	if raw.Kind == "circle" {
+		var c Circle
+		f.Shape = &c
+		return json.Unmarshal(raw.Shape, &c)
+	}
+	var s Square
+	f.Shape = &s
+	return json.Unmarshal(raw.Shape, &s)
+}
+
+// Labeled embeds its shape, inlined with the label.
+type Labeled struct {
+	Shape `oai:"schema=circle"`
+	Label string `json:"label"`
+}
+
+func (l *Labeled) UnmarshalJSON(data []byte) error {
+	var c Circle
+	if err := json.Unmarshal(data, &c); err != nil {
+		return err
+	}
+	var raw struct {
+		Label string `json:"label"`
+	}
+	l.Shape = &c
+	return json.Unmarshal(data, &raw)
+}
+
+func init() {
+	soda.RegisterSchemaProvider("shape", func(doc *openapi3.T) *openapi3.SchemaRef {
+		schema := openapi3.NewOneOfSchema(
+			openapi3.NewObjectSchema().WithProperty("radius", openapi3.NewFloat64Schema()),
+			openapi3.NewObjectSchema().WithProperty("side", openapi3.NewFloat64Schema()),
+		)
+		return schema.NewRef()
+	})
+	soda.RegisterSchemaProvider("circle", func(doc *openapi3.T) *openapi3.SchemaRef {
+		return openapi3.NewObjectSchema().WithProperty("radius", openapi3.NewFloat64Schema()).WithRequired([]string{"radius"}).NewRef()
+	})
+}
+
+func TestSchemaProvider(t *testing.T) {
+	Convey("Given bodies with interface fields resolved by their UnmarshalJSON method", t, func() {
+		engine := soda.New().SetBindErrorStatus(http.StatusBadRequest)
+		engine.Post("/figures", func(c *fiber.Ctx) error {
+			return c.JSON(soda.GetInput[struct {
+				Body Figure `body:"json"`
+			}](c).Body.Shape.Area())
+		}).SetInput(&struct {
+			Body Figure `body:"json"`
+		}{}).OK()
+		engine.Post("/labels", func(c *fiber.Ctx) error { return nil }).SetInput(&struct {
+			Body Labeled `body:"json"`
+		}{}).OK()
+		post := func(path, body string) *http.Response {
+			req := httptest.NewRequest("POST", path, strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := engine.App().Test(req)
+			So(err, ShouldBeNil)
+			return resp
+		}
+
+		Convey("The interface fields should be documented by their schema provider", func() {
+			schema := engine.OpenAPI().Paths.Value("/figures").Post.RequestBody.Value.Content.Get("application/json").Schema.Value
+			So(schema.Properties["shape"].Value.OneOf, ShouldHaveLength, 2)
+		})
+
+		Convey("The properties of the embedded interfaces should be inlined", func() {
+			schema := engine.OpenAPI().Paths.Value("/labels").Post.RequestBody.Value.Content.Get("application/json").Schema.Value
+			So(schema.Properties, ShouldContainKey, "radius")
+			So(schema.Properties, ShouldContainKey, "label")
+			So(schema.Required, ShouldContain, "radius")
+		})
+
+		Convey("The binding should delegate to the UnmarshalJSON method", func() {
+			resp := post("/figures", `{"kind":"square","shape":{"side":2}}`)
+			So(resp.StatusCode, ShouldEqual, http.StatusOK)
+		})
+
+		Convey("The rules of the dynamic values should be enforced", func() {
+			resp := post("/figures", `{"kind":"circle","shape":{"radius":1,"unit":"in"}}`)
+			So(resp.StatusCode, ShouldEqual, http.StatusBadRequest)
+			resp = post("/labels", `{"label":"a","radius":1,"unit":"in"}`)
+			So(resp.StatusCode, ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("An unknown provider should panic", func() {
+			So(func() {
+				engine.Post("/unknown", nil).SetInput(&struct {
+					Body struct {
+						Shape Shape `json:"shape" oai:"schema=polygon"`
+					} `body:"json"`
+				}{})
+			}, ShouldPanicWith, "the schema provider polygon of the field Shape is not registered")
+		})
+
+		Convey("A provider on a concrete field should panic", func() {
+			So(func() {
+				engine.Post("/concrete", nil).SetInput(&struct {
+					Body struct {
+						Circle Circle `json:"circle" oai:"schema=circle"`
+					} `body:"json"`
+				}{})
+			}, ShouldPanicWith, "the schema of the field Circle can only be provided for interfaces, not soda_test.Circle")
+		})
+	})
+}
@@ -126,7 +126,7 @@ func TestGenerator(t *testing.T) {
 					WithProperty("A", openapi3.NewStringSchema()).
 					WithProperty("B", openapi3.NewIntegerSchema()).
 					WithRequired([]string{"A", "B"})
-				So(schema.Value, ShouldResemble, expected)
+				So(schema.Value, ShouldResemble, withPropertyOrder(expected, "A", "B"))
 				So(schema.Ref, ShouldEqual, "#/components/schemas/soda_test.TestCase")
 			})
 
@@ -140,7 +140,7 @@ func TestGenerator(t *testing.T) {
 					WithProperty("A", openapi3.NewStringSchema()).
 					WithProperty("B", openapi3.NewIntegerSchema()).
 					WithRequired([]string{"A", "B"})
-				So(schema.Value, ShouldResemble, expected)
+				So(schema.Value, ShouldResemble, withPropertyOrder(expected, "A", "B"))
 				So(schema.Ref, ShouldEqual, "#/components/schemas/soda_test.TestCase")
 			})
 
@@ -154,7 +154,7 @@ func TestGenerator(t *testing.T) {
 					WithProperty("items", openapi3.NewArraySchema().WithItems(openapi3.NewStringSchema())).
 					WithProperty("total", openapi3.NewIntegerSchema()).
 					WithRequired([]string{"items", "total"})
-				So(schema.Value, ShouldResemble, expected)
+				So(schema.Value, ShouldResemble, withPropertyOrder(expected, "items", "total"))
 			})
 
 			Convey("It should return the correct schema for a struct slices", func() {
@@ -165,9 +165,9 @@ func TestGenerator(t *testing.T) {
 				expected := openapi3.NewArraySchema()
 				expected.Items = openapi3.NewSchemaRef(
 					"#/components/schemas/soda_test.TestCase",
-					openapi3.NewObjectSchema().
+					withPropertyOrder(openapi3.NewObjectSchema().
 						WithProperty("a", openapi3.NewStringSchema()).
-						WithRequired([]string{"a"}),
+						WithRequired([]string{"a"}), "a"),
 				)
 				So(schema.Value, ShouldResemble, expected)
 			})
@@ -190,7 +190,7 @@ func TestGenerator(t *testing.T) {
 					WithProperty("string5", openapi3.NewArraySchema().WithItems(openapi3.NewStringSchema())).
 					WithProperty("string6", openapi3.NewArraySchema().WithItems(openapi3.NewStringSchema())).
 					WithRequired([]string{"string1", "string3", "string5"})
-				So(schema.Value, ShouldResemble, expected)
+				So(schema.Value, ShouldResemble, withPropertyOrder(expected, "string1", "string2", "string3", "string4", "string5", "string6"))
 				So(schema.Ref, ShouldEqual, "#/components/schemas/lol")
 			})
 
@@ -227,7 +227,7 @@ func TestGenerator(t *testing.T) {
 					WithProperty("A", openapi3.NewStringSchema()).
 					WithProperty("B", openapi3.NewIntegerSchema()).
 					WithRequired([]string{"A", "B"})
-				So(schema.Value, ShouldResemble, expected)
+				So(schema.Value, ShouldResemble, withPropertyOrder(expected, "A", "B"))
 				So(schema.Ref, ShouldEqual, "#/components/schemas/soda_test.embeddedStruct")
 			})
 
@@ -242,7 +242,7 @@ func TestGenerator(t *testing.T) {
 					WithProperty("B", openapi3.NewIntegerSchema()).
 					WithRequired([]string{"A", "B"})
 				expected := openapi3.NewArraySchema()
-				expected.Items = openapi3.NewSchemaRef("#/components/schemas/soda_test.TestCase", itemsSchema)
+				expected.Items = openapi3.NewSchemaRef("#/components/schemas/soda_test.TestCase", withPropertyOrder(itemsSchema, "A", "B"))
 				So(schema.Value, ShouldEqual, expected)
 			})
 
@@ -255,7 +255,20 @@ func TestGenerator(t *testing.T) {
 				expected := openapi3.NewObjectSchema().
 					WithProperty("A", openapi3.NewStringSchema()).
 					WithRequired([]string{"A"})
-				So(schema.Value, ShouldEqual, expected)
+				So(schema.Value, ShouldEqual, withPropertyOrder(expected, "A"))
+			})
+
+			Convey("It should keep the declaration order of the struct fields", func() {
+				type Base struct {
+					ID string `json:"id"`
+				}
+				type orderedStruct struct {
+					Zebra string `json:"zebra"`
+					Base
+					Apple string `json:"apple"`
+				}
+				schema := soda.GenerateSchemaRef(orderedStruct{}, "json")
+				So(schema.Value.Extensions["x-propertyOrder"], ShouldResemble, []string{"zebra", "id", "apple"})
 			})
 
 			Convey("It should panic for unsupported types", func() {
@@ -389,6 +402,53 @@ func TestGenerator(t *testing.T) {
 				So(parameters, ShouldHaveLength, 2)
 			})
 
+			Convey("It should flatten nested struct parameters", func() {
+				type address struct {
+					City string `query:"city"`
+					Zip  *int   `query:"zip"`
+				}
+				type filter struct {
+					Field string `query:"field"`
+					Op    string `query:"op"`
+				}
+				type schema struct {
+					Address  address   `query:"address"`
+					Billing  *address  `query:"billing"`
+					Filters  []filter  `query:"filters"`
+					Page     *int      `query:"page"`
+					Birthday time.Time `query:"birthday"`
+				}
+				parameters := g.GenerateParameters(reflect.TypeOf(schema{}))
+				So(parameters, ShouldHaveLength, 7)
+				So(parameters.GetByInAndName("query", "address"), ShouldBeNil)
+				So(parameters.GetByInAndName("query", "address.city").Required, ShouldBeTrue)
+				So(parameters.GetByInAndName("query", "address.zip").Required, ShouldBeFalse)
+				So(parameters.GetByInAndName("query", "billing.city").Required, ShouldBeFalse)
+				So(parameters.GetByInAndName("query", "page").Required, ShouldBeFalse)
+				So(parameters.GetByInAndName("query", "birthday").Schema.Value.Format, ShouldEqual, "date-time")
+				filters := parameters.GetByInAndName("query", "filters")
+				So(filters.Style, ShouldEqual, openapi3.SerializationDeepObject)
+				So(*filters.Explode, ShouldBeTrue)
+				So(filters.Schema.Value.Type.Is("array"), ShouldBeTrue)
+			})
+
+			Convey("It should generate deprecated parameters with examples", func() {
+				type schema struct {
+					Sort   string `query:"sort"    oai:"deprecated;example=name"`
+					Order  string `query:"order"   oai:"examples=asc,desc"`
+					Region string `header:"Region" oai:"example=eu"`
+				}
+				parameters := g.GenerateParameters(reflect.TypeOf(schema{}))
+				sort := parameters.GetByInAndName("query", "sort")
+				So(sort.Deprecated, ShouldBeTrue)
+				So(sort.Example, ShouldEqual, "name")
+				order := parameters.GetByInAndName("query", "order")
+				So(order.Example, ShouldBeNil)
+				So(order.Examples, ShouldHaveLength, 2)
+				So(order.Examples["desc"].Value.Value, ShouldEqual, "desc")
+				So(parameters.GetByInAndName("header", "Region").Example, ShouldEqual, "eu")
+			})
+
 			Convey("It should panic while invalid parameters", func() {
 				type schema struct {
 					A []string `query:"a"`
@@ -400,6 +460,52 @@ func TestGenerator(t *testing.T) {
 		})
 	})
 
+	Convey("Given models shared by operations", t, func() {
+		g := soda.NewGenerator()
+		type shared struct {
+			A string `json:"a" query:"a"`
+		}
+
+		Convey("Their schemas should be generated once", func() {
+			first := g.GenerateResponse(200, shared{}, "application/json", "")
+			second := g.GenerateResponse(201, shared{}, "application/json", "")
+			So(second.Content.Get("application/json").Schema.Value, ShouldPointTo, first.Content.Get("application/json").Schema.Value)
+		})
+
+		Convey("Their parameters should be generated once but copied", func() {
+			first := g.GenerateParameters(reflect.TypeOf(shared{}))
+			second := g.GenerateParameters(reflect.TypeOf(shared{}))
+			So(second[0].Value, ShouldNotPointTo, first[0].Value)
+			So(second[0].Value.Schema, ShouldPointTo, first[0].Value.Schema)
+			first[0].Value.Description = "changed"
+			So(second[0].Value.Description, ShouldBeEmpty)
+		})
+
+		Convey("The tags of the fields should not change the shared schemas", func() {
+			type address struct {
+				City string `json:"city"`
+			}
+			type shipping struct {
+				Ship address `json:"ship" oai:"description=shipping;readOnly"`
+			}
+			type billing struct {
+				Bill address `json:"bill"`
+			}
+			ship := g.GenerateResponse(200, shipping{}, "application/json", "").Content.Get("application/json").Schema.Value
+			bill := g.GenerateResponse(200, billing{}, "application/json", "").Content.Get("application/json").Schema.Value
+
+			shipRef := ship.Properties["ship"]
+			So(shipRef.Ref, ShouldBeEmpty)
+			So(shipRef.Value.Description, ShouldEqual, "shipping")
+			So(shipRef.Value.ReadOnly, ShouldBeTrue)
+			So(shipRef.Value.AllOf[0].Ref, ShouldEqual, bill.Properties["bill"].Ref)
+			billRef := bill.Properties["bill"]
+			So(billRef.Ref, ShouldNotBeEmpty)
+			So(billRef.Value.Description, ShouldBeEmpty)
+			So(billRef.Value.ReadOnly, ShouldBeFalse)
+		})
+	})
+
 	Convey("Given request body generation", t, func() {
 		Convey("It should not be nil", func() {
 			g := soda.NewGenerator()
@@ -447,3 +553,9 @@ func TestGenerator(t *testing.T) {
 		})
 	})
 }
+
+// withPropertyOrder sets the x-propertyOrder extension expected on generated object schemas.
+func withPropertyOrder(schema *openapi3.Schema, names ...string) *openapi3.Schema {
+	schema.Extensions = map[string]any{"x-propertyOrder": names}
+	return schema
+}
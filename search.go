@@ -0,0 +1,116 @@
+package soda
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+)
+
+// SearchResult is an operation matching a search of ServeSearch.
+type SearchResult struct {
+	Method      string   `json:"method"`
+	Path        string   `json:"path"`
+	OperationID string   `json:"operationId,omitempty"`
+	Summary     string   `json:"summary,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	Deprecated  bool     `json:"deprecated,omitempty"`
+	// Score ranks the results, the matches in the paths and summaries weighing more than in the descriptions.
+	Score int `json:"score"`
+}
+
+// defaultSearchLimit is the number of results of a search without a limit parameter.
+const defaultSearchLimit = 20
+
+// ServeSearch serves the search of the documented operations, e.g. GET /openapi/search?q=invoice&limit=10, matching
+// every word of the query against their paths, operation IDs, tags, summaries and descriptions, so that developer
+// portals can search without loading the whole spec. The operations are those of the spec served to the request.
+func (e *Engine) ServeSearch(pattern string) *Engine {
+	e.serveDoc(pattern, func(c *fiber.Ctx) error {
+		limit := defaultSearchLimit
+		if raw := c.Query("limit"); raw != "" {
+			n, err := strconv.Atoi(raw)
+			if err != nil || n <= 0 {
+				return fiber.NewError(http.StatusBadRequest, "invalid limit")
+			}
+			limit = n
+		}
+		doc := e.gen.doc
+		if e.specCustomized() {
+			doc = e.specFor(c).doc
+		} else {
+			e.specMu.Lock()
+			defer e.specMu.Unlock()
+		}
+		results := searchOperations(doc, c.Query("q"))
+		if len(results) > limit {
+			results = results[:limit]
+		}
+		return c.JSON(results)
+	})
+	return e
+}
+
+// searchOperations returns the operations of the spec matching every word of the query, by descending score.
+func searchOperations(doc *openapi3.T, query string) []SearchResult {
+	words := strings.Fields(strings.ToLower(query))
+	results := make([]SearchResult, 0)
+	if len(words) == 0 {
+		return results
+	}
+	paths := doc.Paths.Map()
+	for _, path := range sortedKeys(paths) {
+		operations := paths[path].Operations()
+		for _, method := range sortedKeys(operations) {
+			op := operations[method]
+			score := scoreOperation(path, op, words)
+			if score == 0 {
+				continue
+			}
+			results = append(results, SearchResult{
+				Method:      method,
+				Path:        path,
+				OperationID: op.OperationID,
+				Summary:     op.Summary,
+				Description: op.Description,
+				Tags:        op.Tags,
+				Deprecated:  op.Deprecated,
+				Score:       score,
+			})
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool { return results[i].Score > results[j].Score })
+	return results
+}
+
+// scoreOperation scores the matches of the words in the operation, or returns 0 unless they all match.
+func scoreOperation(path string, op *openapi3.Operation, words []string) int {
+	fields := []struct {
+		text   string
+		weight int
+	}{
+		{strings.ToLower(path), 3},
+		{strings.ToLower(op.OperationID), 3},
+		{strings.ToLower(op.Summary), 3},
+		{strings.ToLower(strings.Join(op.Tags, " ")), 2},
+		{strings.ToLower(op.Description), 1},
+	}
+	score := 0
+	for _, word := range words {
+		matched := 0
+		for _, field := range fields {
+			if strings.Contains(field.text, word) {
+				matched += field.weight
+			}
+		}
+		if matched == 0 {
+			return 0
+		}
+		score += matched
+	}
+	return score
+}
@@ -0,0 +1,69 @@
+package soda_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestServeSearch(t *testing.T) {
+	Convey("Given an engine serving the search of its operations", t, func() {
+		engine := soda.New()
+		ok := func(c *fiber.Ctx) error { return c.SendStatus(http.StatusOK) }
+		engine.Get("/invoices", ok).SetSummary("List invoices").AddTags("billing").OK()
+		engine.Post("/invoices", ok).SetSummary("Create an invoice").AddTags("billing").OK()
+		engine.Get("/customers", ok).SetSummary("List customers").SetDescription("Includes their unpaid invoices.").OK()
+		engine.Get("/health", ok).SetSummary("Check the health").OK()
+		engine.ServeSearch("/openapi/search")
+
+		search := func(query string) (int, []soda.SearchResult) {
+			resp, err := engine.App().Test(httptest.NewRequest("GET", "/openapi/search?"+query, nil))
+			So(err, ShouldBeNil)
+			var results []soda.SearchResult
+			_ = json.NewDecoder(resp.Body).Decode(&results)
+			return resp.StatusCode, results
+		}
+
+		Convey("The matching operations should be ranked by score", func() {
+			status, results := search("q=invoice")
+			So(status, ShouldEqual, http.StatusOK)
+			So(results, ShouldHaveLength, 3)
+			So(results[0].Path, ShouldEqual, "/invoices")
+			So(results[2].Path, ShouldEqual, "/customers")
+			So(results[0].Score, ShouldBeGreaterThan, results[2].Score)
+		})
+
+		Convey("Every word of the query should match", func() {
+			_, results := search("q=list+billing")
+			So(results, ShouldHaveLength, 1)
+			So(results[0].Method, ShouldEqual, "GET")
+			So(results[0].Summary, ShouldEqual, "List invoices")
+			So(results[0].Tags, ShouldResemble, []string{"billing"})
+
+			_, results = search("q=")
+			So(results, ShouldBeEmpty)
+		})
+
+		Convey("The results should be limited", func() {
+			_, results := search("q=invoice&limit=1")
+			So(results, ShouldHaveLength, 1)
+			status, _ := search("q=invoice&limit=zero")
+			So(status, ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("The search should cover the spec served to the request", func() {
+			engine.UseSpecTransformer(soda.SpecTransformer{
+				Variant:   func(c *fiber.Ctx) string { return "public" },
+				Transform: func(_ string, doc *openapi3.T) { doc.Paths.Delete("/customers") },
+			})
+			_, results := search("q=invoice")
+			So(results, ShouldHaveLength, 2)
+		})
+	})
+}
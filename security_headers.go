@@ -0,0 +1,59 @@
+package soda
+
+import (
+	"maps"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// securityHeadersExtension documents the security headers of the responses at the root of the spec.
+const securityHeadersExtension = "x-security-headers"
+
+// DefaultSecurityHeaders are the hardened defaults of the security headers of the responses of an API.
+var DefaultSecurityHeaders = map[string]string{
+	fiber.HeaderStrictTransportSecurity:       "max-age=31536000; includeSubDomains",
+	fiber.HeaderXContentTypeOptions:           "nosniff",
+	fiber.HeaderXFrameOptions:                 "DENY",
+	fiber.HeaderContentSecurityPolicy:         "default-src 'none'; frame-ancestors 'none'",
+	fiber.HeaderReferrerPolicy:                "no-referrer",
+	"Cross-Origin-Opener-Policy":              "same-origin",
+	fiber.HeaderCrossOriginResourcePolicy:     "same-origin",
+	fiber.HeaderXPermittedCrossDomainPolicies: "none",
+}
+
+// UseSecurityHeaders sets the DefaultSecurityHeaders on the responses of the operations finalized afterwards,
+// the overrides replacing them, or removing them if empty, e.g. {"Strict-Transport-Security": ""} behind a proxy
+// setting it. The headers are documented once by the x-security-headers extension of the spec, so that the
+// security reviews can check them against the contract. The documentation endpoints, whose UIs load scripts,
+// are left alone.
+func (e *Engine) UseSecurityHeaders(overrides ...map[string]string) *Engine {
+	headers := maps.Clone(DefaultSecurityHeaders)
+	for _, override := range overrides {
+		for name, value := range override {
+			if value == "" {
+				delete(headers, name)
+			} else {
+				headers[name] = value
+			}
+		}
+	}
+	e.invalidateSpec()
+	e.securityHeaders = headers
+	doc := e.gen.doc
+	if doc.Extensions == nil {
+		doc.Extensions = make(map[string]any)
+	}
+	doc.Extensions[securityHeadersExtension] = headers
+	return e
+}
+
+// securityHeadersHandler sets the security headers of the responses, which the handlers may still override.
+func securityHeadersHandler(headers map[string]string) fiber.Handler {
+	names := sortedKeys(headers)
+	return func(c *fiber.Ctx) error {
+		for _, name := range names {
+			c.Set(name, headers[name])
+		}
+		return c.Next()
+	}
+}
@@ -0,0 +1,54 @@
+package soda_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSecurityHeaders(t *testing.T) {
+	Convey("Given operations with the security headers", t, func() {
+		engine := soda.New().UseSecurityHeaders(map[string]string{
+			"Strict-Transport-Security": "",
+			"Referrer-Policy":           "same-origin",
+		})
+		engine.Get("/users", func(c *fiber.Ctx) error { return c.SendString("ok") }).OK()
+		engine.Get("/frames", func(c *fiber.Ctx) error {
+			c.Set("X-Frame-Options", "SAMEORIGIN")
+			return c.SendString("ok")
+		}).OK()
+		engine.ServeDocUI("/docs", soda.UISwaggerUI)
+		get := func(target string) http.Header {
+			resp, err := engine.App().Test(httptest.NewRequest("GET", target, nil))
+			So(err, ShouldBeNil)
+			return resp.Header
+		}
+
+		Convey("The responses should have the hardened headers", func() {
+			headers := get("/users")
+			So(headers.Get("X-Content-Type-Options"), ShouldEqual, "nosniff")
+			So(headers.Get("X-Frame-Options"), ShouldEqual, "DENY")
+			So(headers.Get("Content-Security-Policy"), ShouldEqual, "default-src 'none'; frame-ancestors 'none'")
+			So(headers.Get("Referrer-Policy"), ShouldEqual, "same-origin")
+			So(headers.Get("Strict-Transport-Security"), ShouldBeEmpty)
+		})
+
+		Convey("The handlers should be able to override them", func() {
+			So(get("/frames").Get("X-Frame-Options"), ShouldEqual, "SAMEORIGIN")
+		})
+
+		Convey("The documentation endpoints should be left alone", func() {
+			So(get("/docs").Get("Content-Security-Policy"), ShouldBeEmpty)
+		})
+
+		Convey("The headers should be documented once in the spec", func() {
+			headers := engine.OpenAPI().Extensions["x-security-headers"].(map[string]string)
+			So(headers["X-Content-Type-Options"], ShouldEqual, "nosniff")
+			So(headers, ShouldNotContainKey, "Strict-Transport-Security")
+		})
+	})
+}
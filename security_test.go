@@ -0,0 +1,62 @@
+package soda_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestHMACSecurity(t *testing.T) {
+	Convey("Given a webhook receiver verifying HMAC signatures", t, func() {
+		cfg := soda.HMACConfig{Header: "X-Signature", Secret: []byte("secret"), Prefix: "sha256="}
+		sign := func(body string) string {
+			mac := hmac.New(sha256.New, cfg.Secret)
+			mac.Write([]byte(body))
+			return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+		}
+
+		engine := soda.New()
+		engine.Post("/webhook", func(c *fiber.Ctx) error {
+			return c.SendStatus(http.StatusNoContent)
+		}).AddHMACSecurity("webhook", cfg).OK()
+
+		hooks := engine.Group("/hooks").AddHMACSecurity("webhook", cfg)
+		hooks.Post("/event", func(c *fiber.Ctx) error {
+			return c.SendStatus(http.StatusNoContent)
+		}).OK()
+
+		post := func(path, body, signature string) int {
+			request, _ := http.NewRequest("POST", path, strings.NewReader(body))
+			request.Header.Add("X-Signature", signature)
+			response, _ := engine.App().Test(request)
+			return response.StatusCode
+		}
+
+		Convey("The signature header and the 401 response should be documented", func() {
+			operation := engine.OpenAPI().Paths.Find("/webhook").Post
+			So((*operation.Security)[0], ShouldContainKey, "webhook")
+			So(operation.Responses.Status(http.StatusUnauthorized), ShouldNotBeNil)
+			scheme := engine.OpenAPI().Components.SecuritySchemes["webhook"].Value
+			So(scheme.In, ShouldEqual, "header")
+			So(scheme.Name, ShouldEqual, "X-Signature")
+		})
+
+		Convey("A correctly signed request should be accepted", func() {
+			So(post("/webhook", `{"a":1}`, sign(`{"a":1}`)), ShouldEqual, http.StatusNoContent)
+			So(post("/hooks/event", `{"a":1}`, sign(`{"a":1}`)), ShouldEqual, http.StatusNoContent)
+		})
+
+		Convey("A tampered or unsigned request should be rejected", func() {
+			So(post("/webhook", `{"a":2}`, sign(`{"a":1}`)), ShouldEqual, http.StatusUnauthorized)
+			So(post("/webhook", `{"a":1}`, ""), ShouldEqual, http.StatusUnauthorized)
+			So(post("/hooks/event", `{"a":1}`, "sha256=zz"), ShouldEqual, http.StatusUnauthorized)
+		})
+	})
+}
@@ -0,0 +1,121 @@
+package sodatest
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+)
+
+// Coverage records the operations and the response statuses exercised by a test suite,
+// to report the untested parts of the API contract.
+type Coverage struct {
+	engine *soda.Engine
+
+	mu   sync.Mutex
+	hits map[string]map[int]bool
+}
+
+// NewCoverage records the coverage of the operations of the engine. Its Middleware must be installed
+// on the app of the engine before the operations are registered.
+func NewCoverage(engine *soda.Engine) *Coverage {
+	return &Coverage{engine: engine, hits: make(map[string]map[int]bool)}
+}
+
+// Middleware records the operation and the response status of the requests.
+func (c *Coverage) Middleware() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		err := ctx.Next()
+		status := ctx.Response().StatusCode()
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+		key := ctx.Method() + " " + ctx.Route().Path
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if c.hits[key] == nil {
+			c.hits[key] = make(map[int]bool)
+		}
+		c.hits[key][status] = true
+		return err
+	}
+}
+
+// CoverageReport lists the documented operations and responses no test exercised.
+type CoverageReport struct {
+	// Operations is the number of documented operations.
+	Operations int
+	// Untested lists the operations never called, such as "GET /items/:id".
+	Untested []string
+	// UntestedStatuses lists the documented responses never sent by tested operations, such as "GET /items/:id 404".
+	UntestedStatuses []string
+}
+
+// Ratio returns the ratio of tested operations.
+func (r CoverageReport) Ratio() float64 {
+	if r.Operations == 0 {
+		return 1
+	}
+	return float64(r.Operations-len(r.Untested)) / float64(r.Operations)
+}
+
+func (r CoverageReport) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "API coverage: %.1f%% of %d operations", r.Ratio()*100, r.Operations)
+	for _, op := range r.Untested {
+		fmt.Fprintf(&b, "\n  untested operation: %s", op)
+	}
+	for _, status := range r.UntestedStatuses {
+		fmt.Fprintf(&b, "\n  untested response: %s", status)
+	}
+	return b.String()
+}
+
+// Report reports the coverage of the documented operations.
+func (c *Coverage) Report() CoverageReport {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var report CoverageReport
+	doc := c.engine.OpenAPI()
+	for _, route := range c.engine.Routes() {
+		if !route.Documented {
+			continue
+		}
+		report.Operations++
+		key := route.Method + " " + route.Path
+		hits, ok := c.hits[key]
+		if !ok {
+			report.Untested = append(report.Untested, key)
+			continue
+		}
+		item := doc.Paths.Value(route.DocPath)
+		if item == nil {
+			item = doc.Paths.Find(route.DocPath)
+		}
+		if item == nil || item.GetOperation(route.Method) == nil {
+			continue
+		}
+		var untested []int
+		for code := range item.GetOperation(route.Method).Responses.Map() {
+			if status, err := strconv.Atoi(code); err == nil && !hits[status] {
+				untested = append(untested, status)
+			}
+		}
+		sort.Ints(untested)
+		for _, status := range untested {
+			report.UntestedStatuses = append(report.UntestedStatuses, fmt.Sprintf("%s %d", key, status))
+		}
+	}
+	return report
+}
+
+// Log logs the report of the coverage, typically at the end of TestMain or of a test.
+func (c *Coverage) Log(t testing.TB) {
+	t.Helper()
+	t.Log(c.Report())
+}
@@ -0,0 +1,46 @@
+package sodatest_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	"github.com/neo-f/soda/v3/sodatest"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCoverage(t *testing.T) {
+	Convey("Given an API recording its coverage", t, func() {
+		engine := soda.New()
+		coverage := sodatest.NewCoverage(engine)
+		engine.App().Use(coverage.Middleware())
+
+		engine.Get("/items/:id", func(c *fiber.Ctx) error {
+			if c.Params("id") == "0" {
+				return soda.NewError(http.StatusNotFound, "not_found", "no such item")
+			}
+			return c.SendStatus(http.StatusOK)
+		}).
+			AddJSONResponse(http.StatusOK, nil).
+			AddErrorResponses(http.StatusNotFound, http.StatusConflict).
+			OK()
+		engine.Delete("/items/:id", func(c *fiber.Ctx) error { return nil }).OK()
+
+		Convey("The report should list the untested operations and responses", func() {
+			for _, path := range []string{"/items/1", "/items/0"} {
+				_, err := engine.App().Test(httptest.NewRequest("GET", path, nil))
+				So(err, ShouldBeNil)
+			}
+			report := coverage.Report()
+			So(report.Operations, ShouldEqual, 2)
+			So(report.Untested, ShouldResemble, []string{"DELETE /items/:id"})
+			So(report.UntestedStatuses, ShouldResemble, []string{"GET /items/:id 409"})
+			So(report.Ratio(), ShouldEqual, 0.5)
+			So(report.String(), ShouldEqual, "API coverage: 50.0% of 2 operations\n"+
+				"  untested operation: DELETE /items/:id\n"+
+				"  untested response: GET /items/:id 409")
+		})
+	})
+}
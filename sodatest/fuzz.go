@@ -0,0 +1,254 @@
+// Package sodatest provides utilities to test the APIs built with soda against their generated spec.
+package sodatest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/neo-f/soda/v3"
+)
+
+// fuzzCase is a request fired at an operation.
+type fuzzCase struct {
+	name    string
+	route   string
+	path    string
+	query   url.Values
+	headers http.Header
+	body    string
+}
+
+// regexPathParam matches the path parameters, in both the fiber and the OpenAPI notation.
+var regexPathParam = regexp.MustCompile(`:(\w+)\??|\{(\w+)\}`)
+
+// Fuzz walks the spec of the engine and fires boundary and invalid values of every parameter and request body
+// at the operations, asserting that none of them responds with a 5xx status, and that the error responses
+// match the schema documented for their status, if any.
+// The inputs are derived from the spec only, so handlers should tolerate being called with them.
+func Fuzz(t testing.TB, engine *soda.Engine) {
+	t.Helper()
+	doc := engine.OpenAPI()
+	paths := doc.Paths.Map()
+	keys := make([]string, 0, len(paths))
+	for path := range paths {
+		keys = append(keys, path)
+	}
+	sort.Strings(keys)
+	for _, path := range keys {
+		for method, op := range paths[path].Operations() {
+			for _, c := range fuzzCases(path, op) {
+				fire(t, engine, doc, method, op, c)
+			}
+		}
+	}
+}
+
+// fuzzCases returns the cases of the operation, each of which alters a single parameter or the body
+// of a baseline request.
+func fuzzCases(path string, op *openapi3.Operation) []fuzzCase {
+	base := fuzzCase{route: path, path: path, query: url.Values{}, headers: http.Header{}}
+	params := make(map[string]*openapi3.Parameter)
+	for _, ref := range op.Parameters {
+		p := ref.Value
+		params[p.In+":"+p.Name] = p
+		if p.Required && p.In != openapi3.ParameterInPath {
+			setParam(&base, p, validValue(p.Schema))
+		}
+	}
+	base.path = regexPathParam.ReplaceAllStringFunc(path, func(s string) string {
+		if p, ok := params["path:"+strings.Trim(s, ":?{}")]; ok {
+			return url.PathEscape(validValue(p.Schema))
+		}
+		return "1"
+	})
+	if op.RequestBody != nil && op.RequestBody.Value != nil {
+		if mt := op.RequestBody.Value.Content.Get("application/json"); mt != nil {
+			base.headers.Set("Content-Type", "application/json")
+			base.body = "{}"
+		}
+	}
+
+	cases := []fuzzCase{withName(base, "baseline")}
+	for _, ref := range op.Parameters {
+		p := ref.Value
+		for _, value := range invalidValues(p.Schema) {
+			c := clone(base)
+			c.name = fmt.Sprintf("%s parameter %s=%q", p.In, p.Name, truncate(value))
+			if p.In == openapi3.ParameterInPath {
+				c.path = regexPathParam.ReplaceAllStringFunc(path, func(s string) string {
+					if strings.Trim(s, ":?{}") == p.Name {
+						return url.PathEscape(value)
+					}
+					return url.PathEscape(validValue(params["path:"+strings.Trim(s, ":?{}")].Schema))
+				})
+			} else {
+				setParam(&c, p, value)
+			}
+			cases = append(cases, c)
+		}
+	}
+	if base.body != "" {
+		for _, body := range invalidBodies(op.RequestBody.Value.Content.Get("application/json").Schema) {
+			c := clone(base)
+			c.name = "body " + truncate(body)
+			c.body = body
+			cases = append(cases, c)
+		}
+	}
+	return cases
+}
+
+// truncate shortens long values in the names of the cases.
+func truncate(s string) string {
+	if len(s) > 32 {
+		return s[:32] + "..."
+	}
+	return s
+}
+
+func withName(c fuzzCase, name string) fuzzCase {
+	c.name = name
+	return c
+}
+
+func clone(c fuzzCase) fuzzCase {
+	c.query = url.Values(http.Header(c.query).Clone())
+	c.headers = c.headers.Clone()
+	return c
+}
+
+func setParam(c *fuzzCase, p *openapi3.Parameter, value string) {
+	switch p.In {
+	case openapi3.ParameterInQuery:
+		c.query.Set(p.Name, value)
+	case openapi3.ParameterInHeader:
+		c.headers.Set(p.Name, value)
+	case openapi3.ParameterInCookie:
+		c.headers.Add("Cookie", p.Name+"="+url.QueryEscape(value))
+	}
+}
+
+// validValue returns a value matching the schema, as far as it can tell.
+func validValue(ref *openapi3.SchemaRef) string {
+	if ref == nil || ref.Value == nil {
+		return "1"
+	}
+	s := ref.Value
+	if len(s.Enum) > 0 {
+		return fmt.Sprint(s.Enum[0])
+	}
+	switch {
+	case s.Type.Is(openapi3.TypeInteger), s.Type.Is(openapi3.TypeNumber):
+		if s.Min != nil {
+			return fmt.Sprint(*s.Min)
+		}
+		return "1"
+	case s.Type.Is(openapi3.TypeBoolean):
+		return "true"
+	}
+	return strings.Repeat("a", max(int(s.MinLength), 1))
+}
+
+// invalidValues returns boundary and invalid values of the schema.
+func invalidValues(ref *openapi3.SchemaRef) []string {
+	values := []string{"", strings.Repeat("x", 1024), "%00", "' OR 1=1 --"}
+	if ref == nil || ref.Value == nil {
+		return values
+	}
+	s := ref.Value
+	switch {
+	case s.Type.Is(openapi3.TypeInteger), s.Type.Is(openapi3.TypeNumber):
+		values = append(values, "NaN", "1e400", "-99999999999999999999", "99999999999999999999", "1.5")
+		if s.Min != nil {
+			values = append(values, fmt.Sprint(*s.Min-1))
+		}
+		if s.Max != nil {
+			values = append(values, fmt.Sprint(*s.Max+1))
+		}
+	case s.Type.Is(openapi3.TypeBoolean):
+		values = append(values, "maybe", "2")
+	case s.Type.Is(openapi3.TypeArray):
+		values = append(values, ",,,", strings.Repeat("a,", 1024))
+	}
+	if s.MaxLength != nil {
+		values = append(values, strings.Repeat("x", int(*s.MaxLength)+1))
+	}
+	return values
+}
+
+// invalidBodies returns malformed JSON bodies and bodies with properties of the wrong type.
+func invalidBodies(ref *openapi3.SchemaRef) []string {
+	bodies := []string{"", "{", "null", "[]", `"string"`, "0", `{"` + strings.Repeat("x", 1024) + `":1}`}
+	if ref == nil || ref.Value == nil {
+		return bodies
+	}
+	names := make([]string, 0, len(ref.Value.Properties))
+	for name := range ref.Value.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		key, _ := json.Marshal(name)
+		for _, value := range []string{"null", `"string"`, "-1", "1e400", "true", "[]", "{}"} {
+			bodies = append(bodies, fmt.Sprintf("{%s:%s}", key, value))
+		}
+	}
+	return bodies
+}
+
+// fire sends the request of the case and checks the response.
+func fire(t testing.TB, engine *soda.Engine, doc *openapi3.T, method string, op *openapi3.Operation, c fuzzCase) {
+	t.Helper()
+	target := c.path
+	if len(c.query) > 0 {
+		target += "?" + c.query.Encode()
+	}
+	var body io.Reader
+	if c.body != "" {
+		body = strings.NewReader(c.body)
+	}
+	req := httptest.NewRequest(method, target, body)
+	req.Header = c.headers
+	resp, err := engine.App().Test(req, -1)
+	if err != nil {
+		t.Errorf("%s %s (%s): %v", method, c.route, c.name, err)
+		return
+	}
+	defer resp.Body.Close()
+	data, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode >= 500 {
+		t.Errorf("%s %s (%s): unexpected status %d: %s", method, c.route, c.name, resp.StatusCode, data)
+		return
+	}
+	if resp.StatusCode < 400 {
+		return
+	}
+	response := op.Responses.Status(resp.StatusCode)
+	if response == nil {
+		response = op.Responses.Default()
+	}
+	if response == nil || response.Value == nil {
+		return
+	}
+	mt := response.Value.Content.Get("application/json")
+	if mt == nil || mt.Schema == nil {
+		return
+	}
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		t.Errorf("%s %s (%s): error body is not JSON: %s", method, c.route, c.name, data)
+		return
+	}
+	if err := mt.Schema.Value.VisitJSON(v); err != nil {
+		t.Errorf("%s %s (%s): error body doesn't match the documented schema: %v", method, c.route, c.name, err)
+	}
+}
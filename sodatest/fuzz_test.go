@@ -0,0 +1,71 @@
+package sodatest_test
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	"github.com/neo-f/soda/v3/sodatest"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// recorder records the failures reported to it instead of failing the test.
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestFuzz(t *testing.T) {
+	Convey("Given an API", t, func() {
+		type input struct {
+			ID    int `path:"id"`
+			Limit int `query:"limit" oai:"minimum=1;maximum=100"`
+			Body  struct {
+				Name string `json:"name"`
+			} `body:"json"`
+		}
+		engine := soda.New().SetBindErrorStatus(http.StatusBadRequest)
+		engine.Put("/items/:id", func(c *fiber.Ctx) error {
+			return c.SendStatus(http.StatusNoContent)
+		}).SetInput(&input{}).AddErrorResponses(http.StatusBadRequest).OK()
+
+		Convey("Fuzz should pass when it handles invalid inputs", func() {
+			r := &recorder{TB: t}
+			sodatest.Fuzz(r, engine)
+			So(r.errors, ShouldBeEmpty)
+		})
+
+		Convey("Fuzz should report the server errors", func() {
+			engine.Get("/crash", func(c *fiber.Ctx) error {
+				if c.Query("q") == "" {
+					return c.SendStatus(http.StatusInternalServerError)
+				}
+				return nil
+			}).SetInput(&struct {
+				Q string `query:"q"`
+			}{}).OK()
+			r := &recorder{TB: t}
+			sodatest.Fuzz(r, engine)
+			So(r.errors, ShouldNotBeEmpty)
+			So(strings.Join(r.errors, "\n"), ShouldContainSubstring, `GET /crash (query parameter q=""): unexpected status 500`)
+		})
+
+		Convey("Fuzz should report the undocumented error bodies", func() {
+			engine.Get("/bad-error", func(c *fiber.Ctx) error {
+				return c.Status(http.StatusBadRequest).JSON(map[string]int{"code": 1})
+			}).AddErrorResponses(http.StatusBadRequest).OK()
+			r := &recorder{TB: t}
+			sodatest.Fuzz(r, engine)
+			So(strings.Join(r.errors, "\n"), ShouldContainSubstring, "error body doesn't match the documented schema")
+		})
+	})
+}
@@ -0,0 +1,125 @@
+package sodatest
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+)
+
+// Harvest promotes the payloads of the exchanges recorded afterwards into examples of the spec as they are
+// captured, so that the documentation stays realistic. A payload is representative, and promoted, when its
+// shape, namely its set of properties and their types, differs from the ones already promoted for the same
+// request or response, up to max examples each. The examples are named as by AttachExamples, suffixed by
+// their rank from the second on, such as "get-items-200-2".
+// The payloads are not promoted once the engine is frozen.
+func (r *Recorder) Harvest(max int) *Recorder {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.harvest = max
+	if r.harvested == nil {
+		r.harvested = make(map[string][]string)
+	}
+	return r
+}
+
+// promote registers the JSON bodies of the exchange as examples of its operation, unless max examples or one
+// of the same shape are already promoted, as tracked by the shapes
+// of the examples promoted per request or response.
+func (r *Recorder) promote(exchange Exchange, max int, promoted map[string][]string) {
+	route, op := r.find(exchange)
+	if op == nil {
+		return
+	}
+	if op.RequestBody != nil && op.RequestBody.Value != nil {
+		content := op.RequestBody.Value.Content.Get(fiber.MIMEApplicationJSON)
+		r.promoteBody(route.OperationID+"-request", exchange.RequestBody, content, max, promoted)
+	}
+	if response := op.Responses.Status(exchange.Status); response != nil && response.Value != nil {
+		content := response.Value.Content.Get(fiber.MIMEApplicationJSON)
+		r.promoteBody(fmt.Sprintf("%s-%d", route.OperationID, exchange.Status), exchange.ResponseBody, content, max, promoted)
+	}
+}
+
+// promoteBody registers the JSON body as an example component referenced from the content.
+func (r *Recorder) promoteBody(base string, body []byte, content *openapi3.MediaType, max int, promoted map[string][]string) {
+	if content == nil || len(body) == 0 || len(promoted[base]) >= max {
+		return
+	}
+	var value any
+	if err := json.Unmarshal(body, &value); err != nil {
+		return
+	}
+	shape := jsonShape(value)
+	for _, s := range promoted[base] {
+		if s == shape {
+			return
+		}
+	}
+	name := base
+	if n := len(promoted[base]); n > 0 {
+		name = fmt.Sprintf("%s-%d", base, n+1)
+	}
+	applied := r.engine.UpdateSpec(func(doc *openapi3.T) {
+		example := openapi3.NewExample(value)
+		doc.Components.Examples[name] = &openapi3.ExampleRef{Value: example}
+		content.Example = nil
+		if content.Examples == nil {
+			content.Examples = make(openapi3.Examples)
+		}
+		content.Examples[name] = &openapi3.ExampleRef{Ref: "#/components/examples/" + name, Value: example}
+	})
+	if applied {
+		promoted[base] = append(promoted[base], shape)
+	}
+}
+
+// find returns the route and the documented operation of the exchange.
+func (r *Recorder) find(exchange Exchange) (soda.RouteInfo, *openapi3.Operation) {
+	for _, route := range r.engine.Routes() {
+		if route.Documented && route.OperationID == exchange.OperationID && route.Method == exchange.Method {
+			return route, r.operation(route)
+		}
+	}
+	return soda.RouteInfo{}, nil
+}
+
+// jsonShape describes the properties of the JSON value and their types, such as "{id:number,tags:[string]}".
+func jsonShape(v any) string {
+	switch v := v.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for i, key := range keys {
+			keys[i] = key + ":" + jsonShape(v[key])
+		}
+		return "{" + strings.Join(keys, ",") + "}"
+	case []any:
+		// The shapes of the items are merged, so that the length of an array doesn't make it representative.
+		shapes := make(map[string]bool)
+		for _, item := range v {
+			shapes[jsonShape(item)] = true
+		}
+		items := make([]string, 0, len(shapes))
+		for shape := range shapes {
+			items = append(items, shape)
+		}
+		sort.Strings(items)
+		return "[" + strings.Join(items, "|") + "]"
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "boolean"
+	default:
+		return "null"
+	}
+}
@@ -0,0 +1,60 @@
+package sodatest_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	"github.com/neo-f/soda/v3/sodatest"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type harvestedItem struct {
+	ID   int      `json:"id"`
+	Tags []string `json:"tags,omitempty"`
+}
+
+func TestHarvest(t *testing.T) {
+	Convey("Given an API harvesting examples", t, func() {
+		engine := soda.New().ServeSpecJSON("/openapi.json")
+		rec := sodatest.NewRecorder(engine).Harvest(2)
+		engine.App().Use(rec.Middleware())
+		engine.Get("/items/:id", func(c *fiber.Ctx) error {
+			switch c.Params("id") {
+			case "1", "2":
+				return c.JSON(harvestedItem{ID: 1})
+			default:
+				return c.JSON(harvestedItem{ID: 3, Tags: []string{"new"}})
+			}
+		}).SetOperationID("get-item").AddJSONResponse(http.StatusOK, harvestedItem{}).OK()
+
+		get := func(target string) string {
+			resp, err := engine.App().Test(httptest.NewRequest("GET", target, nil))
+			So(err, ShouldBeNil)
+			body, _ := io.ReadAll(resp.Body)
+			return string(body)
+		}
+
+		Convey("The representative payloads should be promoted into the served spec", func() {
+			So(get("/openapi.json"), ShouldNotContainSubstring, "get-item-200")
+			for _, id := range []string{"1", "2", "3", "4"} {
+				get("/items/" + id)
+			}
+			examples := engine.OpenAPI().Paths.Value("/items/:id").Get.Responses.Status(http.StatusOK).Value.
+				Content.Get("application/json").Examples
+			So(examples, ShouldHaveLength, 2)
+			So(examples["get-item-200"].Value.Value, ShouldResemble, map[string]any{"id": float64(1)})
+			So(examples["get-item-200-2"].Value.Value, ShouldResemble, map[string]any{"id": float64(3), "tags": []any{"new"}})
+			So(get("/openapi.json"), ShouldContainSubstring, "#/components/examples/get-item-200-2")
+		})
+
+		Convey("The payloads should not be promoted once the engine is frozen", func() {
+			engine.Freeze()
+			get("/items/1")
+			So(engine.OpenAPI().Components.Examples, ShouldBeEmpty)
+		})
+	})
+}
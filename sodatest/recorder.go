@@ -0,0 +1,458 @@
+package sodatest
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+)
+
+// SensitiveHeaders are the headers whose values are redacted from the recorded exchanges.
+var SensitiveHeaders = []string{
+	fiber.HeaderAuthorization,
+	fiber.HeaderProxyAuthorization,
+	fiber.HeaderCookie,
+	fiber.HeaderSetCookie,
+	"X-Api-Key",
+}
+
+// Exchange is a request to an operation and its response, as recorded by a Recorder.
+type Exchange struct {
+	Time        time.Time
+	Duration    time.Duration
+	OperationID string
+	Method      string
+	// URL is the URI of the request, such as "/items/1?verbose=true".
+	URL             string
+	RequestHeaders  http.Header
+	RequestBody     []byte
+	Status          int
+	ResponseHeaders http.Header
+	ResponseBody    []byte
+}
+
+// Recorder records sanitized exchanges with the operations of an engine, meant to be used in development
+// to harvest HAR files, examples of the spec or regression tests.
+// The sensitive headers and the JSON properties marked as sensitive in the spec are redacted.
+type Recorder struct {
+	engine *soda.Engine
+
+	mu        sync.Mutex
+	exchanges []Exchange
+
+	// harvest is the maximum number of examples harvested per request or response, see Harvest.
+	harvest   int
+	harvested map[string][]string
+}
+
+// NewRecorder records the exchanges with the operations of the engine. Its Middleware must be installed
+// on the app of the engine before the operations are registered.
+func NewRecorder(engine *soda.Engine) *Recorder {
+	return &Recorder{engine: engine}
+}
+
+// Middleware records the exchanges with the documented operations, the other requests being ignored.
+// The errors of the operations are handled by the error handler of the app, so that their responses are recorded.
+func (r *Recorder) Middleware() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		start := time.Now()
+		err := ctx.Next()
+		route, ok := soda.OperationFromCtx(ctx)
+		if !ok || !route.Documented {
+			return err
+		}
+		if err != nil {
+			// Let the error handler write the response to record it.
+			if err := ctx.App().ErrorHandler(ctx, err); err != nil {
+				_ = ctx.SendStatus(http.StatusInternalServerError)
+			}
+			err = nil
+		}
+		op := r.operation(route)
+		exchange := Exchange{
+			Time:            start,
+			Duration:        time.Since(start),
+			OperationID:     route.OperationID,
+			Method:          strings.Clone(ctx.Method()),
+			URL:             string(ctx.Request().RequestURI()),
+			RequestHeaders:  sanitizeHeaders(ctx.GetReqHeaders()),
+			Status:          ctx.Response().StatusCode(),
+			ResponseHeaders: sanitizeHeaders(ctx.GetRespHeaders()),
+		}
+		if op != nil && op.RequestBody != nil && op.RequestBody.Value != nil {
+			exchange.RequestBody = sanitizeBody(ctx.Body(), op.RequestBody.Value.Content.Get(fiber.MIMEApplicationJSON))
+		} else {
+			exchange.RequestBody = sanitizeBody(ctx.Body(), nil)
+		}
+		var content *openapi3.MediaType
+		if op != nil {
+			if response := op.Responses.Status(exchange.Status); response != nil && response.Value != nil {
+				content = response.Value.Content.Get(fiber.MIMEApplicationJSON)
+			}
+		}
+		exchange.ResponseBody = sanitizeBody(ctx.Response().Body(), content)
+
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.exchanges = append(r.exchanges, exchange)
+		if r.harvest > 0 {
+			r.promote(exchange, r.harvest, r.harvested)
+		}
+		return err
+	}
+}
+
+// Exchanges returns the recorded exchanges, in order.
+func (r *Recorder) Exchanges() []Exchange {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Exchange(nil), r.exchanges...)
+}
+
+// operation returns the documented operation of the route, if any.
+func (r *Recorder) operation(route soda.RouteInfo) *openapi3.Operation {
+	item := r.engine.OpenAPI().Paths.Value(route.DocPath)
+	if item == nil {
+		return nil
+	}
+	return item.GetOperation(route.Method)
+}
+
+// sanitizeHeaders copies the headers, redacting the sensitive ones.
+func sanitizeHeaders(headers map[string][]string) http.Header {
+	sanitized := make(http.Header, len(headers))
+	for name, values := range headers {
+		for _, value := range values {
+			// The values may alias the buffers of the request, which are reused.
+			sanitized.Add(name, strings.Clone(value))
+		}
+	}
+	for _, name := range SensitiveHeaders {
+		if values := sanitized.Values(name); len(values) != 0 {
+			sanitized.Set(name, soda.RedactedValue)
+		}
+	}
+	return sanitized
+}
+
+// sanitizeBody copies the body, redacting the properties marked as sensitive by the schema of its JSON content.
+func sanitizeBody(body []byte, content *openapi3.MediaType) []byte {
+	if len(body) == 0 {
+		return nil
+	}
+	if content == nil || content.Schema == nil {
+		return bytes.Clone(body)
+	}
+	var v any
+	if err := json.Unmarshal(body, &v); err != nil {
+		return bytes.Clone(body)
+	}
+	sanitized, err := json.Marshal(redactJSON(v, content.Schema))
+	if err != nil {
+		return bytes.Clone(body)
+	}
+	return sanitized
+}
+
+// redactJSON replaces the values of the properties whose schema has the x-sensitive extension.
+func redactJSON(v any, ref *openapi3.SchemaRef) any {
+	if ref == nil || ref.Value == nil {
+		return v
+	}
+	schema := ref.Value
+	if sensitive, _ := schema.Extensions["x-sensitive"].(bool); sensitive {
+		return soda.RedactedValue
+	}
+	switch v := v.(type) {
+	case map[string]any:
+		for name, value := range v {
+			if property, ok := schema.Properties[name]; ok {
+				v[name] = redactJSON(value, property)
+			} else if schema.AdditionalProperties.Schema != nil {
+				v[name] = redactJSON(value, schema.AdditionalProperties.Schema)
+			}
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = redactJSON(item, schema.Items)
+		}
+	}
+	return v
+}
+
+// AttachExamples registers the first recorded JSON request and response bodies of every documented operation
+// as example components, named after the operation ID such as "get-items-request" and "get-items-200",
+// and references them from the operation.
+func (r *Recorder) AttachExamples() {
+	promoted := make(map[string][]string)
+	for _, exchange := range r.Exchanges() {
+		r.promote(exchange, 1, promoted)
+	}
+}
+
+// har is an HTTP Archive, see http://www.softwareishard.com/blog/har-12-spec/.
+type har struct {
+	Log harLog `json:"log"`
+}
+
+type harLog struct {
+	Version string     `json:"version"`
+	Creator harCreator `json:"creator"`
+	Entries []harEntry `json:"entries"`
+}
+
+type harCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type harEntry struct {
+	StartedDateTime time.Time   `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         harRequest  `json:"request"`
+	Response        harResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         harTimings  `json:"timings"`
+	// OperationID is a custom field holding the operation ID of the exchange.
+	OperationID string `json:"_operationId,omitempty"`
+}
+
+type harRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Headers     []harNameValue `json:"headers"`
+	QueryString []harNameValue `json:"queryString"`
+	Cookies     []harNameValue `json:"cookies"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+	PostData    *harPostData   `json:"postData,omitempty"`
+}
+
+type harResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Headers     []harNameValue `json:"headers"`
+	Cookies     []harNameValue `json:"cookies"`
+	Content     harContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type harContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type harTimings struct {
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
+
+// WriteHAR writes the recorded exchanges as an HTTP Archive, which browsers and HTTP tools can import.
+func (r *Recorder) WriteHAR(w io.Writer) error {
+	archive := har{Log: harLog{Version: "1.2", Creator: harCreator{Name: "soda", Version: "3"}, Entries: []harEntry{}}}
+	for _, exchange := range r.Exchanges() {
+		archive.Log.Entries = append(archive.Log.Entries, toHAREntry(exchange))
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(archive)
+}
+
+// ReadHAR reads the exchanges of an HTTP Archive, such as written by Recorder.WriteHAR.
+func ReadHAR(r io.Reader) ([]Exchange, error) {
+	var archive har
+	if err := json.NewDecoder(r).Decode(&archive); err != nil {
+		return nil, err
+	}
+	exchanges := make([]Exchange, 0, len(archive.Log.Entries))
+	for _, entry := range archive.Log.Entries {
+		exchange := Exchange{
+			Time:            entry.StartedDateTime,
+			Duration:        time.Duration(entry.Time * float64(time.Millisecond)),
+			OperationID:     entry.OperationID,
+			Method:          entry.Request.Method,
+			URL:             entry.Request.URL,
+			RequestHeaders:  fromHARHeaders(entry.Request.Headers),
+			Status:          entry.Response.Status,
+			ResponseHeaders: fromHARHeaders(entry.Response.Headers),
+		}
+		if entry.Request.PostData != nil && entry.Request.PostData.Text != "" {
+			exchange.RequestBody = []byte(entry.Request.PostData.Text)
+		}
+		if entry.Response.Content.Text != "" {
+			exchange.ResponseBody = []byte(entry.Response.Content.Text)
+		}
+		exchanges = append(exchanges, exchange)
+	}
+	return exchanges, nil
+}
+
+// toHAREntry converts the exchange to an entry of an HTTP Archive.
+func toHAREntry(exchange Exchange) harEntry {
+	entry := harEntry{
+		StartedDateTime: exchange.Time,
+		Time:            float64(exchange.Duration) / float64(time.Millisecond),
+		OperationID:     exchange.OperationID,
+		Request: harRequest{
+			Method:      exchange.Method,
+			URL:         exchange.URL,
+			HTTPVersion: "HTTP/1.1",
+			Headers:     toHARHeaders(exchange.RequestHeaders),
+			QueryString: []harNameValue{},
+			Cookies:     []harNameValue{},
+			HeadersSize: -1,
+			BodySize:    len(exchange.RequestBody),
+		},
+		Response: harResponse{
+			Status:      exchange.Status,
+			StatusText:  http.StatusText(exchange.Status),
+			HTTPVersion: "HTTP/1.1",
+			Headers:     toHARHeaders(exchange.ResponseHeaders),
+			Cookies:     []harNameValue{},
+			Content: harContent{
+				Size:     len(exchange.ResponseBody),
+				MimeType: exchange.ResponseHeaders.Get(fiber.HeaderContentType),
+				Text:     string(exchange.ResponseBody),
+			},
+			HeadersSize: -1,
+			BodySize:    len(exchange.ResponseBody),
+		},
+		Timings: harTimings{Wait: float64(exchange.Duration) / float64(time.Millisecond)},
+	}
+	if i := strings.IndexByte(exchange.URL, '?'); i >= 0 {
+		for _, pair := range strings.Split(exchange.URL[i+1:], "&") {
+			name, value, _ := strings.Cut(pair, "=")
+			entry.Request.QueryString = append(entry.Request.QueryString, harNameValue{Name: name, Value: value})
+		}
+	}
+	if len(exchange.RequestBody) != 0 {
+		entry.Request.PostData = &harPostData{
+			MimeType: exchange.RequestHeaders.Get(fiber.HeaderContentType),
+			Text:     string(exchange.RequestBody),
+		}
+	}
+	return entry
+}
+
+func toHARHeaders(headers http.Header) []harNameValue {
+	pairs := []harNameValue{}
+	for _, name := range sortedHeaderNames(headers) {
+		for _, value := range headers[name] {
+			pairs = append(pairs, harNameValue{Name: name, Value: value})
+		}
+	}
+	return pairs
+}
+
+func fromHARHeaders(pairs []harNameValue) http.Header {
+	headers := make(http.Header, len(pairs))
+	for _, pair := range pairs {
+		headers.Add(pair.Name, pair.Value)
+	}
+	return headers
+}
+
+func sortedHeaderNames(headers http.Header) []string {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Replay sends the requests of the exchanges to the engine and asserts that the responses have the recorded
+// status and, for JSON bodies, the recorded body, the redacted values matching any value.
+// The prepare functions can restore the redacted credentials of the requests.
+func Replay(t testing.TB, engine *soda.Engine, exchanges []Exchange, prepare ...func(*http.Request)) {
+	t.Helper()
+	for _, exchange := range exchanges {
+		var body io.Reader
+		if len(exchange.RequestBody) != 0 {
+			body = bytes.NewReader(exchange.RequestBody)
+		}
+		req := httptest.NewRequest(exchange.Method, exchange.URL, body)
+		req.Header = exchange.RequestHeaders.Clone()
+		for _, fn := range prepare {
+			fn(req)
+		}
+		resp, err := engine.App().Test(req, -1)
+		if err != nil {
+			t.Errorf("%s %s: %v", exchange.Method, exchange.URL, err)
+			continue
+		}
+		data, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode != exchange.Status {
+			t.Errorf("%s %s: status %d, recorded %d: %s", exchange.Method, exchange.URL, resp.StatusCode, exchange.Status, data)
+			continue
+		}
+		var recorded, replayed any
+		if json.Unmarshal(exchange.ResponseBody, &recorded) != nil || json.Unmarshal(data, &replayed) != nil {
+			continue
+		}
+		if !matchJSON(recorded, replayed) {
+			t.Errorf("%s %s: body %s, recorded %s", exchange.Method, exchange.URL, data, exchange.ResponseBody)
+		}
+	}
+}
+
+// matchJSON reports whether the JSON values are equal, the redacted values of the recorded one matching any value.
+func matchJSON(recorded, replayed any) bool {
+	if recorded == soda.RedactedValue {
+		return true
+	}
+	switch recorded := recorded.(type) {
+	case map[string]any:
+		replayed, ok := replayed.(map[string]any)
+		if !ok || len(recorded) != len(replayed) {
+			return false
+		}
+		for name, value := range recorded {
+			if other, ok := replayed[name]; !ok || !matchJSON(value, other) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		replayed, ok := replayed.([]any)
+		if !ok || len(recorded) != len(replayed) {
+			return false
+		}
+		for i := range recorded {
+			if !matchJSON(recorded[i], replayed[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return reflect.DeepEqual(recorded, replayed)
+	}
+}
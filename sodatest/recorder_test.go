@@ -0,0 +1,110 @@
+package sodatest_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	"github.com/neo-f/soda/v3/sodatest"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type recordedUser struct {
+	Name     string `json:"name"`
+	Password string `json:"password" oai:"sensitive"`
+}
+
+type recordedInput struct {
+	Body recordedUser `body:"json"`
+}
+
+func TestRecorder(t *testing.T) {
+	Convey("Given an API recording its exchanges", t, func() {
+		engine := soda.New()
+		rec := sodatest.NewRecorder(engine)
+		engine.App().Use(rec.Middleware())
+		engine.Get("/health", func(c *fiber.Ctx) error { return c.SendString("ok") }).IgnoreAPIDoc(true).OK()
+		engine.Post("/users", func(c *fiber.Ctx) error {
+			if c.Get(fiber.HeaderAuthorization) != "Bearer token" {
+				return soda.NewError(http.StatusUnauthorized, "unauthorized", "invalid credentials")
+			}
+			user := soda.GetInput[recordedInput](c).Body
+			return c.Status(http.StatusCreated).JSON(user)
+		}).
+			SetOperationID("create-user").
+			SetInput(recordedInput{}).
+			AddJSONResponse(http.StatusCreated, recordedUser{}).
+			AddErrorResponses(http.StatusUnauthorized).
+			OK()
+
+		call := func(token string) {
+			req := httptest.NewRequest("POST", "/users?notify=true", strings.NewReader(`{"name":"ada","password":"secret"}`))
+			req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+			req.Header.Set(fiber.HeaderAuthorization, "Bearer "+token)
+			_, err := engine.App().Test(req)
+			So(err, ShouldBeNil)
+		}
+		call("token")
+		call("expired")
+		_, err := engine.App().Test(httptest.NewRequest("GET", "/health", nil))
+		So(err, ShouldBeNil)
+
+		Convey("The exchanges with the documented operations should be recorded sanitized", func() {
+			exchanges := rec.Exchanges()
+			So(exchanges, ShouldHaveLength, 2)
+			exchange := exchanges[0]
+			So(exchange.OperationID, ShouldEqual, "create-user")
+			So(exchange.URL, ShouldEqual, "/users?notify=true")
+			So(exchange.Status, ShouldEqual, http.StatusCreated)
+			So(exchange.RequestHeaders.Get(fiber.HeaderAuthorization), ShouldEqual, soda.RedactedValue)
+			So(string(exchange.RequestBody), ShouldEqual, `{"name":"ada","password":"******"}`)
+			So(string(exchange.ResponseBody), ShouldEqual, `{"name":"ada","password":"******"}`)
+			So(exchanges[1].Status, ShouldEqual, http.StatusUnauthorized)
+		})
+
+		Convey("The exchanges should round trip through a HAR file", func() {
+			var buf bytes.Buffer
+			So(rec.WriteHAR(&buf), ShouldBeNil)
+			var archive map[string]any
+			So(json.Unmarshal(buf.Bytes(), &archive), ShouldBeNil)
+			So(archive["log"].(map[string]any)["version"], ShouldEqual, "1.2")
+
+			exchanges, err := sodatest.ReadHAR(&buf)
+			So(err, ShouldBeNil)
+			So(exchanges, ShouldHaveLength, 2)
+			So(exchanges[0].OperationID, ShouldEqual, "create-user")
+			So(exchanges[0].Status, ShouldEqual, http.StatusCreated)
+			So(exchanges[0].RequestBody, ShouldResemble, rec.Exchanges()[0].RequestBody)
+		})
+
+		Convey("The exchanges should be attached as examples", func() {
+			rec.AttachExamples()
+			doc := engine.OpenAPI()
+			So(doc.Components.Examples, ShouldContainKey, "create-user-request")
+			So(doc.Components.Examples, ShouldContainKey, "create-user-201")
+			So(doc.Components.Examples, ShouldContainKey, "create-user-401")
+			operation := doc.Paths.Value("/users").Post
+			So(operation.RequestBody.Value.Content.Get("application/json").Examples, ShouldContainKey, "create-user-request")
+			So(operation.Responses.Status(http.StatusCreated).Value.Content.Get("application/json").Examples["create-user-201"].Ref,
+				ShouldEqual, "#/components/examples/create-user-201")
+		})
+
+		Convey("The exchanges should be replayed as regression tests", func() {
+			restore := func(req *http.Request) {
+				req.Header.Set(fiber.HeaderAuthorization, "Bearer token")
+			}
+			var r recorder
+			sodatest.Replay(&r, engine, rec.Exchanges()[:1], restore)
+			So(r.errors, ShouldBeEmpty)
+
+			sodatest.Replay(&r, engine, rec.Exchanges()[1:], restore)
+			So(r.errors, ShouldHaveLength, 1)
+			So(r.errors[0], ShouldContainSubstring, "status 201, recorded 401")
+		})
+	})
+}
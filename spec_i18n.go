@@ -0,0 +1,95 @@
+package soda
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+)
+
+// LocalizeSpec serves the documentation translated into the language of the lang query parameter, e.g.
+// /openapi.json?lang=de, the untranslated spec being served without it or for the languages without a catalog.
+// The translations are registered with RegisterCatalog under the keys:
+//
+//	<operationID>.summary               the summary of an operation
+//	<operationID>.description           the description of an operation
+//	<operationID>.parameters.<name>     the description of a parameter of an operation
+//	<operationID>.responses.<status>    the description of a response of an operation
+//	<schema>.description                the description of a component schema
+//	<schema>.properties.<property>       the description of a property of a component schema
+//	tags.<name>                         the description of a tag
+//
+// The text without translation is left as is.
+func (e *Engine) LocalizeSpec() *Engine {
+	e.specLocalized = true
+	e.specVariants = sync.Map{}
+	return e
+}
+
+// specLanguage returns the language of the spec served to the request, or "" for the untranslated spec.
+// Only the languages with a catalog are served, so that arbitrary values don't pile up cached variants.
+func specLanguage(c *fiber.Ctx) string {
+	lang := c.Query("lang")
+	if lang == "" {
+		return ""
+	}
+	catalogsMu.RLock()
+	defer catalogsMu.RUnlock()
+	if _, ok := catalogs[lang]; ok {
+		return lang
+	}
+	if base, _, ok := strings.Cut(lang, "-"); ok {
+		if _, ok := catalogs[base]; ok {
+			return base
+		}
+	}
+	return ""
+}
+
+// localizeSpec translates the text of the spec into the language.
+func localizeSpec(doc *openapi3.T, lang string) {
+	catalogsMu.RLock()
+	defer catalogsMu.RUnlock()
+	catalog := catalogs[lang]
+	translate := func(text *string, key string) {
+		if translated, ok := catalog[key]; ok {
+			*text = translated
+		}
+	}
+	for _, item := range doc.Paths.Map() {
+		for _, op := range item.Operations() {
+			if op.OperationID == "" {
+				continue
+			}
+			translate(&op.Summary, op.OperationID+".summary")
+			translate(&op.Description, op.OperationID+".description")
+			for _, param := range op.Parameters {
+				// The parameters referring to components are shared by the operations.
+				if param.Ref == "" && param.Value != nil {
+					translate(&param.Value.Description, op.OperationID+".parameters."+param.Value.Name)
+				}
+			}
+			for status, resp := range op.Responses.Map() {
+				if resp.Ref == "" && resp.Value != nil && resp.Value.Description != nil {
+					translate(resp.Value.Description, op.OperationID+".responses."+status)
+				}
+			}
+		}
+	}
+	for name, schema := range doc.Components.Schemas {
+		if schema.Value == nil {
+			continue
+		}
+		translate(&schema.Value.Description, name+".description")
+		for prop, propSchema := range schema.Value.Properties {
+			// The properties referring to components are described by them.
+			if propSchema.Ref == "" && propSchema.Value != nil {
+				translate(&propSchema.Value.Description, name+".properties."+prop)
+			}
+		}
+	}
+	for _, tag := range doc.Tags {
+		translate(&tag.Description, "tags."+tag.Name)
+	}
+}
@@ -0,0 +1,68 @@
+package soda_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type localizedOrder struct {
+	ID    string `json:"id"    oai:"description=The order ID"`
+	Total int    `json:"total" oai:"description=The total amount"`
+}
+
+func TestLocalizeSpec(t *testing.T) {
+	Convey("Given an engine serving localized specs", t, func() {
+		soda.RegisterCatalog("de", soda.Catalog{
+			"get-order.summary":                         "Bestellung abrufen",
+			"get-order.parameters.id":                   "Die Bestellnummer",
+			"get-order.responses.200":                   "Die Bestellung",
+			"soda_test.localizedOrder.properties.total": "Der Gesamtbetrag",
+			"tags.orders":                               "Bestellungen",
+		})
+		type input struct {
+			ID string `path:"id" oai:"description=The order ID"`
+		}
+		engine := soda.New()
+		engine.AddTags("orders")
+		engine.Get("/orders/:id", func(c *fiber.Ctx) error { return nil }).
+			SetOperationID("get-order").
+			SetSummary("Get an order").
+			SetInput(input{}).
+			AddJSONResponse(http.StatusOK, localizedOrder{}, "The order").
+			OK()
+		engine.LocalizeSpec().ServeSpecJSON("/openapi.json")
+
+		get := func(target string) *openapi3.T {
+			resp, err := engine.App().Test(httptest.NewRequest("GET", target, nil))
+			So(err, ShouldBeNil)
+			var doc openapi3.T
+			So(json.NewDecoder(resp.Body).Decode(&doc), ShouldBeNil)
+			return &doc
+		}
+
+		Convey("The text should be translated into the requested language", func() {
+			doc := get("/openapi.json?lang=de-AT")
+			op := doc.Paths.Find("/orders/:id").Get
+			So(op.Summary, ShouldEqual, "Bestellung abrufen")
+			So(op.Parameters[0].Value.Description, ShouldEqual, "Die Bestellnummer")
+			So(*op.Responses.Status(http.StatusOK).Value.Description, ShouldEqual, "Die Bestellung")
+			order := doc.Components.Schemas["soda_test.localizedOrder"].Value
+			So(order.Properties["total"].Value.Description, ShouldEqual, "Der Gesamtbetrag")
+			So(order.Properties["id"].Value.Description, ShouldEqual, "The order ID")
+			So(doc.Tags.Get("orders").Description, ShouldEqual, "Bestellungen")
+		})
+
+		Convey("The untranslated spec should be served otherwise", func() {
+			So(get("/openapi.json").Paths.Find("/orders/:id").Get.Summary, ShouldEqual, "Get an order")
+			So(get("/openapi.json?lang=xx").Paths.Find("/orders/:id").Get.Summary, ShouldEqual, "Get an order")
+			So(engine.OpenAPI().Paths.Find("/orders/:id").Get.Summary, ShouldEqual, "Get an order")
+		})
+	})
+}
@@ -0,0 +1,162 @@
+package soda
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// SpecStats reports the size of the spec, to keep an eye on the growth of the published document.
+type SpecStats struct {
+	// Paths is the number of documented paths.
+	Paths int
+	// Operations is the number of documented operations.
+	Operations int
+	// Schemas is the number of component schemas.
+	Schemas int
+	// Components is the number of components of all kinds, schemas included.
+	Components int
+	// UnusedComponents lists the components no operation refers to, such as "#/components/schemas/Legacy".
+	UnusedComponents []string
+	// Bytes is the size of the spec marshaled as JSON.
+	Bytes int
+}
+
+// SpecStats reports the size of the spec.
+func (e *Engine) SpecStats() SpecStats {
+	data := e.specJSON()
+	e.specMu.Lock()
+	defer e.specMu.Unlock()
+	doc := e.gen.doc
+	stats := SpecStats{Paths: doc.Paths.Len(), Schemas: len(doc.Components.Schemas), Bytes: len(data)}
+	for _, item := range doc.Paths.Map() {
+		stats.Operations += len(item.Operations())
+	}
+	used := referencedComponents(doc)
+	for _, ref := range componentRefs(doc) {
+		stats.Components++
+		if !used[ref] {
+			stats.UnusedComponents = append(stats.UnusedComponents, ref)
+		}
+	}
+	return stats
+}
+
+// PruneComponents removes the components of the spec no operation refers to, directly or through other
+// components, and returns their references. The security schemes are kept, as they are referred to by name.
+// It can be called in a SpecTransformer after hiding operations, whose models are then left unused.
+func PruneComponents(doc *openapi3.T) []string {
+	used := referencedComponents(doc)
+	var pruned []string
+	for _, ref := range componentRefs(doc) {
+		if used[ref] {
+			continue
+		}
+		pruned = append(pruned, ref)
+		kind, name := splitComponentRef(ref)
+		c := doc.Components
+		switch kind {
+		case "schemas":
+			delete(c.Schemas, name)
+		case "parameters":
+			delete(c.Parameters, name)
+		case "headers":
+			delete(c.Headers, name)
+		case "requestBodies":
+			delete(c.RequestBodies, name)
+		case "responses":
+			delete(c.Responses, name)
+		case "examples":
+			delete(c.Examples, name)
+		case "links":
+			delete(c.Links, name)
+		case "callbacks":
+			delete(c.Callbacks, name)
+		}
+	}
+	return pruned
+}
+
+// PruneComponents removes the components of the spec no operation refers to, and returns their references.
+// It is meant to be called once all the operations are registered, and is a no-op once the engine is frozen.
+func (e *Engine) PruneComponents() []string {
+	var pruned []string
+	e.UpdateSpec(func(doc *openapi3.T) {
+		pruned = PruneComponents(doc)
+	})
+	return pruned
+}
+
+// componentRefs returns the references of the prunable components of the spec, sorted by kind and name.
+func componentRefs(doc *openapi3.T) []string {
+	c := doc.Components
+	var refs []string
+	add := func(kind string, names []string) {
+		for _, name := range names {
+			refs = append(refs, "#/components/"+kind+"/"+name)
+		}
+	}
+	add("callbacks", sortedKeys(c.Callbacks))
+	add("examples", sortedKeys(c.Examples))
+	add("headers", sortedKeys(c.Headers))
+	add("links", sortedKeys(c.Links))
+	add("parameters", sortedKeys(c.Parameters))
+	add("requestBodies", sortedKeys(c.RequestBodies))
+	add("responses", sortedKeys(c.Responses))
+	add("schemas", sortedKeys(c.Schemas))
+	return refs
+}
+
+// splitComponentRef splits a reference such as "#/components/schemas/User" into its kind and name.
+func splitComponentRef(ref string) (string, string) {
+	kind, name, _ := strings.Cut(strings.TrimPrefix(ref, "#/components/"), "/")
+	return kind, name
+}
+
+// referencedComponents returns the references of the components the operations refer to, directly or through
+// other components, including the discriminator mappings.
+func referencedComponents(doc *openapi3.T) map[string]bool {
+	var components map[string]map[string]any
+	if data, err := json.Marshal(doc.Components); err == nil {
+		_ = json.Unmarshal(data, &components)
+	}
+	var paths any
+	if data, err := json.Marshal(doc.Paths); err == nil {
+		_ = json.Unmarshal(data, &paths)
+	}
+	used := make(map[string]bool)
+	var queue []string
+	collect := func(v any) {
+		walkStrings(v, func(s string) {
+			if strings.HasPrefix(s, "#/components/") && !used[s] {
+				used[s] = true
+				queue = append(queue, s)
+			}
+		})
+	}
+	collect(paths)
+	for len(queue) != 0 {
+		ref := queue[0]
+		queue = queue[1:]
+		kind, name := splitComponentRef(ref)
+		collect(components[kind][name])
+	}
+	return used
+}
+
+// walkStrings calls fn with the strings of the decoded JSON value.
+func walkStrings(v any, fn func(string)) {
+	switch v := v.(type) {
+	case string:
+		fn(v)
+	case []any:
+		for _, item := range v {
+			walkStrings(item, fn)
+		}
+	case map[string]any:
+		for _, item := range v {
+			walkStrings(item, fn)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package soda_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type statsAddress struct {
+	City string `json:"city"`
+}
+
+type statsCustomer struct {
+	Name    string       `json:"name"`
+	Address statsAddress `json:"address"`
+}
+
+type statsAudit struct {
+	Event string `json:"event"`
+}
+
+func TestSpecStats(t *testing.T) {
+	Convey("Given an engine with public and internal operations", t, func() {
+		engine := soda.New()
+		ok := func(c *fiber.Ctx) error { return c.SendStatus(http.StatusOK) }
+		engine.Get("/customers", ok).AddJSONResponse(http.StatusOK, []statsCustomer{}).OK()
+		engine.Get("/customers/:id", ok).AddJSONResponse(http.StatusOK, statsCustomer{}).OK()
+		engine.Get("/internal/audit", ok).AddJSONResponse(http.StatusOK, statsAudit{}).OK()
+		engine.UpdateSpec(func(doc *openapi3.T) {
+			doc.Components.Schemas["Legacy"] = openapi3.NewStringSchema().NewRef()
+		})
+
+		Convey("The stats should count the operations and the components", func() {
+			stats := engine.SpecStats()
+			So(stats.Paths, ShouldEqual, 3)
+			So(stats.Operations, ShouldEqual, 3)
+			So(stats.Schemas, ShouldEqual, len(engine.OpenAPI().Components.Schemas))
+			So(stats.Components, ShouldBeGreaterThanOrEqualTo, stats.Schemas)
+			So(stats.UnusedComponents, ShouldResemble, []string{"#/components/schemas/Legacy"})
+			So(stats.Bytes, ShouldBeGreaterThan, 0)
+		})
+
+		Convey("Pruning should remove the unused components only", func() {
+			So(engine.PruneComponents(), ShouldResemble, []string{"#/components/schemas/Legacy"})
+			schemas := engine.OpenAPI().Components.Schemas
+			So(schemas, ShouldNotContainKey, "Legacy")
+			So(schemas, ShouldContainKey, "soda_test.statsAddress")
+			So(engine.SpecStats().UnusedComponents, ShouldBeEmpty)
+		})
+
+		Convey("Pruning should keep the spec variants lean after hiding operations", func() {
+			engine.UseSpecTransformer(soda.SpecTransformer{
+				Variant: func(c *fiber.Ctx) string { return "public" },
+				Transform: func(_ string, doc *openapi3.T) {
+					doc.Paths.Delete("/internal/audit")
+					soda.PruneComponents(doc)
+				},
+			})
+			engine.ServeSpecJSON("/openapi.json")
+			resp, err := engine.App().Test(httptest.NewRequest("GET", "/openapi.json", nil))
+			So(err, ShouldBeNil)
+			data, _ := io.ReadAll(resp.Body)
+			var doc openapi3.T
+			So(json.Unmarshal(data, &doc), ShouldBeNil)
+			So(doc.Components.Schemas, ShouldNotContainKey, "soda_test.statsAudit")
+			So(doc.Components.Schemas, ShouldContainKey, "soda_test.statsCustomer")
+			So(engine.OpenAPI().Components.Schemas, ShouldContainKey, "soda_test.statsAudit")
+		})
+	})
+}
@@ -0,0 +1,88 @@
+package soda
+
+import (
+	"sync"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// SpecTransformer customizes the spec served to a request, e.g. injecting tenant-specific server URLs or hiding
+// the operations the caller is not entitled to.
+type SpecTransformer struct {
+	// Variant returns the key of the variant of the spec served to the request, such as the tenant.
+	// Transform is called once per variant, whose spec is then cached.
+	Variant func(c *fiber.Ctx) string
+	// Transform customizes a copy of the spec for the variant.
+	Transform func(variant string, spec *openapi3.T)
+}
+
+// specVariant is a customized spec cached for a variant, with its lazily marshaled forms.
+type specVariant struct {
+	doc      *openapi3.T
+	jsonOnce sync.Once
+	json     []byte
+	yamlOnce sync.Once
+	yaml     []byte
+}
+
+// UseSpecTransformer customizes the spec served by the documentation endpoints per request.
+// It is meant to be called once all the operations are registered.
+func (e *Engine) UseSpecTransformer(transformer SpecTransformer) *Engine {
+	e.specTransformer = &transformer
+	e.specVariants = sync.Map{}
+	return e
+}
+
+// specCustomized reports whether the spec served depends on the request, being transformed or localized.
+func (e *Engine) specCustomized() bool {
+	return e.specTransformer != nil || e.specLocalized
+}
+
+// specFor returns the spec served to the request.
+func (e *Engine) specFor(c *fiber.Ctx) *specVariant {
+	var variant, lang string
+	if e.specTransformer != nil {
+		variant = e.specTransformer.Variant(c)
+	}
+	if e.specLocalized {
+		lang = specLanguage(c)
+	}
+	key := variant + "\x00" + lang
+	if v, ok := e.specVariants.Load(key); ok {
+		return v.(*specVariant)
+	}
+	doc := cloneSpec(e.gen.doc)
+	if lang != "" {
+		localizeSpec(doc, lang)
+	}
+	if e.specTransformer != nil {
+		e.specTransformer.Transform(variant, doc)
+	}
+	v, _ := e.specVariants.LoadOrStore(key, &specVariant{doc: doc})
+	return v.(*specVariant)
+}
+
+func (v *specVariant) marshalJSON() []byte {
+	v.jsonOnce.Do(func() { v.json, _ = v.doc.MarshalJSON() })
+	return v.json
+}
+
+func (v *specVariant) marshalYAML() []byte {
+	v.yamlOnce.Do(func() { v.yaml, _ = yaml.Marshal(v.doc) })
+	return v.yaml
+}
+
+// cloneSpec returns a deep copy of the spec.
+func cloneSpec(doc *openapi3.T) *openapi3.T {
+	data, err := doc.MarshalJSON()
+	if err != nil {
+		panic(err)
+	}
+	clone, err := openapi3.NewLoader().LoadFromData(data)
+	if err != nil {
+		panic(err)
+	}
+	return clone
+}
@@ -0,0 +1,64 @@
+package soda_test
+
+import (
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSpecTransformer(t *testing.T) {
+	Convey("Given a spec customized per tenant", t, func() {
+		engine := soda.New()
+		engine.Get("/users", func(c *fiber.Ctx) error { return nil }).OK()
+		engine.Get("/admin", func(c *fiber.Ctx) error { return nil }).OK()
+		calls := 0
+		engine.UseSpecTransformer(soda.SpecTransformer{
+			Variant: func(c *fiber.Ctx) string { return c.Get("X-Tenant") },
+			Transform: func(tenant string, spec *openapi3.T) {
+				calls++
+				spec.AddServer(&openapi3.Server{URL: "https://" + tenant + ".example.com"})
+				if tenant != "acme" {
+					spec.Paths.Delete("/admin")
+				}
+			},
+		})
+		engine.ServeSpecJSON("/openapi.json")
+		engine.ServeSpecYAML("/openapi.yaml")
+
+		get := func(target, tenant string) string {
+			req := httptest.NewRequest("GET", target, nil)
+			req.Header.Set("X-Tenant", tenant)
+			resp, err := engine.App().Test(req)
+			So(err, ShouldBeNil)
+			body, _ := io.ReadAll(resp.Body)
+			return string(body)
+		}
+
+		Convey("Each tenant should be served its variant", func() {
+			acme := get("/openapi.json", "acme")
+			So(acme, ShouldContainSubstring, "https://acme.example.com")
+			So(acme, ShouldContainSubstring, "/admin")
+			other := get("/openapi.yaml", "other")
+			So(other, ShouldContainSubstring, "https://other.example.com")
+			So(other, ShouldNotContainSubstring, "/admin")
+		})
+
+		Convey("The variants should be cached", func() {
+			get("/openapi.json", "acme")
+			get("/openapi.yaml", "acme")
+			get("/openapi.json", "acme")
+			So(calls, ShouldEqual, 1)
+		})
+
+		Convey("The spec of the engine should be left untouched", func() {
+			get("/openapi.json", "other")
+			So(engine.OpenAPI().Paths.Find("/admin"), ShouldNotBeNil)
+			So(engine.OpenAPI().Servers, ShouldBeEmpty)
+		})
+	})
+}
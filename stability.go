@@ -0,0 +1,53 @@
+package soda
+
+// Stability is the stability level of an operation.
+type Stability int
+
+// The stability levels, from the least to the most stable.
+const (
+	Alpha Stability = iota + 1
+	Beta
+	GA
+)
+
+// stabilityExtension documents the stability level of the operations which are not generally available.
+const stabilityExtension = "x-stability"
+
+func (s Stability) String() string {
+	switch s {
+	case Alpha:
+		return "alpha"
+	case Beta:
+		return "beta"
+	default:
+		return "ga"
+	}
+}
+
+// Stability sets the stability level of the operation, GA by default.
+// Operations less stable than the minimum stability of the engine are neither routed nor documented.
+func (op *OperationBuilder) Stability(stability Stability) *OperationBuilder {
+	op.stability = stability
+	if op.operation.Extensions == nil {
+		op.operation.Extensions = make(map[string]any)
+	}
+	op.operation.Extensions[stabilityExtension] = stability.String()
+	return op
+}
+
+// SetMinStability sets the minimum stability level of the operations finalized afterwards, e.g. GA in production
+// and Beta in staging, so that experimental operations never appear nor route where they are not allowed.
+// All the levels are allowed by default.
+func (e *Engine) SetMinStability(stability Stability) *Engine {
+	e.minStability = stability
+	return e
+}
+
+// stable reports whether the stability of the operation is allowed by the engine.
+func (op *OperationBuilder) stable() bool {
+	stability := op.stability
+	if stability == 0 {
+		stability = GA
+	}
+	return stability >= op.route.engine.minStability
+}
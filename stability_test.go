@@ -0,0 +1,50 @@
+package soda_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestStability(t *testing.T) {
+	Convey("Given operations of every stability level", t, func() {
+		handler := func(c *fiber.Ctx) error { return c.SendString("ok") }
+		register := func(engine *soda.Engine) {
+			engine.Get("/alpha", handler).Stability(soda.Alpha).OK()
+			engine.Get("/beta", handler).Stability(soda.Beta).OK()
+			engine.Get("/ga", handler).OK()
+		}
+		status := func(engine *soda.Engine, target string) int {
+			resp, err := engine.App().Test(httptest.NewRequest("GET", target, nil))
+			So(err, ShouldBeNil)
+			return resp.StatusCode
+		}
+
+		Convey("All of them should be registered by default", func() {
+			engine := soda.New()
+			register(engine)
+			So(status(engine, "/alpha"), ShouldEqual, http.StatusOK)
+			So(engine.OpenAPI().Paths.Find("/alpha").Get.Extensions["x-stability"], ShouldEqual, "alpha")
+			So(engine.OpenAPI().Paths.Find("/ga").Get.Extensions, ShouldNotContainKey, "x-stability")
+		})
+
+		Convey("The less stable ones should be discarded", func() {
+			engine := soda.New().SetMinStability(soda.Beta)
+			register(engine)
+			So(status(engine, "/alpha"), ShouldEqual, http.StatusNotFound)
+			So(engine.OpenAPI().Paths.Find("/alpha"), ShouldBeNil)
+			So(status(engine, "/beta"), ShouldEqual, http.StatusOK)
+			So(engine.OpenAPI().Paths.Find("/beta").Get.Extensions["x-stability"], ShouldEqual, "beta")
+
+			engine = soda.New().SetMinStability(soda.GA)
+			register(engine)
+			So(status(engine, "/beta"), ShouldEqual, http.StatusNotFound)
+			So(status(engine, "/ga"), ShouldEqual, http.StatusOK)
+			So(engine.Routes(), ShouldHaveLength, 1)
+		})
+	})
+}
@@ -0,0 +1,57 @@
+package soda
+
+import (
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// StaticFile describes a file of a directory listed by StaticListing.
+type StaticFile struct {
+	Name    string    `json:"name"`
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"modTime"`
+	IsDir   bool      `json:"isDir"`
+}
+
+// Static serves the static files of the root directory under the prefix through fiber.
+// Those routes are not operations and never appear in the spec.
+func (r *Router) Static(prefix, root string, config ...fiber.Static) *Router {
+	r.Raw.Static(prefix, root, config...)
+	return r
+}
+
+// SPA serves a single-page application from the root directory under the prefix, answering the paths which are
+// not files with its index.html so that the client-side routing works. Like Static, it never appears in the spec.
+func (r *Router) SPA(prefix, root string) *Router {
+	r.Raw.Static(prefix, root)
+	index := filepath.Join(root, "index.html")
+	r.Raw.Get(path.Join(prefix, "*"), func(c *fiber.Ctx) error {
+		return c.SendFile(index)
+	})
+	return r
+}
+
+// StaticListing registers a documented operation listing the files of the root directory,
+// e.g. a download directory served by Static.
+func (r *Router) StaticListing(pattern, root string) *OperationBuilder {
+	return r.Get(pattern, func(c *fiber.Ctx) error {
+		entries, err := os.ReadDir(root)
+		if err != nil {
+			return err
+		}
+		files := make([]StaticFile, 0, len(entries))
+		for _, entry := range entries {
+			info, err := entry.Info()
+			if err != nil {
+				continue
+			}
+			files = append(files, StaticFile{Name: entry.Name(), Size: info.Size(), ModTime: info.ModTime(), IsDir: entry.IsDir()})
+		}
+		return c.JSON(files)
+	}).AddJSONResponse(http.StatusOK, []StaticFile{})
+}
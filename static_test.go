@@ -0,0 +1,63 @@
+package soda_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestStatic(t *testing.T) {
+	Convey("Given static files", t, func() {
+		root := t.TempDir()
+		So(os.WriteFile(filepath.Join(root, "index.html"), []byte("<html>app</html>"), 0o600), ShouldBeNil)
+		So(os.WriteFile(filepath.Join(root, "app.js"), []byte("console.log(1)"), 0o600), ShouldBeNil)
+		get := func(engine *soda.Engine, target string) (int, string) {
+			resp, err := engine.App().Test(httptest.NewRequest("GET", target, nil))
+			So(err, ShouldBeNil)
+			body, _ := io.ReadAll(resp.Body)
+			return resp.StatusCode, string(body)
+		}
+
+		Convey("Static should serve them without documenting them", func() {
+			engine := soda.New()
+			engine.Static("/assets", root)
+			status, body := get(engine, "/assets/app.js")
+			So(status, ShouldEqual, http.StatusOK)
+			So(body, ShouldEqual, "console.log(1)")
+			So(engine.OpenAPI().Paths.Len(), ShouldEqual, 0)
+			So(engine.Routes(), ShouldBeEmpty)
+		})
+
+		Convey("SPA should fall back to the index", func() {
+			engine := soda.New()
+			engine.SPA("/app", root)
+			status, body := get(engine, "/app/app.js")
+			So(status, ShouldEqual, http.StatusOK)
+			So(body, ShouldEqual, "console.log(1)")
+			status, body = get(engine, "/app/users/1")
+			So(status, ShouldEqual, http.StatusOK)
+			So(body, ShouldEqual, "<html>app</html>")
+			So(engine.OpenAPI().Paths.Len(), ShouldEqual, 0)
+		})
+
+		Convey("StaticListing should document and list the files", func() {
+			engine := soda.New()
+			engine.StaticListing("/downloads", root).OK()
+			So(engine.OpenAPI().Paths.Find("/downloads").Get, ShouldNotBeNil)
+			status, body := get(engine, "/downloads")
+			So(status, ShouldEqual, http.StatusOK)
+			var files []soda.StaticFile
+			So(json.Unmarshal([]byte(body), &files), ShouldBeNil)
+			So(files, ShouldHaveLength, 2)
+			So(files[0].Name, ShouldEqual, "app.js")
+			So(files[0].Size, ShouldEqual, 14)
+		})
+	})
+}
@@ -0,0 +1,224 @@
+package soda
+
+import (
+	"bufio"
+	"encoding"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"reflect"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+)
+
+// eachItem calls fn for each item of a slice, an array, a channel or an iterator function
+// of the form func(yield func(T) bool), until fn returns false.
+func eachItem(items reflect.Value, fn func(item reflect.Value) bool) {
+	switch items.Kind() {
+	case reflect.Func:
+		yield := reflect.MakeFunc(items.Type().In(0), func(args []reflect.Value) []reflect.Value {
+			return []reflect.Value{reflect.ValueOf(fn(args[0]))}
+		})
+		items.Call([]reflect.Value{yield})
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < items.Len(); i++ {
+			if !fn(items.Index(i)) {
+				return
+			}
+		}
+	case reflect.Chan:
+		for {
+			item, ok := items.Recv()
+			if !ok || !fn(item) {
+				return
+			}
+		}
+	default:
+		panic("items must be a slice, an array, a channel or an iterator, got " + items.Type().String())
+	}
+}
+
+// itemType returns the type of the items of a slice, an array, a channel or an iterator function.
+func itemType(t reflect.Type) reflect.Type {
+	switch t.Kind() {
+	case reflect.Func:
+		return t.In(0).In(0)
+	case reflect.Slice, reflect.Array, reflect.Chan:
+		return t.Elem()
+	}
+	panic("items must be a slice, an array, a channel or an iterator, got " + t.String())
+}
+
+// csvColumn is a column of a CSV export, resolved from a struct field.
+type csvColumn struct {
+	name  string
+	index []int
+}
+
+// csvColumns resolves the columns of a row type from the `csv` tags of its fields,
+// falling back to the `json` tags and then to the field names.
+func csvColumns(t reflect.Type) []csvColumn {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		panic("csv rows must be structs, got " + t.String())
+	}
+	columns := make([]csvColumn, 0, t.NumField())
+	for _, f := range reflect.VisibleFields(t) {
+		if f.Anonymous || !f.IsExported() || f.Tag.Get("csv") == "-" || f.Tag.Get(OpenAPITag) == "-" {
+			continue
+		}
+		field := newTagsResolver(f)
+		name := field.name("csv")
+		if f.Tag.Get("csv") == "" {
+			name = field.name("json")
+		}
+		if name == "-" {
+			continue
+		}
+		columns = append(columns, csvColumn{name: name, index: f.Index})
+	}
+	return columns
+}
+
+// csvValue formats a field value as a CSV cell.
+func csvValue(v reflect.Value) string {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+	switch value := v.Interface().(type) {
+	case time.Time:
+		return value.Format(time.RFC3339)
+	case encoding.TextMarshaler:
+		text, _ := value.MarshalText()
+		return string(text)
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+// GenerateCSVResponse generates a text/csv response whose rows are described by the model,
+// the column names being listed in order by the x-csv-columns extension.
+func (g *Generator) GenerateCSVResponse(model any, description string) *openapi3.Response {
+	t := reflect.TypeOf(model)
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		t = t.Elem()
+	}
+	row := openapi3.NewObjectSchema()
+	names := make([]string, 0)
+	for _, column := range csvColumns(t) {
+		f := t.FieldByIndex(column.index)
+		schemaRef := g.generateSchemaRef(nil, f.Type, "json")
+		if schemaRef.Value != nil && schemaRef.Ref == "" {
+			newTagsResolver(f).injectOAITags(schemaRef.Value)
+		}
+		row.Properties[column.name] = schemaRef
+		names = append(names, column.name)
+	}
+	schema := openapi3.NewArraySchema().WithItems(row)
+	schema.Extensions = map[string]any{"x-csv-columns": names}
+	return openapi3.NewResponse().
+		WithDescription(description).
+		WithContent(openapi3.NewContentWithSchema(schema, []string{"text/csv"}))
+}
+
+// AddCSVResponse adds a text/csv response to the operation, the columns being described by the row model.
+func (op *OperationBuilder) AddCSVResponse(code int, model any, description ...string) (self *OperationBuilder) {
+	defer op.recoverMisuse(&self)
+	desc := http.StatusText(code)
+	if len(description) > 0 {
+		desc = description[0]
+	}
+	op.operation.AddResponse(code, op.route.gen.GenerateCSVResponse(model, desc))
+	return op
+}
+
+// SendCSV streams the rows as text/csv, preceded by a header row.
+// The rows may be a slice, an array or a channel of structs; a channel is consumed while the response is written,
+// so an export never needs to be buffered whole.
+func SendCSV(c *fiber.Ctx, rows any) error {
+	items := reflect.ValueOf(rows)
+	columns := csvColumns(itemType(items.Type()))
+
+	c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		writer := csv.NewWriter(w)
+		header := make([]string, len(columns))
+		for i, column := range columns {
+			header[i] = column.name
+		}
+		_ = writer.Write(header)
+
+		record := make([]string, len(columns))
+		eachItem(items, func(item reflect.Value) bool {
+			for item.Kind() == reflect.Ptr {
+				item = item.Elem()
+			}
+			for i, column := range columns {
+				record[i] = ""
+				if field, err := item.FieldByIndexErr(column.index); err == nil {
+					record[i] = csvValue(field)
+				}
+			}
+			if err := writer.Write(record); err != nil {
+				return false
+			}
+			writer.Flush()
+			return w.Flush() == nil
+		})
+		writer.Flush()
+	})
+	return nil
+}
+
+// GenerateNDJSONResponse generates an application/x-ndjson response, each line of which is described by the model.
+func (g *Generator) GenerateNDJSONResponse(model any, description string) *openapi3.Response {
+	schema := g.generateSchemaRef(nil, reflect.TypeOf(model), "json")
+	return openapi3.NewResponse().
+		WithDescription(description).
+		WithContent(openapi3.NewContentWithSchemaRef(schema, []string{"application/x-ndjson"}))
+}
+
+// AddNDJSONResponse adds an application/x-ndjson response to the operation, each line being described by the model.
+func (op *OperationBuilder) AddNDJSONResponse(code int, model any, description ...string) (self *OperationBuilder) {
+	defer op.recoverMisuse(&self)
+	desc := http.StatusText(code)
+	if len(description) > 0 {
+		desc = description[0]
+	}
+	op.operation.AddResponse(code, op.route.gen.GenerateNDJSONResponse(model, desc))
+	return op
+}
+
+// SendNDJSON streams the items as newline-delimited JSON, flushing after each item, their properties being named
+// with the naming strategy of the engine.
+// The items may be a slice, an array, a channel or an iterator function of the form func(yield func(T) bool),
+// which suits large result sets as well as tailing endpoints fed by a channel.
+func SendNDJSON(c *fiber.Ctx, items any) error {
+	values := reflect.ValueOf(items)
+	itemType(values.Type()) // fail early on unsupported kinds
+
+	// The items are named like the documented item schema.
+	var naming NamingStrategy
+	if op, ok := c.Locals(keyOperationBuilder).(*OperationBuilder); ok {
+		naming = op.route.gen.naming
+	}
+	c.Set(fiber.HeaderContentType, "application/x-ndjson")
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		eachItem(values, func(item reflect.Value) bool {
+			data, err := naming.Marshal(item.Interface())
+			if err != nil {
+				return false
+			}
+			w.Write(data)
+			w.WriteByte('\n')
+			return w.Flush() == nil
+		})
+	})
+	return nil
+}
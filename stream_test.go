@@ -0,0 +1,150 @@
+package soda_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type csvRow struct {
+	ID        int       `csv:"id"         json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `csv:"created_at" json:"createdAt"`
+	Note      *string   `csv:"note"       json:"note"`
+	Internal  string    `csv:"-"          json:"internal"`
+}
+
+func TestCSV(t *testing.T) {
+	Convey("Given an operation exporting CSV", t, func() {
+		createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+		note := "hello, world"
+		engine := soda.New()
+		engine.Get("/export", func(c *fiber.Ctx) error {
+			if c.Query("stream") != "" {
+				rows := make(chan csvRow)
+				go func() {
+					defer close(rows)
+					for i := 1; i <= 3; i++ {
+						rows <- csvRow{ID: i, Name: "row", CreatedAt: createdAt}
+					}
+				}()
+				return soda.SendCSV(c, rows)
+			}
+			return soda.SendCSV(c, []*csvRow{{ID: 1, Name: "soda", CreatedAt: createdAt, Note: &note}})
+		}).AddCSVResponse(http.StatusOK, csvRow{}).OK()
+
+		get := func(url string) (*http.Response, string) {
+			request, _ := http.NewRequest("GET", url, nil)
+			response, _ := engine.App().Test(request)
+			body, _ := io.ReadAll(response.Body)
+			return response, string(body)
+		}
+
+		Convey("The CSV response should be documented with its columns", func() {
+			content := engine.OpenAPI().Paths.Find("/export").Get.Responses.Status(200).Value.Content
+			So(content, ShouldContainKey, "text/csv")
+			schema := content["text/csv"].Schema.Value
+			So(schema.Extensions["x-csv-columns"], ShouldResemble, []string{"id", "name", "created_at", "note"})
+			So(schema.Items.Value.Properties, ShouldContainKey, "created_at")
+			So(schema.Items.Value.Properties, ShouldNotContainKey, "internal")
+		})
+
+		Convey("A slice of rows should be exported", func() {
+			response, body := get("/export")
+			So(response.Header.Get("Content-Type"), ShouldEqual, "text/csv; charset=utf-8")
+			So(body, ShouldEqual, "id,name,created_at,note\n1,soda,2024-01-02T03:04:05Z,\"hello, world\"\n")
+		})
+
+		Convey("A channel of rows should be streamed", func() {
+			_, body := get("/export?stream=1")
+			So(body, ShouldEqual, "id,name,created_at,note\n"+
+				"1,row,2024-01-02T03:04:05Z,\n"+
+				"2,row,2024-01-02T03:04:05Z,\n"+
+				"3,row,2024-01-02T03:04:05Z,\n")
+		})
+	})
+}
+
+func TestNDJSON(t *testing.T) {
+	Convey("Given an operation streaming NDJSON", t, func() {
+		type event struct {
+			ID int `json:"id"`
+		}
+		engine := soda.New()
+		engine.Get("/events", func(c *fiber.Ctx) error {
+			switch c.Query("source") {
+			case "iterator":
+				return soda.SendNDJSON(c, func(yield func(event) bool) {
+					for i := 1; i <= 3; i++ {
+						if !yield(event{ID: i}) {
+							return
+						}
+					}
+				})
+			case "channel":
+				events := make(chan event, 2)
+				events <- event{ID: 1}
+				events <- event{ID: 2}
+				close(events)
+				return soda.SendNDJSON(c, events)
+			}
+			return soda.SendNDJSON(c, []event{{ID: 1}})
+		}).AddNDJSONResponse(http.StatusOK, event{}).OK()
+
+		get := func(url string) (*http.Response, string) {
+			request, _ := http.NewRequest("GET", url, nil)
+			response, _ := engine.App().Test(request)
+			body, _ := io.ReadAll(response.Body)
+			return response, string(body)
+		}
+
+		Convey("The item schema should be documented", func() {
+			content := engine.OpenAPI().Paths.Find("/events").Get.Responses.Status(200).Value.Content
+			So(content, ShouldContainKey, "application/x-ndjson")
+			So(content["application/x-ndjson"].Schema.Value.Properties, ShouldContainKey, "id")
+		})
+
+		Convey("A slice should be streamed", func() {
+			response, body := get("/events")
+			So(response.Header.Get("Content-Type"), ShouldEqual, "application/x-ndjson")
+			So(body, ShouldEqual, "{\"id\":1}\n")
+		})
+
+		Convey("A channel should be streamed", func() {
+			_, body := get("/events?source=channel")
+			So(body, ShouldEqual, "{\"id\":1}\n{\"id\":2}\n")
+		})
+
+		Convey("An iterator should be streamed", func() {
+			_, body := get("/events?source=iterator")
+			So(body, ShouldEqual, "{\"id\":1}\n{\"id\":2}\n{\"id\":3}\n")
+		})
+
+		Convey("Unsupported items should panic", func() {
+			So(func() { soda.SendNDJSON(nil, event{}) }, ShouldPanic)
+		})
+
+		Convey("The items should be named with the naming strategy of the engine", func() {
+			type namedEvent struct {
+				EventID int
+			}
+			named := soda.New().SetPropertyNaming(soda.SnakeCase)
+			named.Get("/events", func(c *fiber.Ctx) error {
+				return soda.SendNDJSON(c, []namedEvent{{EventID: 1}})
+			}).AddNDJSONResponse(http.StatusOK, namedEvent{}).OK()
+
+			response, err := named.App().Test(httptest.NewRequest("GET", "/events", nil))
+			So(err, ShouldBeNil)
+			body, _ := io.ReadAll(response.Body)
+			So(string(body), ShouldEqual, "{\"event_id\":1}\n")
+			content := named.OpenAPI().Paths.Find("/events").Get.Responses.Status(200).Value.Content
+			So(content["application/x-ndjson"].Schema.Value.Properties, ShouldContainKey, "event_id")
+		})
+	})
+}
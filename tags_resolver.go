@@ -2,11 +2,43 @@ package soda
 
 import (
 	"reflect"
+	"slices"
 	"strings"
 
 	"github.com/getkin/kin-openapi/openapi3"
 )
 
+// TagPropHandler mutates the schema of a field tagged with a custom prop, e.g. `oai:"unit=seconds"`.
+// The value is empty for flag props such as `oai:"pii"`.
+type TagPropHandler func(schema *openapi3.Schema, value string)
+
+var (
+	tagPropHandlers = map[string]TagPropHandler{}
+
+	builtinProps = map[string]bool{
+		propExplode: true, propStyle: true, propExamples: true,
+		propTitle: true, propDescription: true, propType: true, propDeprecated: true, propAllowEmptyValue: true,
+		propNullable: true, propReadOnly: true, propWriteOnly: true, propEnum: true, propDefault: true,
+		propExample: true, propRequired: true, propRequiredIf: true, propConst: true, propSensitive: true, propRedact: true, propContentType: true,
+		propMinLength: true, propMaxLength: true, propPattern: true, propFormat: true,
+		propMultipleOf: true, propMinimum: true, propMin: true, propMaximum: true, propMax: true,
+		propExclusiveMaximum: true, propExclusiveMinimum: true,
+		propMinItems: true, propMaxItems: true, propUniqueItems: true,
+		propPatternProperties: true, propPropertyNames: true, propMinProperties: true, propMaxProperties: true,
+		propLtField: true, propLteField: true, propGtField: true, propGteField: true, propNeField: true,
+		propSchema: true, propAlias: true, propConverter: true,
+	}
+)
+
+// RegisterTagProp registers a handler for a custom `oai` tag prop, called after the built-in props are injected.
+// It panics when the prop is a built-in one.
+func RegisterTagProp(name string, handler TagPropHandler) {
+	if builtinProps[name] {
+		panic("cannot override the built-in tag prop " + name)
+	}
+	tagPropHandlers[name] = handler
+}
+
 // tagsResolver is a structure that contains a reflect.StructField and a map for tag pairs.
 // It is used to resolve the tags of a struct field.
 type tagsResolver struct {
@@ -49,13 +81,49 @@ func (f tagsResolver) injectOAITags(schema *openapi3.Schema) {
 		f.injectOAIArray(schema)
 	case schema.Type.Is(typeBoolean):
 		f.injectOAIBoolean(schema)
+	case schema.Type.Is(typeObject):
+		f.injectOAIObject(schema)
+	}
+
+	// Restrict the schema of const fields
+	f.injectOAIConst(schema)
+
+	// Mask the examples of sensitive fields
+	f.injectOAISensitive(schema)
+
+	// Inject custom props
+	f.injectOAICustom(schema)
+}
+
+// injectOAICustom injects the custom props registered with RegisterTagProp into a schema, in a stable order.
+func (f tagsResolver) injectOAICustom(schema *openapi3.Schema) {
+	props := make([]string, 0, len(f.pairs))
+	for prop := range f.pairs {
+		if _, ok := tagPropHandlers[prop]; ok {
+			props = append(props, prop)
+		}
+	}
+	slices.Sort(props)
+	for _, prop := range props {
+		tagPropHandlers[prop](schema, f.pairs[prop])
 	}
 }
 
 // required checks if the field is required.
-func (f tagsResolver) required() bool {
+// If the field is tagged with the specified tag and the `omitempty` option, it is optional as well.
+func (f tagsResolver) required(tag ...string) bool {
 	// By default, a field is required if it is not a pointer
 	required := f.f.Type.Kind() != reflect.Ptr
+	if len(tag) > 0 {
+		_, opts, _ := strings.Cut(f.f.Tag.Get(tag[0]), ",")
+		if slices.Contains(strings.Split(opts, ","), "omitempty") {
+			required = false
+		}
+	}
+	// A conditionally required field is optional otherwise
+	if _, ok := f.pairs[propRequiredIf]; ok {
+		required = false
+	}
 	// Check the 'required' tag
 	if v, ok := f.pairs[propRequired]; ok {
 		required = toBool(v)
@@ -68,8 +136,8 @@ func (f tagsResolver) required() bool {
 // If the tag contains a comma, then only the first part of the tag is used.
 func (f tagsResolver) name(tag ...string) string {
 	if len(tag) > 0 {
-		if name := f.f.Tag.Get(tag[0]); name != "" {
-			return strings.Split(name, ",")[0]
+		if name, _, _ := strings.Cut(f.f.Tag.Get(tag[0]), ","); name != "" {
+			return name
 		}
 	}
 	return f.f.Name
@@ -202,3 +270,35 @@ func (f *tagsResolver) injectOAIArray(schema *openapi3.Schema) {
 		}
 	}
 }
+
+// injectOAIObject injects OAI tags for object type into a schema.
+// OpenAPI 3.0 has neither patternProperties nor propertyNames, which are documented as extensions.
+func (f *tagsResolver) injectOAIObject(schema *openapi3.Schema) {
+	// Iterate over the tag pairs and inject them into the schema
+	for tag, val := range f.pairs {
+		switch tag {
+		case propPatternProperties:
+			if schema.Extensions == nil {
+				schema.Extensions = make(map[string]any)
+			}
+			properties := openapi3.NewSchema()
+			if schema.AdditionalProperties.Schema != nil {
+				properties = schema.AdditionalProperties.Schema.Value
+			}
+			schema.Extensions["x-patternProperties"] = map[string]any{val: properties}
+		case propPropertyNames:
+			if schema.Extensions == nil {
+				schema.Extensions = make(map[string]any)
+			}
+			schema.Extensions["x-propertyNames"] = openapi3.NewStringSchema().WithPattern(val)
+		case propMinProperties:
+			if num, err := toUint64E(val); err == nil {
+				schema.MinProps = num
+			}
+		case propMaxProperties:
+			if num, err := toUint64E(val); err == nil {
+				schema.MaxProps = &num
+			}
+		}
+	}
+}
@@ -57,7 +57,7 @@ func TestTagResolver(t *testing.T) {
 			expect := openapi3.NewObjectSchema().
 				WithProperty("a", expectA).
 				WithRequired([]string{"a"})
-			So(schema.Value, ShouldResemble, expect)
+			So(schema.Value, ShouldResemble, withPropertyOrder(expect, "a"))
 		})
 
 		Convey("It should inject number related tags", func() {
@@ -113,7 +113,7 @@ func TestTagResolver(t *testing.T) {
 				WithProperty("a", expectA).
 				WithRequired([]string{"a"})
 
-			So(schema.Value, ShouldResemble, expect)
+			So(schema.Value, ShouldResemble, withPropertyOrder(expect, "a"))
 		})
 	})
 
@@ -131,7 +131,32 @@ func TestTagResolver(t *testing.T) {
 				WithProperty("a", expectA).
 				WithRequired([]string{"a"})
 
-			So(schema.Value, ShouldResemble, expect)
+			So(schema.Value, ShouldResemble, withPropertyOrder(expect, "a"))
+		})
+	})
+
+	Convey("Given custom tag props", t, func() {
+		soda.RegisterTagProp("unit", func(schema *openapi3.Schema, value string) {
+			schema.Extensions = map[string]any{"x-unit": value}
+		})
+		soda.RegisterTagProp("pii", func(schema *openapi3.Schema, value string) {
+			schema.Description += " (PII)"
+		})
+		type testStruct struct {
+			A int    `json:"a" oai:"unit=seconds"`
+			B string `json:"b" oai:"description=Email;pii"`
+			C string `json:"c" oai:"unknown=ignored"`
+		}
+
+		Convey("It should call the registered handlers", func() {
+			schema := soda.GenerateSchemaRef(testStruct{}, "json")
+			So(schema.Value.Properties["a"].Value.Extensions["x-unit"], ShouldEqual, "seconds")
+			So(schema.Value.Properties["b"].Value.Description, ShouldEqual, "Email (PII)")
+			So(schema.Value.Properties["c"].Value, ShouldResemble, openapi3.NewStringSchema())
+		})
+
+		Convey("It should refuse to override built-in props", func() {
+			So(func() { soda.RegisterTagProp("minLength", nil) }, ShouldPanic)
 		})
 	})
 }
@@ -0,0 +1,87 @@
+package soda
+
+import (
+	"maps"
+	"path"
+	"slices"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+)
+
+// OperationTemplate returns a builder not bound to any route, on which a common setup, such as the same security,
+// error responses and tags, is defined once and then stamped onto many operations:
+//
+//	admin := engine.OperationTemplate().AddTags("admin").AddJSONResponse(http.StatusForbidden, Problem{})
+//	admin.Clone().Route(http.MethodGet, "/users", listUsers).SetInput(ListUsers{}).OK()
+//	admin.Clone().Route(http.MethodDelete, "/users/:id", deleteUser).OK()
+//
+// Like the operations of the router, it starts with the common tags, responses and securities of the router.
+func (r *Router) OperationTemplate() *OperationBuilder {
+	builder := r.Add("", "")
+	builder.operation.Summary, builder.operation.OperationID = "", ""
+	// The clone doesn't share the securities of the router.
+	return builder.Clone()
+}
+
+// Clone returns an independent copy of the builder, typically of a template to stamp onto an operation with Route,
+// or of an operation to register under another route.
+func (op *OperationBuilder) Clone() *OperationBuilder {
+	clone := *op
+	operation := *op.operation
+	operation.Tags = slices.Clone(op.operation.Tags)
+	operation.Parameters = slices.Clone(op.operation.Parameters)
+	operation.Extensions = maps.Clone(op.operation.Extensions)
+	operation.Callbacks = maps.Clone(op.operation.Callbacks)
+	if op.operation.Responses != nil {
+		operation.Responses = openapi3.NewResponses()
+		operation.Responses.Delete("default")
+		for code, resp := range op.operation.Responses.Map() {
+			operation.Responses.Set(code, resp)
+		}
+	}
+	if op.operation.Security != nil {
+		security := slices.Clone(*op.operation.Security)
+		operation.Security = &security
+	}
+	clone.operation = &operation
+
+	clone.handlers = slices.Clone(op.handlers)
+	clone.hooksBeforeBind = slices.Clone(op.hooksBeforeBind)
+	clone.hooksAfterBind = slices.Clone(op.hooksAfterBind)
+	clone.requestTransformers = slices.Clone(op.requestTransformers)
+	clone.responseTransformers = slices.Clone(op.responseTransformers)
+	clone.permissions = slices.Clone(op.permissions)
+	clone.inputSkipped = slices.Clone(op.inputSkipped)
+	clone.inputIn = maps.Clone(op.inputIn)
+	clone.queryAliases = maps.Clone(op.queryAliases)
+	clone.pathConverters = maps.Clone(op.pathConverters)
+	clone.advisoryHeaders = maps.Clone(op.advisoryHeaders)
+	clone.params = maps.Clone(op.params)
+	clone.responseMediaTypes = maps.Clone(op.responseMediaTypes)
+	if op.concurrency != nil {
+		clone.concurrency = make(chan struct{}, cap(op.concurrency))
+	}
+	if op.coalescer != nil {
+		clone.coalescer = &coalescer{key: op.coalescer.key}
+	}
+	return &clone
+}
+
+// Route routes the builder to the method and the pattern relative to its router, handled by the handlers
+// if any are given. The default summary and operation ID follow the new route, unlike those set explicitly.
+func (op *OperationBuilder) Route(method, pattern string, handlers ...fiber.Handler) *OperationBuilder {
+	pattern = op.route.engine.normalizePattern(pattern)
+	patternFull := path.Join(op.route.commonPrefix, pattern)
+	if op.operation.Summary == "" || op.operation.Summary == op.method+" "+op.patternFull {
+		op.operation.Summary = method + " " + patternFull
+	}
+	if op.operation.OperationID == "" || op.operation.OperationID == genDefaultOperationID(op.method, op.patternFull) {
+		op.operation.OperationID = genDefaultOperationID(method, patternFull)
+	}
+	op.method, op.pattern, op.patternFull = method, pattern, patternFull
+	if len(handlers) != 0 {
+		op.handlers = handlers
+	}
+	return op
+}
@@ -0,0 +1,70 @@
+package soda_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type templateProblem struct {
+	Detail string `json:"detail"`
+}
+
+func TestOperationTemplate(t *testing.T) {
+	Convey("Given an operation template", t, func() {
+		engine := soda.New()
+		ok := func(c *fiber.Ctx) error { return c.SendString(c.Route().Path) }
+		admin := engine.OperationTemplate().
+			AddTags("admin").
+			AddSecurity("jwt", soda.NewJWTSecurityScheme()).
+			AddJSONResponse(http.StatusForbidden, templateProblem{})
+		admin.Clone().Route(http.MethodGet, "/users", ok).OK()
+		admin.Clone().Route(http.MethodDelete, "/users/:id", ok).SetSummary("Delete a user").OK()
+		engine.Get("/public", ok).OK()
+
+		Convey("The setup should be stamped onto each operation", func() {
+			list := engine.OpenAPI().Paths.Value("/users").Get
+			So(list.Tags, ShouldResemble, []string{"admin"})
+			So(list.Summary, ShouldEqual, "GET /users")
+			So(list.OperationID, ShouldEqual, engine.Routes()[0].OperationID)
+			So(list.Responses.Status(http.StatusForbidden), ShouldNotBeNil)
+			So(*list.Security, ShouldHaveLength, 1)
+
+			del := engine.OpenAPI().Paths.Value("/users/:id").Delete
+			So(del.Summary, ShouldEqual, "Delete a user")
+			So(del.Tags, ShouldResemble, []string{"admin"})
+		})
+
+		Convey("The template should not leak into the other operations", func() {
+			public := engine.OpenAPI().Paths.Value("/public").Get
+			So(public.Tags, ShouldBeEmpty)
+			So(public.Security, ShouldNotBeNil)
+			So(*public.Security, ShouldBeEmpty)
+			So(public.Responses.Status(http.StatusForbidden), ShouldBeNil)
+		})
+
+		Convey("The operations should be routed", func() {
+			resp, err := engine.App().Test(httptest.NewRequest("DELETE", "/users/1", nil))
+			So(err, ShouldBeNil)
+			So(resp.StatusCode, ShouldEqual, http.StatusOK)
+		})
+
+		Convey("A cloned operation should be registered under another route", func() {
+			v1 := engine.Get("/v1/items", ok).SetOperationID("list-items").AddTags("items")
+			v2 := v1.Clone().Route(http.MethodGet, "/v2/items").SetOperationID("list-items-v2").AddTags("v2")
+			v1.OK()
+			v2.OK()
+			So(engine.OpenAPI().Paths.Value("/v1/items").Get.Tags, ShouldResemble, []string{"items"})
+			So(engine.OpenAPI().Paths.Value("/v2/items").Get.Tags, ShouldResemble, []string{"items", "v2"})
+			So(engine.OpenAPI().Paths.Value("/v2/items").Get.Summary, ShouldEqual, "GET /v2/items")
+		})
+
+		Convey("Registering an unrouted template should panic", func() {
+			So(func() { engine.OperationTemplate().OK() }, ShouldPanicWith, "the operation template must be routed with Route before OK")
+		})
+	})
+}
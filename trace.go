@@ -0,0 +1,108 @@
+package soda
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"regexp"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+)
+
+// Headers propagating the request ID and the W3C trace context.
+const (
+	HeaderRequestID   = fiber.HeaderXRequestID
+	HeaderTraceParent = "traceparent"
+)
+
+const (
+	// KeyRequestID is the key of the request ID in the context locals.
+	KeyRequestID ck = "soda::request-id"
+	// KeyTraceParent is the key of the traceparent of the request in the context locals.
+	KeyTraceParent ck = "soda::traceparent"
+)
+
+// regexTraceParent matches the version 00 of the traceparent header, see https://www.w3.org/TR/trace-context/.
+var regexTraceParent = regexp.MustCompile(`^00-([0-9a-f]{32})-[0-9a-f]{16}-([0-9a-f]{2})$`)
+
+// UseTraceContext accepts or creates the request ID and the traceparent of the requests of the operations
+// finalized afterwards, stores them in the context locals and sets them as response headers, which are
+// documented on every operation.
+// They are also set as request headers, so that the input can bind them, e.g. with `header:"X-Request-ID"`.
+func (e *Engine) UseTraceContext() *Engine {
+	e.traceContext = true
+	return e
+}
+
+// RequestID returns the request ID of the request, set by UseTraceContext.
+func RequestID(c *fiber.Ctx) string {
+	id, _ := c.Locals(KeyRequestID).(string)
+	return id
+}
+
+// TraceParent returns the traceparent of the request, set by UseTraceContext.
+func TraceParent(c *fiber.Ctx) string {
+	traceParent, _ := c.Locals(KeyTraceParent).(string)
+	return traceParent
+}
+
+// propagateTraceContext is the handler of the trace context.
+func propagateTraceContext(c *fiber.Ctx) error {
+	id := c.Get(HeaderRequestID)
+	if id == "" {
+		id = newID()
+		c.Request().Header.Set(HeaderRequestID, id)
+	}
+
+	// The trace is continued with a new span, or started when the client didn't send a valid traceparent.
+	traceID, flags := newID(), "01"
+	if m := regexTraceParent.FindStringSubmatch(c.Get(HeaderTraceParent)); m != nil && m[1] != "00000000000000000000000000000000" {
+		traceID, flags = m[1], m[2]
+	}
+	traceParent := "00-" + traceID + "-" + newSpanID() + "-" + flags
+	c.Request().Header.Set(HeaderTraceParent, traceParent)
+
+	c.Locals(KeyRequestID, id)
+	c.Locals(KeyTraceParent, traceParent)
+	c.Set(HeaderRequestID, id)
+	c.Set(HeaderTraceParent, traceParent)
+	return c.Next()
+}
+
+// newSpanID returns a random span ID.
+func newSpanID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// documentTraceContext documents the trace context headers of the requests and the responses of the operation.
+func (op *OperationBuilder) documentTraceContext() {
+	parameters := []*openapi3.Parameter{
+		openapi3.NewHeaderParameter(HeaderRequestID).
+			WithDescription("The ID of the request, generated when not provided.").
+			WithSchema(openapi3.NewStringSchema()),
+		openapi3.NewHeaderParameter(HeaderTraceParent).
+			WithDescription("The W3C trace context of the request.").
+			WithSchema(openapi3.NewStringSchema().WithPattern(regexTraceParent.String())),
+	}
+	for _, parameter := range parameters {
+		if op.operation.Parameters.GetByInAndName(parameter.In, parameter.Name) == nil {
+			op.operation.AddParameter(parameter)
+		}
+	}
+	for _, response := range op.operation.Responses.Map() {
+		if response.Value == nil {
+			continue
+		}
+		if response.Value.Headers == nil {
+			response.Value.Headers = make(openapi3.Headers)
+		}
+		for _, parameter := range parameters {
+			response.Value.Headers[parameter.Name] = &openapi3.HeaderRef{Value: &openapi3.Header{Parameter: openapi3.Parameter{
+				Description: parameter.Description,
+				Schema:      parameter.Schema,
+			}}}
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package soda_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestTraceContext(t *testing.T) {
+	Convey("Given an engine propagating the trace context", t, func() {
+		type input struct {
+			RequestID string `header:"X-Request-ID"`
+		}
+		engine := soda.New().UseTraceContext()
+		engine.Get("/ping", func(c *fiber.Ctx) error {
+			return c.SendString(soda.GetInput[input](c).RequestID + " " + soda.RequestID(c))
+		}).SetInput(&input{}).AddJSONResponse(http.StatusOK, nil).OK()
+
+		Convey("The headers should be documented", func() {
+			operation := engine.OpenAPI().Paths.Find("/ping").Get
+			So(operation.Parameters, ShouldHaveLength, 2)
+			So(operation.Parameters.GetByInAndName("header", "traceparent"), ShouldNotBeNil)
+			headers := operation.Responses.Status(http.StatusOK).Value.Headers
+			So(headers, ShouldContainKey, "X-Request-ID")
+			So(headers, ShouldContainKey, "traceparent")
+		})
+
+		Convey("The request ID and the trace should be created", func() {
+			resp, _ := engine.App().Test(httptest.NewRequest("GET", "/ping", nil))
+			id := resp.Header.Get("X-Request-ID")
+			So(id, ShouldHaveLength, 32)
+			body, _ := io.ReadAll(resp.Body)
+			So(string(body), ShouldEqual, id+" "+id)
+			So(regexp.MustCompile(`^00-[0-9a-f]{32}-[0-9a-f]{16}-01$`).MatchString(resp.Header.Get("traceparent")), ShouldBeTrue)
+		})
+
+		Convey("The provided request ID and trace should be propagated", func() {
+			req := httptest.NewRequest("GET", "/ping", nil)
+			req.Header.Set("X-Request-ID", "req-1")
+			req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00")
+			resp, _ := engine.App().Test(req)
+			So(resp.Header.Get("X-Request-ID"), ShouldEqual, "req-1")
+			traceParent := resp.Header.Get("traceparent")
+			So(traceParent, ShouldStartWith, "00-4bf92f3577b34da6a3ce929d0e0e4736-")
+			So(traceParent, ShouldEndWith, "-00")
+			So(traceParent, ShouldNotContainSubstring, "00f067aa0ba902b7")
+		})
+	})
+}
@@ -0,0 +1,55 @@
+package soda
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+// KeyTx is the key of the transaction of the request in the context locals, see Transactional.
+const KeyTx ck = "soda::tx"
+
+// Tx is a transaction, such as a *sql.Tx.
+type Tx interface {
+	Commit() error
+	Rollback() error
+}
+
+// TxProvider opens a transaction for a request, e.g. with db.BeginTx(c.UserContext(), nil).
+type TxProvider func(c *fiber.Ctx) (Tx, error)
+
+// Transactional runs the handlers of the operation in a transaction opened by the provider once the input is
+// bound. The transaction is committed if the handlers succeed with a 2xx status, and rolled back if they return
+// an error, panic or respond with another status. The handlers get it with GetTx.
+func (op *OperationBuilder) Transactional(provider TxProvider) *OperationBuilder {
+	op.txProvider = provider
+	return op
+}
+
+// GetTx returns the transaction of the request, opened by Transactional, as its concrete type, e.g. *sql.Tx.
+func GetTx[T Tx](c *fiber.Ctx) T {
+	tx, _ := c.Locals(KeyTx).(T)
+	return tx
+}
+
+// transact is the handler running the next handlers in a transaction.
+func (op *OperationBuilder) transact(c *fiber.Ctx) error {
+	tx, err := op.txProvider(c)
+	if err != nil {
+		return err
+	}
+	c.Locals(KeyTx, tx)
+	committed := false
+	defer func() {
+		if !committed {
+			_ = tx.Rollback()
+		}
+	}()
+
+	if err := c.Next(); err != nil {
+		return err
+	}
+	if status := c.Response().StatusCode(); status < 200 || status >= 300 {
+		return nil
+	}
+	committed = true
+	return tx.Commit()
+}
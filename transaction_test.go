@@ -0,0 +1,95 @@
+package soda_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/recover"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type fakeTx struct {
+	committed, rolledBack bool
+	commitErr             error
+}
+
+func (tx *fakeTx) Commit() error {
+	tx.committed = true
+	return tx.commitErr
+}
+
+func (tx *fakeTx) Rollback() error {
+	tx.rolledBack = true
+	return nil
+}
+
+func TestTransactional(t *testing.T) {
+	Convey("Given a transactional operation", t, func() {
+		var tx *fakeTx
+		var seen *fakeTx
+		provider := func(c *fiber.Ctx) (soda.Tx, error) {
+			if c.Query("outcome") == "unavailable" {
+				return nil, fiber.ErrServiceUnavailable
+			}
+			tx = &fakeTx{}
+			if c.Query("outcome") == "commit-error" {
+				tx.commitErr = errors.New("serialization failure")
+			}
+			return tx, nil
+		}
+		engine := soda.New()
+		engine.App().Use(recover.New())
+		engine.Post("/orders", func(c *fiber.Ctx) error {
+			seen = soda.GetTx[*fakeTx](c)
+			switch c.Query("outcome") {
+			case "error":
+				return errors.New("failed")
+			case "conflict":
+				return c.SendStatus(http.StatusConflict)
+			case "panic":
+				panic("boom")
+			}
+			return c.SendStatus(http.StatusCreated)
+		}).Transactional(provider).OK()
+
+		call := func(outcome string) int {
+			resp, err := engine.App().Test(httptest.NewRequest("POST", "/orders?outcome="+outcome, nil))
+			So(err, ShouldBeNil)
+			return resp.StatusCode
+		}
+
+		Convey("The transaction should be exposed and committed on success", func() {
+			So(call("ok"), ShouldEqual, http.StatusCreated)
+			So(seen, ShouldPointTo, tx)
+			So(tx.committed, ShouldBeTrue)
+			So(tx.rolledBack, ShouldBeFalse)
+		})
+
+		Convey("The transaction should be rolled back on error or non-2xx status", func() {
+			So(call("error"), ShouldEqual, http.StatusInternalServerError)
+			So(tx.committed, ShouldBeFalse)
+			So(tx.rolledBack, ShouldBeTrue)
+
+			So(call("conflict"), ShouldEqual, http.StatusConflict)
+			So(tx.committed, ShouldBeFalse)
+			So(tx.rolledBack, ShouldBeTrue)
+		})
+
+		Convey("The transaction should be rolled back on panic", func() {
+			So(call("panic"), ShouldEqual, http.StatusInternalServerError)
+			So(tx.rolledBack, ShouldBeTrue)
+		})
+
+		Convey("The errors of the provider and of the commit should be returned", func() {
+			So(call("unavailable"), ShouldEqual, http.StatusServiceUnavailable)
+			So(seen, ShouldBeNil)
+			So(call("commit-error"), ShouldEqual, http.StatusInternalServerError)
+			So(tx.committed, ShouldBeTrue)
+			So(tx.rolledBack, ShouldBeFalse)
+		})
+	})
+}
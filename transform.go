@@ -0,0 +1,42 @@
+package soda
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+type (
+	// RequestTransformer transforms the bound input in place before it reaches the handler,
+	// e.g. to decrypt fields or to map legacy field aliases.
+	RequestTransformer func(ctx *fiber.Ctx, input any) error
+
+	// ResponseTransformer transforms the output of the handler before it is serialized by Respond,
+	// returning the value to serialize instead.
+	ResponseTransformer func(ctx *fiber.Ctx, status int, output any) (any, error)
+)
+
+// TransformRequest adds a transformer of the input, run in order after the AfterBind hooks.
+func (op *OperationBuilder) TransformRequest(fn RequestTransformer) *OperationBuilder {
+	op.requestTransformers = append(op.requestTransformers, fn)
+	return op
+}
+
+// TransformResponse adds a transformer of the output, run in order on the values sent with Respond.
+func (op *OperationBuilder) TransformResponse(fn ResponseTransformer) *OperationBuilder {
+	op.responseTransformers = append(op.responseTransformers, fn)
+	return op
+}
+
+// transformResponse applies the response transformers of the operation handling the request to the output.
+func transformResponse(ctx *fiber.Ctx, status int, output any) (any, error) {
+	op, ok := ctx.Locals(keyOperationBuilder).(*OperationBuilder)
+	if !ok {
+		return output, nil
+	}
+	for _, fn := range op.responseTransformers {
+		var err error
+		if output, err = fn(ctx, status, output); err != nil {
+			return nil, err
+		}
+	}
+	return output, nil
+}
@@ -0,0 +1,51 @@
+package soda_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestTransform(t *testing.T) {
+	Convey("Given an operation with request and response transformers", t, func() {
+		type account struct {
+			Name   string `json:"name"`
+			Secret string `json:"secret"`
+		}
+		type input struct {
+			Body account `body:"json"`
+		}
+		engine := soda.New()
+		engine.Post("/accounts", func(c *fiber.Ctx) error {
+			in := soda.GetInput[input](c)
+			return soda.Respond(c, http.StatusCreated, in.Body)
+		}).
+			SetInput(&input{}).
+			TransformRequest(func(ctx *fiber.Ctx, in any) error {
+				in.(*input).Body.Secret = strings.TrimPrefix(in.(*input).Body.Secret, "enc:")
+				return nil
+			}).
+			TransformResponse(func(ctx *fiber.Ctx, status int, output any) (any, error) {
+				acc := output.(account)
+				acc.Secret = "enc:" + strings.ToUpper(acc.Secret)
+				return acc, nil
+			}).
+			OK()
+
+		Convey("Both should apply around the handler", func() {
+			req := httptest.NewRequest("POST", "/accounts", strings.NewReader(`{"name":"a","secret":"enc:s3"}`))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := engine.App().Test(req)
+			So(err, ShouldBeNil)
+			So(resp.StatusCode, ShouldEqual, http.StatusCreated)
+			body, _ := io.ReadAll(resp.Body)
+			So(string(body), ShouldEqual, `{"name":"a","secret":"enc:S3"}`)
+		})
+	})
+}
@@ -1,9 +1,15 @@
 package soda
 
 import (
+	"crypto/rand"
+	"encoding"
+	"encoding/hex"
 	"fmt"
+	"net/http"
 	"path"
+	"reflect"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -84,6 +90,10 @@ func derefSchema(doc *openapi3.T, schemaRef *openapi3.SchemaRef) *openapi3.Schem
 	if schemaRef.Value != nil {
 		return schemaRef.Value
 	}
+	// The external schemas are unknown.
+	if isExternalRef(schemaRef) {
+		return &openapi3.Schema{}
+	}
 	if schemaRef.Ref != "" {
 		full := schemaRef.Ref
 		name := path.Base(full)
@@ -100,3 +110,42 @@ func derefSchema(doc *openapi3.T, schemaRef *openapi3.SchemaRef) *openapi3.Schem
 func GetInput[T any](c *fiber.Ctx) *T {
 	return c.Locals(KeyInput).(*T)
 }
+
+// newID generates a random identifier.
+func newID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// sortedKeys returns the keys of the map in order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// derefType returns the type pointed to by the pointer types.
+func derefType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+// isNestedStruct reports whether the struct type is bound and documented field by field in the parameters,
+// unlike the well-known structs and those decoded from text or documenting themselves.
+func isNestedStruct(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && t != wnTime &&
+		!reflect.PointerTo(t).Implements(textUnmarshaler) && !t.Implements(jsonSchemaFunc) && !reflect.PointerTo(t).Implements(jsonSchemaFunc)
+}
+
+var textUnmarshaler = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// isNoContent reports whether responses with the status have no body.
+func isNoContent(status int) bool {
+	return status == http.StatusNoContent || status == http.StatusNotModified
+}
@@ -0,0 +1,181 @@
+package soda
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// fieldRule checks the value of a field of the struct holding it, returning the reason why it is invalid, if any.
+type fieldRule func(parent, value reflect.Value) string
+
+// fieldRuleBuilder builds the rule of a prop of the field, e.g. `oai:"requiredIf=type=card"`.
+type fieldRuleBuilder func(t reflect.Type, f reflect.StructField, value string) fieldRule
+
+// fieldRuleBuilders are the builders of the rules enforced on the request bodies, by prop.
+var fieldRuleBuilders = map[string]fieldRuleBuilder{}
+
+// validatedField is a field of a struct, checked by its rules and walked for the rules of its nested values.
+type validatedField struct {
+	index []int
+	name  string
+	rules []fieldRule
+	// nested is set when the values of the field may hold rules.
+	nested bool
+	// inline is set for the embedded interfaces, whose values are inlined into the struct by its UnmarshalJSON.
+	inline bool
+}
+
+// structValidator enforces the rules of the fields of a struct type and its cross-field constraints.
+type structValidator struct {
+	fields      []validatedField
+	constraints []Constraint
+}
+
+// validators caches the validators by struct type, nil for the types without any rule.
+var (
+	validators   sync.Map
+	validatorsMu sync.Mutex
+)
+
+// validatorOf returns the validator of the struct type, or nil if neither it nor its nested types have rules.
+func validatorOf(t reflect.Type) *structValidator {
+	if v, ok := validators.Load(t); ok {
+		return v.(*structValidator)
+	}
+	validatorsMu.Lock()
+	defer validatorsMu.Unlock()
+	return buildValidator(t)
+}
+
+// buildValidator builds the validator of the struct type. The caller must hold validatorsMu.
+func buildValidator(t reflect.Type) *structValidator {
+	if v, ok := validators.Load(t); ok {
+		return v.(*structValidator)
+	}
+	// The validator is cached before its fields are resolved, so that the recursive types refer to it,
+	// but only published to the other goroutines once built.
+	v := &structValidator{}
+	building[t] = v
+	defer delete(building, t)
+	for _, f := range reflect.VisibleFields(t) {
+		if f.Anonymous && f.Type.Kind() == reflect.Interface {
+			v.fields = append(v.fields, validatedField{index: f.Index, nested: true, inline: true})
+			continue
+		}
+		if f.Anonymous || !f.IsExported() || f.Tag.Get("json") == "-" {
+			continue
+		}
+		field := newTagsResolver(f)
+		vf := validatedField{index: f.Index, name: field.name("json"), nested: holdsRules(f.Type)}
+		for _, prop := range sortedKeys(field.pairs) {
+			if build, ok := fieldRuleBuilders[prop]; ok {
+				vf.rules = append(vf.rules, build(t, f, field.pairs[prop]))
+			}
+		}
+		if len(vf.rules) != 0 || vf.nested {
+			v.fields = append(v.fields, vf)
+		}
+	}
+	v.constraints = constraintsOf(t)
+	if len(v.fields) == 0 && len(v.constraints) == 0 {
+		v = nil
+	}
+	validators.Store(t, v)
+	return v
+}
+
+// building holds the validators being built, guarded by validatorsMu.
+var building = map[reflect.Type]*structValidator{}
+
+// holdsRules reports whether the values of the type may hold structs with rules. The caller must hold validatorsMu.
+func holdsRules(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+		t = t.Elem()
+	}
+	// The interfaces are walked for the rules of their dynamic values, e.g. resolved by an UnmarshalJSON method.
+	if t.Kind() == reflect.Interface {
+		return true
+	}
+	if t.Kind() != reflect.Struct || t == wnTime {
+		return false
+	}
+	if _, ok := building[t]; ok {
+		return true
+	}
+	return buildValidator(t) != nil
+}
+
+// buildRules builds the rules of the type and of its nested types, so that their props are checked
+// at registration rather than on the first request.
+func buildRules(t reflect.Type) {
+	validatorsMu.Lock()
+	defer validatorsMu.Unlock()
+	holdsRules(t)
+}
+
+// validate checks the value against the rules of its type and of its nested values, returning the JSON pointer
+// of the first invalid value and the reason why it is invalid.
+func validate(v reflect.Value, pointer string) (string, string) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return "", ""
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		validator := validatorOf(v.Type())
+		if validator == nil {
+			return "", ""
+		}
+		for _, field := range validator.fields {
+			value := v.FieldByIndex(field.index)
+			for _, rule := range field.rules {
+				if reason := rule(v, value); reason != "" {
+					return pointer + "/" + escapePointer(field.name), reason
+				}
+			}
+			if field.inline {
+				if p, reason := validate(value, pointer); reason != "" {
+					return p, reason
+				}
+			} else if field.nested {
+				if p, reason := validate(value, pointer+"/"+escapePointer(field.name)); reason != "" {
+					return p, reason
+				}
+			}
+		}
+		return checkConstraints(v, validator.constraints, pointer)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if p, reason := validate(v.Index(i), pointer+"/"+strconv.Itoa(i)); reason != "" {
+				return p, reason
+			}
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			key := escapePointer(fmt.Sprint(iter.Key().Interface()))
+			if p, reason := validate(iter.Value(), pointer+"/"+key); reason != "" {
+				return p, reason
+			}
+		}
+	}
+	return "", ""
+}
+
+// validateBody enforces the rules of the bound request body, returning an HTTPError with the status of the bind
+// errors locating the invalid value.
+func (op *OperationBuilder) validateBody(c *fiber.Ctx, body reflect.Value) error {
+	pointer, reason := validate(body, "")
+	if reason == "" {
+		return nil
+	}
+	httpErr := NewError(op.bindErrorStatus(), MsgInvalidValue, Translate(c, MsgInvalidValue, pointer, reason))
+	httpErr.Pointer = pointer
+	return httpErr
+}
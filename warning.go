@@ -0,0 +1,65 @@
+package soda
+
+import (
+	"fmt"
+	"maps"
+	"strconv"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+)
+
+// Warn codes of the Warning header, as per RFC 7234.
+const (
+	// WarnMiscellaneous is a warning about the response which a cache would discard once it is revalidated.
+	WarnMiscellaneous = 199
+	// WarnMiscellaneousPersistent is a warning that persists, e.g. a deprecation or a quota notice.
+	WarnMiscellaneousPersistent = 299
+)
+
+// warningDescription documents the Warning header of the responses.
+const warningDescription = `Advisory notices, e.g. 299 - "the operation is deprecated", which don't fail the request.`
+
+// AddWarning appends a Warning header to the response, e.g. 299 - "the quota is almost exhausted", advising the
+// clients without failing the request. The operations whose handlers warn should document it with AddAdvisoryHeader.
+func AddWarning(c *fiber.Ctx, code int, text string) {
+	c.Append(fiber.HeaderWarning, strconv.Itoa(code)+" - "+strconv.Quote(text))
+}
+
+// AddAdvisoryHeader documents an advisory header on every response of the operation, such as Warning or a custom
+// quota notice, set by its handlers.
+func (op *OperationBuilder) AddAdvisoryHeader(name, description string) *OperationBuilder {
+	if op.advisoryHeaders == nil {
+		op.advisoryHeaders = make(map[string]string)
+	}
+	op.advisoryHeaders[name] = description
+	return op
+}
+
+// warnDeprecated warns the clients of a deprecated operation.
+func (op *OperationBuilder) warnDeprecated(c *fiber.Ctx) error {
+	AddWarning(c, WarnMiscellaneousPersistent, fmt.Sprintf("the operation %s %s is deprecated", op.method, op.patternFull))
+	return c.Next()
+}
+
+// documentAdvisoryHeaders documents the advisory headers on every response of the operation.
+func (op *OperationBuilder) documentAdvisoryHeaders() {
+	for status, response := range op.operation.Responses.Map() {
+		if response.Value == nil {
+			continue
+		}
+		// The response may be shared with other operations.
+		documented := *response.Value
+		documented.Headers = maps.Clone(response.Value.Headers)
+		if documented.Headers == nil {
+			documented.Headers = make(openapi3.Headers)
+		}
+		for _, name := range sortedKeys(op.advisoryHeaders) {
+			documented.Headers[name] = &openapi3.HeaderRef{Value: &openapi3.Header{Parameter: openapi3.Parameter{
+				Description: op.advisoryHeaders[name],
+				Schema:      openapi3.NewStringSchema().NewRef(),
+			}}}
+		}
+		op.operation.Responses.Set(status, &openapi3.ResponseRef{Value: &documented})
+	}
+}
@@ -0,0 +1,70 @@
+package soda_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestWarning(t *testing.T) {
+	Convey("Given operations warning their clients", t, func() {
+		engine := soda.New()
+		engine.Get("/quota", func(c *fiber.Ctx) error {
+			soda.AddWarning(c, soda.WarnMiscellaneousPersistent, "the quota is almost exhausted")
+			c.Set("X-Quota-Remaining", "3")
+			return c.SendString("ok")
+		}).
+			SetResponseDescription(http.StatusOK, "The quota").
+			AddAdvisoryHeader("Warning", "The quota notices.").
+			AddAdvisoryHeader("X-Quota-Remaining", "The number of requests left in the quota.").
+			OK()
+		engine.Get("/legacy", func(c *fiber.Ctx) error { return c.SendString("ok") }).
+			SetResponseDescription(http.StatusOK, "The legacy").SetDeprecated(true).OK()
+		engine.Get("/items", func(c *fiber.Ctx) error { return c.SendString("ok") }).SetInput(&struct {
+			PageSize int `query:"page_size" oai:"alias=per_page"`
+		}{}).SetResponseDescription(http.StatusOK, "The items").OK()
+		api := engine.Group("/api").AddJSONResponse(http.StatusBadRequest, "")
+		api.Get("/old", func(c *fiber.Ctx) error { return c.SendString("ok") }).SetDeprecated(true).OK()
+		api.Get("/new", func(c *fiber.Ctx) error { return c.SendString("ok") }).OK()
+		get := func(target string) *http.Response {
+			resp, err := engine.App().Test(httptest.NewRequest("GET", target, nil))
+			So(err, ShouldBeNil)
+			return resp
+		}
+
+		Convey("The handlers should attach warnings", func() {
+			resp := get("/quota")
+			So(resp.Header.Get("Warning"), ShouldEqual, `299 - "the quota is almost exhausted"`)
+			So(resp.Header.Get("X-Quota-Remaining"), ShouldEqual, "3")
+		})
+
+		Convey("The deprecated operations should warn", func() {
+			So(get("/legacy").Header.Get("Warning"), ShouldEqual, `299 - "the operation GET /legacy is deprecated"`)
+		})
+
+		Convey("The deprecated query parameters should warn when used", func() {
+			So(get("/items?per_page=5").Header.Get("Warning"), ShouldEqual,
+				`299 - "the query parameter per_page is deprecated, use page_size"`)
+			So(get("/items?page_size=5").Header.Get("Warning"), ShouldBeEmpty)
+		})
+
+		Convey("The advisory headers should be documented", func() {
+			paths := engine.OpenAPI().Paths
+			headers := paths.Value("/quota").Get.Responses.Status(http.StatusOK).Value.Headers
+			So(headers, ShouldContainKey, "Warning")
+			So(headers, ShouldContainKey, "X-Quota-Remaining")
+			So(paths.Value("/legacy").Get.Responses.Status(http.StatusOK).Value.Headers, ShouldContainKey, "Warning")
+			So(paths.Value("/items").Get.Responses.Status(http.StatusOK).Value.Headers, ShouldContainKey, "Warning")
+		})
+
+		Convey("The responses shared by the operations should not be changed", func() {
+			paths := engine.OpenAPI().Paths
+			So(paths.Value("/api/old").Get.Responses.Status(http.StatusBadRequest).Value.Headers, ShouldContainKey, "Warning")
+			So(paths.Value("/api/new").Get.Responses.Status(http.StatusBadRequest).Value.Headers, ShouldNotContainKey, "Warning")
+		})
+	})
+}
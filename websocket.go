@@ -0,0 +1,57 @@
+package soda
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// websocketExtension documents the messages of WebSocket endpoints.
+const websocketExtension = "x-websocket"
+
+// WebSocket registers a WebSocket endpoint, handled by a fiber WebSocket handler such as the one created with
+// github.com/gofiber/contrib/websocket. Requests that are not WebSocket upgrades are answered with
+// 426 Upgrade Required. The endpoint is documented as a GET operation with a 101 Switching Protocols response
+// and the x-websocket extension, whose messages are set with SetWebSocketMessages.
+func (r *Router) WebSocket(pattern string, handlers ...fiber.Handler) *OperationBuilder {
+	handlers = append([]fiber.Handler{requireWebSocketUpgrade}, handlers...)
+	op := r.Get(pattern, handlers...)
+	op.operation.AddResponse(http.StatusSwitchingProtocols, op.route.gen.GenerateResponse(http.StatusSwitchingProtocols, nil, "", ""))
+	op.operation.AddResponse(http.StatusUpgradeRequired, op.route.gen.GenerateResponse(http.StatusUpgradeRequired, nil, "", ""))
+	op.setWebSocketExtension(map[string]any{})
+	return op
+}
+
+// SetWebSocketMessages documents the JSON models of the messages received from and sent to the clients
+// of a WebSocket endpoint. Either can be nil.
+func (op *OperationBuilder) SetWebSocketMessages(in, out any) (self *OperationBuilder) {
+	defer op.recoverMisuse(&self)
+	messages := make(map[string]any)
+	if in != nil {
+		messages["in"] = op.route.gen.generateSchemaRef(nil, reflect.TypeOf(in), "json")
+	}
+	if out != nil {
+		messages["out"] = op.route.gen.generateSchemaRef(nil, reflect.TypeOf(out), "json")
+	}
+	op.setWebSocketExtension(map[string]any{"messages": messages})
+	return op
+}
+
+func (op *OperationBuilder) setWebSocketExtension(value map[string]any) {
+	if op.operation.Extensions == nil {
+		op.operation.Extensions = make(map[string]any)
+	}
+	op.operation.Extensions[websocketExtension] = value
+}
+
+// requireWebSocketUpgrade answers 426 Upgrade Required to the requests which are not WebSocket upgrades.
+func requireWebSocketUpgrade(c *fiber.Ctx) error {
+	if !strings.EqualFold(c.Get(fiber.HeaderUpgrade), "websocket") ||
+		!strings.Contains(strings.ToLower(c.Get(fiber.HeaderConnection)), "upgrade") {
+		c.Set(fiber.HeaderUpgrade, "websocket")
+		return fiber.NewError(http.StatusUpgradeRequired, http.StatusText(http.StatusUpgradeRequired))
+	}
+	return c.Next()
+}
@@ -0,0 +1,49 @@
+package soda_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestWebSocket(t *testing.T) {
+	Convey("Given a WebSocket endpoint", t, func() {
+		type chatIn struct {
+			Text string `json:"text"`
+		}
+		type chatOut struct {
+			From string `json:"from"`
+			Text string `json:"text"`
+		}
+		engine := soda.New()
+		engine.WebSocket("/chat", func(c *fiber.Ctx) error {
+			return c.SendStatus(http.StatusSwitchingProtocols)
+		}).SetWebSocketMessages(chatIn{}, chatOut{}).OK()
+
+		Convey("It should be documented with its messages", func() {
+			operation := engine.OpenAPI().Paths.Find("/chat").Get
+			So(operation.Responses.Status(http.StatusSwitchingProtocols), ShouldNotBeNil)
+			spec, err := engine.OpenAPI().MarshalJSON()
+			So(err, ShouldBeNil)
+			So(string(spec), ShouldContainSubstring, `"x-websocket":{"messages":{"in":{"$ref":"#/components/schemas/soda_test.chatIn"},"out":{"$ref":"#/components/schemas/soda_test.chatOut"}}}`)
+		})
+
+		Convey("Requests which are not upgrades should be rejected", func() {
+			resp, _ := engine.App().Test(httptest.NewRequest("GET", "/chat", nil))
+			So(resp.StatusCode, ShouldEqual, http.StatusUpgradeRequired)
+			So(resp.Header.Get("Upgrade"), ShouldEqual, "websocket")
+		})
+
+		Convey("Upgrade requests should reach the handler", func() {
+			req := httptest.NewRequest("GET", "/chat", nil)
+			req.Header.Set("Connection", "Upgrade")
+			req.Header.Set("Upgrade", "websocket")
+			resp, _ := engine.App().Test(req)
+			So(resp.StatusCode, ShouldEqual, http.StatusSwitchingProtocols)
+		})
+	})
+}
@@ -0,0 +1,46 @@
+package soda
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// xmlNameTag is the name tag of schemas generated for XML media types.
+const xmlNameTag = "xml"
+
+// xmlField resolves the element name of a struct field from its xml tag, along with the tag options.
+func xmlField(f reflect.StructField) (string, []string) {
+	name, opts, _ := strings.Cut(f.Tag.Get(xmlNameTag), ",")
+	if name == "" {
+		name = f.Name
+	}
+	return name, strings.Split(opts, ",")
+}
+
+// injectXMLTags injects the XML object derived from the xml tag of the field into its schema,
+// and returns the property name of the field.
+// `xml:"name,attr"` documents an attribute, `xml:"items>item"` documents a wrapped array.
+func injectXMLTags(f reflect.StructField, schemaRef *openapi3.SchemaRef) string {
+	name, opts := xmlField(f)
+	wrapper, element, wrapped := strings.Cut(name, ">")
+
+	// Referenced schemas are shared, the property name is all that can be documented.
+	if schemaRef.Ref != "" || schemaRef.Value == nil {
+		return wrapper
+	}
+	schema := schemaRef.Value
+	for _, opt := range opts {
+		if opt == "attr" {
+			schema.XML = &openapi3.XML{Attribute: true}
+		}
+	}
+	if wrapped && schema.Type.Is(typeArray) {
+		schema.XML = &openapi3.XML{Name: wrapper, Wrapped: true}
+		if schema.Items != nil && schema.Items.Ref == "" && schema.Items.Value != nil {
+			schema.Items.Value.XML = &openapi3.XML{Name: element}
+		}
+	}
+	return wrapper
+}
@@ -0,0 +1,70 @@
+package soda_test
+
+import (
+	"encoding/xml"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/neo-f/soda/v3"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type xmlOrder struct {
+	XMLName xml.Name `xml:"order"`
+	ID      string   `xml:"id,attr"   json:"id"`
+	Items   []string `xml:"items>item" json:"items"`
+	Note    string   `xml:"note"      json:"note"`
+	Secret  string   `xml:"-"         json:"secret"`
+}
+
+func TestXML(t *testing.T) {
+	Convey("Given an operation consuming and producing XML", t, func() {
+		type input struct {
+			Body xmlOrder `body:"xml"`
+		}
+		engine := soda.New()
+		engine.Post("/orders", func(c *fiber.Ctx) error {
+			in := soda.GetInput[input](c)
+			return soda.Respond(c, http.StatusOK, in.Body)
+		}).
+			SetInput(input{}).
+			AddResponse(http.StatusOK, xmlOrder{}, "application/xml", "application/json").
+			OK()
+
+		Convey("The request body should be documented as XML", func() {
+			body := engine.OpenAPI().Paths.Find("/orders").Post.RequestBody.Value
+			So(body.Content, ShouldContainKey, "application/xml")
+			schema := body.Content["application/xml"].Schema.Value
+			So(schema.XML.Name, ShouldEqual, "order")
+			So(schema.Properties["id"].Value.XML.Attribute, ShouldBeTrue)
+			So(schema.Properties["items"].Value.XML.Wrapped, ShouldBeTrue)
+			So(schema.Properties["items"].Value.Items.Value.XML.Name, ShouldEqual, "item")
+			So(schema.Properties, ShouldNotContainKey, "XMLName")
+			So(schema.Properties, ShouldNotContainKey, "-")
+			So(schema.Properties, ShouldNotContainKey, "Secret")
+			So(schema.Required, ShouldNotContain, "-")
+		})
+
+		Convey("The XML and JSON response schemas should be distinct components", func() {
+			content := engine.OpenAPI().Paths.Find("/orders").Post.Responses.Status(200).Value.Content
+			So(content["application/xml"].Schema.Ref, ShouldEqual, "#/components/schemas/soda_test.xmlOrderXML")
+			So(content["application/json"].Schema.Ref, ShouldEqual, "#/components/schemas/soda_test.xmlOrder")
+			So(content["application/json"].Schema.Value.Properties, ShouldContainKey, "secret")
+		})
+
+		Convey("An XML body should be bound", func() {
+			payload := `<order id="1"><items><item>a</item><item>b</item></items><note>hi</note></order>`
+			request, _ := http.NewRequest("POST", "/orders", strings.NewReader(payload))
+			request.Header.Add("Content-Type", "application/xml")
+			request.Header.Add("Accept", "application/xml")
+			response, err := engine.App().Test(request)
+			So(err, ShouldBeNil)
+			So(response.StatusCode, ShouldEqual, http.StatusOK)
+			body, _ := io.ReadAll(response.Body)
+			So(string(body), ShouldEqual, payload)
+		})
+	})
+}